@@ -0,0 +1,46 @@
+package accesstoken
+
+import (
+	"context"
+	"fmt"
+
+	appErrors "eztrip/api-go/errors"
+
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+const (
+	ErrCodeInsufficientScope = "INSUFFICIENT_SCOPE"
+)
+
+type tokenContextKey struct{}
+
+// WithToken stores the access token a request was authenticated with
+func WithToken(ctx context.Context, token *Token) context.Context {
+	return context.WithValue(ctx, tokenContextKey{}, token)
+}
+
+// FromContext returns the access token a request was authenticated with, or nil for
+// requests authenticated otherwise
+func FromContext(ctx context.Context) *Token {
+	token, _ := ctx.Value(tokenContextKey{}).(*Token)
+	return token
+}
+
+// RequireScope checks that a request authenticated with an access token was granted
+// the scope. Requests authenticated otherwise have every scope.
+func RequireScope(ctx context.Context, scope Scope) error {
+	token := FromContext(ctx)
+	if token == nil || token.HasScope(scope) {
+		return nil
+	}
+	return InsufficientScopeError(scope)
+}
+
+// InsufficientScopeError returns an error for when an access token lacks a scope
+func InsufficientScopeError(scope Scope) *gqlerror.Error {
+	return appErrors.WithDetails(
+		appErrors.New(ErrCodeInsufficientScope, fmt.Sprintf("Access token is missing the %s scope", scope)),
+		map[string]interface{}{"requiredScope": scope},
+	)
+}
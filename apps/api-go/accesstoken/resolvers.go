@@ -0,0 +1,95 @@
+package accesstoken
+
+import (
+	"context"
+
+	"eztrip/api-go/validation"
+
+	"github.com/google/uuid"
+)
+
+// Resolver handles GraphQL resolver operations for access tokens and service accounts
+type Resolver struct {
+	Service *Service
+}
+
+// NewResolver creates a new access token resolver
+func NewResolver(service *Service) *Resolver {
+	return &Resolver{
+		Service: service,
+	}
+}
+
+// AccessTokens returns the active tokens of the current user
+func (r *Resolver) AccessTokens(ctx context.Context) ([]*Token, error) {
+	return r.Service.ListMine(ctx)
+}
+
+// CreateAccessToken issues a personal access token for the current user
+func (r *Resolver) CreateAccessToken(ctx context.Context, input TokenInput) (*Token, error) {
+	if err := validation.ValidateStruct(input); err != nil {
+		return nil, err
+	}
+
+	return r.Service.Create(ctx, input)
+}
+
+// RevokeAccessToken disables an access token
+func (r *Resolver) RevokeAccessToken(ctx context.Context, id string) (bool, error) {
+	tokenID, err := uuid.Parse(id)
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.Service.Revoke(ctx, tokenID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ServiceAccounts returns all service accounts. Admin only, enforced by @hasRole in the schema.
+func (r *Resolver) ServiceAccounts(ctx context.Context) ([]*ServiceAccount, error) {
+	return r.Service.ListServiceAccounts(ctx)
+}
+
+// ServiceAccountAccessTokens returns the active tokens of a service account
+func (r *Resolver) ServiceAccountAccessTokens(ctx context.Context, account *ServiceAccount) ([]*Token, error) {
+	return r.Service.ListForUser(ctx, account.ID)
+}
+
+// CreateServiceAccount adds a service account. Admin only, enforced by @hasRole in the schema.
+func (r *Resolver) CreateServiceAccount(ctx context.Context, input ServiceAccountInput) (*ServiceAccount, error) {
+	if err := validation.ValidateStruct(input); err != nil {
+		return nil, err
+	}
+
+	return r.Service.CreateServiceAccount(ctx, input)
+}
+
+// DeleteServiceAccount removes a service account. Admin only, enforced by @hasRole in the schema.
+func (r *Resolver) DeleteServiceAccount(ctx context.Context, id string) (bool, error) {
+	accountID, err := uuid.Parse(id)
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.Service.DeleteServiceAccount(ctx, accountID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// CreateServiceAccountToken issues a token for a service account. Admin only, enforced
+// by @hasRole in the schema.
+func (r *Resolver) CreateServiceAccountToken(ctx context.Context, serviceAccountID string, input TokenInput) (*Token, error) {
+	if err := validation.ValidateStruct(input); err != nil {
+		return nil, err
+	}
+
+	accountID, err := uuid.Parse(serviceAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Service.CreateServiceAccountToken(ctx, accountID, input)
+}
@@ -0,0 +1,382 @@
+package accesstoken
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	appErrors "eztrip/api-go/errors"
+	"eztrip/api-go/logger"
+	"eztrip/api-go/rbac"
+	"eztrip/api-go/user"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultExpiresInDays = 90
+
+	// lastUsedInterval limits how often last use is written, so busy tokens don't
+	// cause a write per request
+	lastUsedInterval = time.Minute
+
+	serviceAccountEmailDomain = "service-accounts.invalid"
+	serviceAccountIDPrefix    = "service|"
+)
+
+// Service manages access tokens and service accounts, and authenticates requests
+// made with access tokens
+type Service struct {
+	db *gorm.DB
+}
+
+// NewService creates a new access token service
+func NewService(db *gorm.DB) *Service {
+	return &Service{db: db}
+}
+
+// ListMine returns the active tokens of the current user, newest first
+func (s *Service) ListMine(ctx context.Context) ([]*Token, error) {
+	_, userID, err := user.GetAuthenticatedUser(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+	return s.ListForUser(ctx, userID)
+}
+
+// ListForUser returns the active tokens of a user or service account, newest first
+func (s *Service) ListForUser(ctx context.Context, userID uuid.UUID) ([]*Token, error) {
+	var tokens []*Token
+	err := s.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&tokens).Error
+
+	if err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"user_id": userID,
+			"error":   err.Error(),
+		}).Error("Failed to fetch access tokens")
+		return nil, appErrors.Internal("Failed to fetch access tokens")
+	}
+
+	return tokens, nil
+}
+
+// Create issues a personal access token for the current user. Tokens can't be used
+// to create more tokens, and the admin scope is only granted to admins.
+func (s *Service) Create(ctx context.Context, input TokenInput) (*Token, error) {
+	if FromContext(ctx) != nil {
+		return nil, appErrors.Forbidden("Access tokens can't be created with an access token")
+	}
+
+	_, userID, err := user.GetAuthenticatedUser(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.issue(ctx, userID, userID, input)
+}
+
+// Revoke disables a token. Users can revoke their own tokens; admins can revoke any.
+func (s *Service) Revoke(ctx context.Context, id uuid.UUID) error {
+	_, userID, err := user.GetAuthenticatedUser(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
+	var token Token
+	if err := s.db.WithContext(ctx).First(&token, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return appErrors.NotFound("Access token")
+		}
+		logger.Log.WithFields(logrus.Fields{
+			"access_token_id": id,
+			"error":           err.Error(),
+		}).Error("Failed to fetch access token")
+		return appErrors.Internal("Failed to revoke access token")
+	}
+
+	if token.UserID != userID {
+		if err := rbac.RequireRole(ctx, userID, "admin"); err != nil {
+			// Don't reveal other users' tokens
+			return appErrors.NotFound("Access token")
+		}
+	}
+
+	if err := s.db.WithContext(ctx).Delete(&token).Error; err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"access_token_id": id,
+			"error":           err.Error(),
+		}).Error("Failed to revoke access token")
+		return appErrors.Internal("Failed to revoke access token")
+	}
+
+	logger.Log.WithFields(logrus.Fields{
+		"user_id":         token.UserID,
+		"access_token_id": id,
+		"revoked_by":      userID,
+	}).Info("Access token revoked successfully")
+
+	return nil
+}
+
+// Authenticate finds the owner of an active token. Unknown, revoked and expired
+// tokens, and tokens of deleted or blocked owners, are all rejected the same way.
+func (s *Service) Authenticate(ctx context.Context, raw string) (*Token, *user.User, error) {
+	invalid := appErrors.Unauthorized("Invalid or expired access token")
+
+	var token Token
+	if err := s.db.WithContext(ctx).First(&token, "token_hash = ?", hashToken(raw)).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil, invalid
+		}
+		logger.Log.WithField("error", err.Error()).Error("Failed to fetch access token")
+		return nil, nil, appErrors.Internal("Failed to authenticate access token")
+	}
+
+	now := time.Now()
+	if token.IsExpired(now) {
+		return nil, nil, invalid
+	}
+
+	var owner user.User
+	if err := s.db.WithContext(ctx).First(&owner, "id = ?", token.UserID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil, invalid
+		}
+		logger.Log.WithFields(logrus.Fields{
+			"access_token_id": token.ID,
+			"error":           err.Error(),
+		}).Error("Failed to fetch access token owner")
+		return nil, nil, appErrors.Internal("Failed to authenticate access token")
+	}
+	if owner.BlockedAt != nil || owner.Auth0UserID == nil {
+		return nil, nil, invalid
+	}
+
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) >= lastUsedInterval {
+		// Usage tracking is best effort and shouldn't fail the request
+		if err := s.db.WithContext(ctx).Model(&token).UpdateColumn("last_used_at", now).Error; err != nil {
+			logger.Log.WithFields(logrus.Fields{
+				"access_token_id": token.ID,
+				"error":           err.Error(),
+			}).Warn("Failed to record access token use")
+		}
+	}
+
+	return &token, &owner, nil
+}
+
+// ListServiceAccounts returns all service accounts with their roles, newest first
+func (s *Service) ListServiceAccounts(ctx context.Context) ([]*ServiceAccount, error) {
+	enforcer, err := rbac.GetEnforcerFromContext(ctx)
+	if err != nil {
+		return nil, appErrors.Internal("Failed to fetch service accounts")
+	}
+
+	var users []*user.User
+	err = s.db.WithContext(ctx).
+		Where("service_account = ?", true).
+		Order("created_at DESC").
+		Find(&users).Error
+
+	if err != nil {
+		logger.Log.WithField("error", err.Error()).Error("Failed to fetch service accounts")
+		return nil, appErrors.Internal("Failed to fetch service accounts")
+	}
+
+	accounts := make([]*ServiceAccount, 0, len(users))
+	for _, u := range users {
+		roles, err := rbac.GetRolesForUser(enforcer, u.ID.String())
+		if err != nil {
+			return nil, appErrors.Internal("Failed to fetch service accounts")
+		}
+		accounts = append(accounts, toServiceAccount(u, roles))
+	}
+
+	return accounts, nil
+}
+
+// CreateServiceAccount adds a service account with a role, the default role unless
+// another is given
+func (s *Service) CreateServiceAccount(ctx context.Context, input ServiceAccountInput) (*ServiceAccount, error) {
+	enforcer, err := rbac.GetEnforcerFromContext(ctx)
+	if err != nil {
+		return nil, appErrors.Internal("Failed to create service account")
+	}
+
+	role := rbac.DefaultRole
+	if input.Role != nil {
+		role = *input.Role
+	}
+
+	// The email and subject are placeholders that keep the users table's unique
+	// columns filled; neither can be used to sign in
+	id := uuid.New()
+	subject := serviceAccountIDPrefix + id.String()
+	account := &user.User{
+		ID:             id,
+		Auth0UserID:    &subject,
+		FirstName:      strings.TrimSpace(input.Name),
+		Email:          id.String() + "@" + serviceAccountEmailDomain,
+		ServiceAccount: true,
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(account).Error; err != nil {
+			return err
+		}
+		return rbac.AddRoleForUser(enforcer, id.String(), role)
+	})
+	if err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"service_account_id": id,
+			"error":              err.Error(),
+		}).Error("Failed to create service account")
+		return nil, appErrors.Internal("Failed to create service account")
+	}
+
+	logger.Log.WithFields(logrus.Fields{
+		"service_account_id": id,
+		"role":               role,
+	}).Info("Service account created successfully")
+
+	return toServiceAccount(account, []string{role}), nil
+}
+
+// CreateServiceAccountToken issues a token acting as a service account
+func (s *Service) CreateServiceAccountToken(ctx context.Context, id uuid.UUID, input TokenInput) (*Token, error) {
+	_, creatorID, err := user.GetAuthenticatedUser(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.getServiceAccount(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return s.issue(ctx, id, creatorID, input)
+}
+
+// DeleteServiceAccount revokes a service account's tokens and removes it with its roles
+func (s *Service) DeleteServiceAccount(ctx context.Context, id uuid.UUID) error {
+	enforcer, err := rbac.GetEnforcerFromContext(ctx)
+	if err != nil {
+		return appErrors.Internal("Failed to delete service account")
+	}
+
+	account, err := s.getServiceAccount(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", id).Delete(&Token{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(account).Error; err != nil {
+			return err
+		}
+		_, err := enforcer.DeleteRolesForUser(id.String())
+		return err
+	})
+	if err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"service_account_id": id,
+			"error":              err.Error(),
+		}).Error("Failed to delete service account")
+		return appErrors.Internal("Failed to delete service account")
+	}
+
+	logger.Log.WithField("service_account_id", id).Info("Service account deleted successfully")
+
+	return nil
+}
+
+func (s *Service) getServiceAccount(ctx context.Context, id uuid.UUID) (*user.User, error) {
+	var account user.User
+	err := s.db.WithContext(ctx).First(&account, "id = ? AND service_account = ?", id, true).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, appErrors.NotFound("Service account")
+		}
+		logger.Log.WithFields(logrus.Fields{
+			"service_account_id": id,
+			"error":              err.Error(),
+		}).Error("Failed to fetch service account")
+		return nil, appErrors.Internal("Failed to fetch service account")
+	}
+	return &account, nil
+}
+
+// issue creates a token acting as a user. The admin scope needs the user to be an
+// admin, so tokens never grant more than their owner has.
+func (s *Service) issue(ctx context.Context, userID, createdBy uuid.UUID, input TokenInput) (*Token, error) {
+	scopes := make([]string, 0, len(input.Scopes))
+	for _, scope := range input.Scopes {
+		if scope == ScopeAdmin {
+			if err := rbac.RequireRole(ctx, userID, "admin"); err != nil {
+				return nil, appErrors.ValidationError("scopes", "The admin scope needs an owner with the admin role")
+			}
+		}
+		if !slices.Contains(scopes, string(scope)) {
+			scopes = append(scopes, string(scope))
+		}
+	}
+
+	days := int32(defaultExpiresInDays)
+	if input.ExpiresInDays != nil {
+		days = *input.ExpiresInDays
+	}
+
+	raw, prefix, tokenHash, err := generateToken()
+	if err != nil {
+		logger.Log.WithField("error", err.Error()).Error("Failed to generate access token")
+		return nil, appErrors.Internal("Failed to create access token")
+	}
+
+	token := &Token{
+		UserID:    userID,
+		CreatedBy: createdBy,
+		Name:      strings.TrimSpace(input.Name),
+		Prefix:    prefix,
+		TokenHash: tokenHash,
+		ScopeList: strings.Join(scopes, " "),
+		ExpiresAt: time.Now().AddDate(0, 0, int(days)),
+	}
+
+	if err := s.db.WithContext(ctx).Create(token).Error; err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"user_id": userID,
+			"error":   err.Error(),
+		}).Error("Failed to create access token")
+		return nil, appErrors.Internal("Failed to create access token")
+	}
+
+	token.Token = &raw
+
+	logger.Log.WithFields(logrus.Fields{
+		"user_id":         userID,
+		"access_token_id": token.ID,
+		"created_by":      createdBy,
+	}).Info("Access token created successfully")
+
+	return token, nil
+}
+
+func toServiceAccount(u *user.User, roles []string) *ServiceAccount {
+	role := ""
+	if len(roles) > 0 {
+		role = roles[0]
+	}
+	return &ServiceAccount{
+		ID:        u.ID,
+		Name:      u.FirstName,
+		Role:      role,
+		CreatedAt: u.CreatedAt,
+	}
+}
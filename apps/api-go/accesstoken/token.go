@@ -0,0 +1,121 @@
+package accesstoken
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	// TokenPrefix starts every access token, so they can be told apart from JWTs and
+	// found by secret scanners
+	TokenPrefix = "ezt_"
+
+	tokenBytes = 32
+	// displayPrefixLength is how much of a token is kept to help users recognize it
+	displayPrefixLength = len(TokenPrefix) + 8
+)
+
+// Scope limits what a token may do. Requests authenticated otherwise have every scope.
+type Scope string
+
+const (
+	// ScopeRead allows queries
+	ScopeRead Scope = "read"
+	// ScopeWrite allows queries and mutations
+	ScopeWrite Scope = "write"
+	// ScopeAdmin allows fields restricted to admins, for owners with the admin role
+	ScopeAdmin Scope = "admin"
+)
+
+// Token is a personal access token or a service account token. Only a hash of the
+// token is stored, so the token is shown once when it is created.
+type Token struct {
+	ID         uuid.UUID      `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	UserID     uuid.UUID      `gorm:"type:uuid;not null;index"` // The user or service account the token acts as
+	CreatedBy  uuid.UUID      `gorm:"type:uuid;not null"`
+	Name       string         `gorm:"column:name;not null"`
+	Prefix     string         `gorm:"column:token_prefix;not null"`
+	TokenHash  string         `gorm:"column:token_hash;not null;uniqueIndex"`
+	ScopeList  string         `gorm:"column:scopes;not null"` // Space separated, like OAuth scopes
+	ExpiresAt  time.Time      `gorm:"column:expires_at;not null"`
+	LastUsedAt *time.Time     `gorm:"column:last_used_at"`
+	CreatedAt  time.Time      `gorm:"column:created_at"`
+	UpdatedAt  time.Time      `gorm:"column:updated_at"`
+	DeletedAt  gorm.DeletedAt `gorm:"column:deleted_at;index"` // Set when the token is revoked
+
+	Token *string `gorm:"-"` // Only populated right after the token is created
+}
+
+// TableName specifies the table name for the Token model
+func (Token) TableName() string {
+	return "access_tokens"
+}
+
+// Scopes returns the scopes the token was granted
+func (t *Token) Scopes() []Scope {
+	fields := strings.Fields(t.ScopeList)
+	scopes := make([]Scope, len(fields))
+	for i, field := range fields {
+		scopes[i] = Scope(field)
+	}
+	return scopes
+}
+
+// HasScope checks if the token grants a scope. Write access includes read access.
+func (t *Token) HasScope(scope Scope) bool {
+	scopes := t.Scopes()
+	if scope == ScopeRead && slices.Contains(scopes, ScopeWrite) {
+		return true
+	}
+	return slices.Contains(scopes, scope)
+}
+
+// IsExpired checks if the token's expiry has passed
+func (t *Token) IsExpired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
+
+// TokenInput represents the options submitted when creating an access token
+type TokenInput struct {
+	Name          string  `json:"name" validate:"required,min=1,max=100"`
+	Scopes        []Scope `json:"scopes" validate:"required,min=1,dive,oneof=read write admin"`
+	ExpiresInDays *int32  `json:"expiresInDays" validate:"omitempty,min=1,max=365"`
+}
+
+// ServiceAccount is a non-human identity for automation. It is backed by a user that
+// can't sign in and only authenticates with access tokens.
+type ServiceAccount struct {
+	ID        uuid.UUID
+	Name      string
+	Role      string
+	CreatedAt time.Time
+}
+
+// ServiceAccountInput represents the options submitted when creating a service account
+type ServiceAccountInput struct {
+	Name string  `json:"name" validate:"required,min=1,max=100"`
+	Role *string `json:"role" validate:"omitempty,oneof=admin user"`
+}
+
+// generateToken returns a random token, the prefix shown to identify it, and its hash
+func generateToken() (string, string, string, error) {
+	raw := make([]byte, tokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", "", "", err
+	}
+	token := TokenPrefix + base64.RawURLEncoding.EncodeToString(raw)
+	return token, token[:displayPrefixLength], hashToken(token), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
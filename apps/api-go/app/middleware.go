@@ -1,6 +1,8 @@
 package app
 
 import (
+	"eztrip/api-go/accesstoken"
+	"eztrip/api-go/identity"
 	"eztrip/api-go/logger"
 	"eztrip/api-go/middleware"
 	"eztrip/api-go/rbac"
@@ -12,26 +14,28 @@ import (
 	"gorm.io/gorm"
 )
 
-func SetupMiddleware(router *gin.Engine, db *gorm.DB, enforcer *casbin.Enforcer) error {
+func SetupMiddleware(router *gin.Engine, db *gorm.DB, enforcer *casbin.SyncedEnforcer, tokenValidator identity.TokenValidator) error {
 	router.Use(gin.Recovery())
 	router.Use(middleware.RequestLogger())
 	router.Use(middleware.ErrorHandler())
 	router.Use(cors.New(ConfigureCORS()))
 
-	auth0JWT, err := middleware.Auth0JWTFromEnv()
-	if err != nil {
-		return err
-	}
-	router.Use(auth0JWT)
+	accessTokens := middleware.AccessTokenMiddleware(accesstoken.NewService(db))
+	router.Use(middleware.SkipForPathPrefixes(accessTokens, publicPathPrefixes...))
+	router.Use(middleware.SkipForPathPrefixes(middleware.AccessTokenScopeMiddleware(), graphQLPath))
+
+	jwt := middleware.JWTMiddleware(tokenValidator)
+	router.Use(middleware.SkipForPathPrefixes(jwt, publicPathPrefixes...))
 
 	userService := user.NewService(db)
-	router.Use(middleware.UserLookupMiddleware(userService))
+	router.Use(middleware.UserLookupMiddleware(userService, enforcer))
 	router.Use(middleware.RBACMiddleware(enforcer))
+	router.Use(middleware.DataLoaderMiddleware())
 
 	return nil
 }
 
-func InitializeRBAC(db *gorm.DB) (*casbin.Enforcer, error) {
+func InitializeRBAC(db *gorm.DB) (*casbin.SyncedEnforcer, error) {
 	enforcer, err := rbac.NewEnforcer(db)
 	if err != nil {
 		return nil, err
@@ -42,7 +46,11 @@ func InitializeRBAC(db *gorm.DB) (*casbin.Enforcer, error) {
 		return nil, err
 	}
 	
-	policyCount := len(rbac.DefaultPolicies)
+	policies, err := enforcer.GetPolicy()
+	if err != nil {
+		return nil, err
+	}
+	policyCount := len(policies)
 	logger.Log.WithFields(map[string]interface{}{
 		"component":     "rbac",
 		"policy_count":  policyCount,
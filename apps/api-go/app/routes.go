@@ -3,21 +3,40 @@ package app
 import (
 	"net/http"
 
+	"eztrip/api-go/auth0sync"
+	"eztrip/api-go/authz"
+	"eztrip/api-go/calendar"
+	"eztrip/api-go/gqlserver"
 	"eztrip/api-go/graph"
+	"eztrip/api-go/identity"
 	"eztrip/api-go/logger"
+	"eztrip/api-go/printout"
+	"eztrip/api-go/privacy"
+	"eztrip/api-go/share"
 
-	"github.com/99designs/gqlgen/graphql/handler"
 	"github.com/99designs/gqlgen/graphql/playground"
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
 
+// publicPathPrefixes are served without Auth0 authentication because they carry their own credentials
+var publicPathPrefixes = []string{
+	calendar.FeedPathPrefix,
+	share.PathPrefix,
+	privacy.ExportPathPrefix,
+	auth0sync.WebhookPath,
+	identity.WellKnownPathPrefix,
+}
+
+// graphQLPath serves GraphQL requests, and the playground outside release mode
+const graphQLPath = "/graphql"
+
 type HealthResponse struct {
 	Status  string `json:"status"`
 	Message string `json:"message"`
 }
 
-func SetupRoutes(router *gin.Engine, database *gorm.DB) {
+func SetupRoutes(router *gin.Engine, database *gorm.DB, tokenValidator identity.TokenValidator) error {
 	router.GET("/health", func(c *gin.Context) {
 		c.JSON(http.StatusOK, HealthResponse{
 			Status:  "ok",
@@ -25,21 +44,67 @@ func SetupRoutes(router *gin.Engine, database *gorm.DB) {
 		})
 	})
 
+	// Providers that issue their own tokens publish the keys to validate them
+	if publisher, ok := tokenValidator.(identity.KeySetPublisher); ok {
+		router.GET(identity.KeySetPath, gin.WrapF(identity.KeySetHandler(publisher)))
+		router.GET(identity.DiscoveryPath, gin.WrapF(identity.DiscoveryHandler(publisher)))
+	}
+
 	resolver := graph.NewResolver(database)
-	graphqlHandler := handler.NewDefaultServer(graph.NewExecutableSchema(graph.Config{Resolvers: resolver}))
+	graphqlConfig, err := gqlserver.ConfigFromEnv()
+	if err != nil {
+		return err
+	}
+	directives := authz.NewDirectives(database, resolver.TripResolver.Service)
+	graphqlHandler, err := gqlserver.New(graph.NewExecutableSchema(graph.Config{
+		Resolvers: resolver,
+		Directives: graph.DirectiveRoot{
+			Auth:     directives.Auth,
+			HasRole:  directives.HasRole,
+			TripRole: directives.TripRole,
+		},
+		Complexity: graph.NewComplexity(),
+	}), graphqlConfig)
+	if err != nil {
+		return err
+	}
 
-	router.POST("/graphql", func(c *gin.Context) {
+	router.POST(graphQLPath, func(c *gin.Context) {
 		graphqlHandler.ServeHTTP(c.Writer, c.Request)
 	})
 
+	calendarHandler := calendar.NewHandler(resolver.CalendarResolver.Service)
+	router.GET("/trips/:id/calendar.ics", calendarHandler.Export)
+	router.GET(calendar.FeedPathPrefix+":token", calendarHandler.Feed)
+
+	printoutHandler := printout.NewHandler(printout.NewService(database, resolver.TripResolver.Service))
+	router.GET("/trips/:id/itinerary.html", printoutHandler.HTML)
+	router.GET("/trips/:id/itinerary.pdf", printoutHandler.PDF)
+
+	shareHandler := share.NewHandler(resolver.ShareResolver.Service)
+	router.GET(share.PathPrefix+":token", shareHandler.View)
+	router.POST(share.PathPrefix+":token", shareHandler.Unlock)
+
+	privacyHandler := privacy.NewHandler(resolver.PrivacyResolver.Service)
+	router.GET(privacy.ExportPathPrefix+":token", privacyHandler.Download)
+
+	if secret := auth0sync.SecretFromEnv(); secret != "" {
+		webhookHandler := auth0sync.NewHandler(auth0sync.NewService(database), secret)
+		router.POST(auth0sync.WebhookPath, webhookHandler.Receive)
+	} else {
+		logger.Log.WithField("component", "auth0sync").Warn("AUTH0_WEBHOOK_SECRET not set, Auth0 webhook disabled")
+	}
+
 	if gin.Mode() != gin.ReleaseMode {
-		playgroundHandler := playground.Handler("GraphQL Playground", "/graphql")
-		router.GET("/graphql", func(c *gin.Context) {
+		playgroundHandler := playground.Handler("GraphQL Playground", graphQLPath)
+		router.GET(graphQLPath, func(c *gin.Context) {
 			playgroundHandler.ServeHTTP(c.Writer, c.Request)
 		})
 		logger.Log.WithFields(map[string]interface{}{
 			"component": "graphql",
-			"path":      "/graphql",
+			"path":      graphQLPath,
 		}).Info("GraphQL Playground enabled")
 	}
+
+	return nil
 }
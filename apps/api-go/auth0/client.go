@@ -10,6 +10,8 @@ import (
 	"os"
 	"strings"
 	"time"
+
+	"eztrip/api-go/identity"
 )
 
 // Client handles Auth0 Management API operations
@@ -32,6 +34,7 @@ const (
 	defaultConnection    = "Username-Password-Authentication"
 	pathOAuthToken       = "/oauth/token"
 	pathUsers            = "/api/v2/users"
+	pathLogs             = "/api/v2/logs"
 	envAuth0Domain       = "AUTH0_DOMAIN"
 	envAuth0ClientID     = "AUTH0_CLIENT_ID"
 	envAuth0ClientSecret = "AUTH0_CLIENT_SECRET"
@@ -133,14 +136,30 @@ type CreateUserRequest struct {
 
 // Auth0User represents a user response from Auth0
 type Auth0User struct {
-	UserID    string `json:"user_id"`
-	Email     string `json:"email"`
-	FirstName string `json:"given_name"`
-	LastName  string `json:"family_name"`
+	UserID        string `json:"user_id"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Name          string `json:"name"`
+	FirstName     string `json:"given_name"`
+	LastName      string `json:"family_name"`
+}
+
+// toIdentity converts an Auth0 user to an identity provider user
+func (u *Auth0User) toIdentity() *identity.User {
+	return &identity.User{
+		ID:            u.UserID,
+		Email:         u.Email,
+		EmailVerified: u.EmailVerified,
+		Name:          u.Name,
+		FirstName:     u.FirstName,
+		LastName:      u.LastName,
+	}
 }
 
+var _ identity.Directory = (*Client)(nil)
+
 // CreateUser creates a new user in Auth0
-func (c *Client) CreateUser(email, password, firstName, lastName string) (*Auth0User, error) {
+func (c *Client) CreateUser(email, password, firstName, lastName string) (*identity.User, error) {
 	if err := c.getAccessToken(); err != nil {
 		return nil, fmt.Errorf("failed to get access token: %w", err)
 	}
@@ -180,11 +199,45 @@ func (c *Client) CreateUser(email, password, firstName, lastName string) (*Auth0
 		return nil, fmt.Errorf("failed to decode auth0 user response: %w", err)
 	}
 
-	return &auth0User, nil
+	return auth0User.toIdentity(), nil
+}
+
+// GetUser retrieves a user's profile from Auth0 by user ID
+func (c *Client) GetUser(userID string) (*identity.User, error) {
+	if err := c.getAccessToken(); err != nil {
+		return nil, fmt.Errorf("failed to get access token: %w", err)
+	}
+
+	urlPath := fmt.Sprintf("%s/%s", pathUsers, url.PathEscape(userID))
+	resp, err := c.makeAuthenticatedRequest(http.MethodGet, c.buildURL(urlPath), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user from auth0: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := readResponseBody(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", identity.ErrUserNotFound, userID)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("auth0 get user failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var auth0User Auth0User
+	if err := json.Unmarshal(body, &auth0User); err != nil {
+		return nil, fmt.Errorf("failed to decode auth0 user response: %w", err)
+	}
+
+	return auth0User.toIdentity(), nil
 }
 
 // GetUserByEmail retrieves a user from Auth0 by email address
-func (c *Client) GetUserByEmail(email string) (*Auth0User, error) {
+func (c *Client) GetUserByEmail(email string) (*identity.User, error) {
 	if err := c.getAccessToken(); err != nil {
 		return nil, fmt.Errorf("failed to get access token: %w", err)
 	}
@@ -202,7 +255,7 @@ func (c *Client) GetUserByEmail(email string) (*Auth0User, error) {
 	}
 
 	if resp.StatusCode == http.StatusNotFound {
-		return nil, fmt.Errorf("user not found: %s", email)
+		return nil, fmt.Errorf("%w: %s", identity.ErrUserNotFound, email)
 	}
 
 	if resp.StatusCode != http.StatusOK {
@@ -215,10 +268,63 @@ func (c *Client) GetUserByEmail(email string) (*Auth0User, error) {
 	}
 
 	if len(users) == 0 {
-		return nil, fmt.Errorf("user not found: %s", email)
+		return nil, fmt.Errorf("%w: %s", identity.ErrUserNotFound, email)
 	}
 
-	return &users[0], nil
+	return users[0].toIdentity(), nil
+}
+
+// updateUserRequest represents the changes to a user in Auth0. The full name is kept
+// in step with the given and family names.
+type updateUserRequest struct {
+	Name      *string `json:"name,omitempty"`
+	FirstName *string `json:"given_name,omitempty"`
+	LastName  *string `json:"family_name,omitempty"`
+	Blocked   *bool   `json:"blocked,omitempty"`
+}
+
+// UpdateUser changes a user's names or blocked state in Auth0
+func (c *Client) UpdateUser(userID string, update identity.UserUpdate) error {
+	if err := c.getAccessToken(); err != nil {
+		return fmt.Errorf("failed to get access token: %w", err)
+	}
+
+	req := updateUserRequest{
+		FirstName: update.FirstName,
+		LastName:  update.LastName,
+		Blocked:   update.Blocked,
+	}
+	if update.FirstName != nil && update.LastName != nil {
+		name := strings.TrimSpace(*update.FirstName + " " + *update.LastName)
+		req.Name = &name
+	}
+
+	jsonData, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("failed to marshal update user request: %w", err)
+	}
+
+	urlPath := fmt.Sprintf("%s/%s", pathUsers, url.PathEscape(userID))
+	resp, err := c.makeAuthenticatedRequest(http.MethodPatch, c.buildURL(urlPath), bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to update user in auth0: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := readResponseBody(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: %s", identity.ErrUserNotFound, userID)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("auth0 update user failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	return nil
 }
 
 // getAuth0Connection returns the Auth0 connection to use, with fallback to default
@@ -243,6 +349,10 @@ func (c *Client) DeleteUser(userID string) error {
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: %s", identity.ErrUserNotFound, userID)
+	}
+
 	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
 		body, err := readResponseBody(resp)
 		if err != nil {
@@ -255,7 +365,7 @@ func (c *Client) DeleteUser(userID string) error {
 }
 
 // ListUsers retrieves all users from Auth0
-func (c *Client) ListUsers() ([]*Auth0User, error) {
+func (c *Client) ListUsers() ([]*identity.User, error) {
 	if err := c.getAccessToken(); err != nil {
 		return nil, fmt.Errorf("failed to get access token: %w", err)
 	}
@@ -280,14 +390,61 @@ func (c *Client) ListUsers() ([]*Auth0User, error) {
 		return nil, fmt.Errorf("failed to read list users response: %w", err)
 	}
 
-	var users []*Auth0User
-	if err := json.Unmarshal(body, &users); err != nil {
+	var auth0Users []*Auth0User
+	if err := json.Unmarshal(body, &auth0Users); err != nil {
 		return nil, fmt.Errorf("failed to parse list users response: %w", err)
 	}
 
+	users := make([]*identity.User, 0, len(auth0Users))
+	for _, auth0User := range auth0Users {
+		users = append(users, auth0User.toIdentity())
+	}
 	return users, nil
 }
 
+// LogEntry is an Auth0 tenant log event, as returned by the logs endpoint and
+// delivered by log streams
+type LogEntry struct {
+	LogID   string          `json:"log_id"`
+	Date    time.Time       `json:"date"`
+	Type    string          `json:"type"`
+	UserID  string          `json:"user_id"`
+	Details json.RawMessage `json:"details"`
+}
+
+// ListLogs retrieves up to take log events that follow the log event with ID from,
+// oldest first
+func (c *Client) ListLogs(from string, take int) ([]LogEntry, error) {
+	if err := c.getAccessToken(); err != nil {
+		return nil, fmt.Errorf("failed to get access token: %w", err)
+	}
+
+	query := url.Values{}
+	query.Set("from", from)
+	query.Set("take", fmt.Sprintf("%d", take))
+	resp, err := c.makeAuthenticatedRequest(http.MethodGet, c.buildURL(pathLogs+"?"+query.Encode()), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list logs from auth0: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := readResponseBody(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("auth0 list logs failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var logs []LogEntry
+	if err := json.Unmarshal(body, &logs); err != nil {
+		return nil, fmt.Errorf("failed to parse list logs response: %w", err)
+	}
+
+	return logs, nil
+}
+
 // makeAuthenticatedRequest creates and executes an authenticated HTTP request
 func (c *Client) makeAuthenticatedRequest(method, url string, body io.Reader) (*http.Response, error) {
 	req, err := http.NewRequest(method, url, body)
@@ -0,0 +1,14 @@
+package auth0
+
+import "eztrip/api-go/identity"
+
+func init() {
+	identity.RegisterProvider(identity.ProviderAuth0, identity.Factory{
+		Validator: func() (identity.TokenValidator, error) {
+			return NewTokenValidatorFromEnv()
+		},
+		Directory: func() (identity.Directory, error) {
+			return NewClient()
+		},
+	})
+}
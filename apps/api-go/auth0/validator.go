@@ -0,0 +1,166 @@
+package auth0
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"eztrip/api-go/identity"
+
+	"github.com/auth0/go-jwt-middleware/v2/jwks"
+	"github.com/auth0/go-jwt-middleware/v2/validator"
+)
+
+var (
+	ErrInvalidIssuerURL = errors.New("invalid issuer URL")
+	ErrInvalidClaims    = errors.New("invalid token claims")
+)
+
+const (
+	envAuth0IssuerURL = "AUTH0_ISSUER_URL"
+	envAuth0Audience  = "AUTH0_AUDIENCE"
+)
+
+// CustomClaims represents custom claims in Auth0 JWT tokens. Profile claims are only
+// present when the tenant adds them to access tokens.
+type CustomClaims struct {
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Name          string `json:"name"`
+	GivenName     string `json:"given_name"`
+	FamilyName    string `json:"family_name"`
+}
+
+func (c *CustomClaims) Validate(_ context.Context) error {
+	return nil
+}
+
+// Config holds configuration for Auth0 JWT validation.
+type Config struct {
+	IssuerURL string
+	Audience  []string
+}
+
+// Validate checks if the Auth0 configuration is valid.
+func (c Config) Validate() error {
+	if c.IssuerURL == "" {
+		return errors.New("AUTH0_ISSUER_URL is required")
+	}
+	if len(c.Audience) == 0 {
+		return errors.New("AUTH0_AUDIENCE is required")
+	}
+	return nil
+}
+
+// normalizeIssuerURL ensures the issuer URL ends with a trailing slash.
+func normalizeIssuerURL(issuer string) string {
+	if !strings.HasSuffix(issuer, "/") {
+		return issuer + "/"
+	}
+	return issuer
+}
+
+// LoadConfigFromEnv loads Auth0 configuration from environment variables.
+func LoadConfigFromEnv() (Config, error) {
+	issuer := strings.TrimSpace(os.Getenv(envAuth0IssuerURL))
+	if issuer == "" {
+		return Config{}, errors.New("AUTH0_ISSUER_URL is required")
+	}
+
+	audienceRaw := strings.TrimSpace(os.Getenv(envAuth0Audience))
+	if audienceRaw == "" {
+		return Config{}, errors.New("AUTH0_AUDIENCE is required")
+	}
+
+	cfg := Config{
+		IssuerURL: normalizeIssuerURL(issuer),
+		Audience:  splitAndTrim(audienceRaw, ","),
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return Config{}, err
+	}
+
+	return cfg, nil
+}
+
+// TokenValidator validates Auth0 access tokens against the tenant's JWKS.
+type TokenValidator struct {
+	validator *validator.Validator
+}
+
+var _ identity.TokenValidator = (*TokenValidator)(nil)
+
+// NewTokenValidator creates a new JWT validator with Auth0 configuration.
+func NewTokenValidator(cfg Config) (*TokenValidator, error) {
+	issuerURL, err := url.Parse(cfg.IssuerURL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidIssuerURL, err)
+	}
+
+	provider := jwks.NewCachingProvider(issuerURL, 5*time.Minute)
+
+	jwtValidator, err := validator.New(
+		provider.KeyFunc,
+		validator.RS256,
+		cfg.IssuerURL,
+		cfg.Audience,
+		validator.WithCustomClaims(func() validator.CustomClaims { return &CustomClaims{} }),
+		validator.WithAllowedClockSkew(60*time.Second),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create validator: %w", err)
+	}
+
+	return &TokenValidator{validator: jwtValidator}, nil
+}
+
+// NewTokenValidatorFromEnv creates a JWT validator from environment variables.
+func NewTokenValidatorFromEnv() (*TokenValidator, error) {
+	cfg, err := LoadConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return NewTokenValidator(cfg)
+}
+
+// ValidateToken checks the token's signature, issuer, audience and expiry and returns
+// its claims.
+func (v *TokenValidator) ValidateToken(ctx context.Context, token string) (*identity.Claims, error) {
+	validated, err := v.validator.ValidateToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := validated.(*validator.ValidatedClaims)
+	if !ok || claims == nil {
+		return nil, ErrInvalidClaims
+	}
+
+	result := &identity.Claims{Subject: claims.RegisteredClaims.Subject}
+	if custom, ok := claims.CustomClaims.(*CustomClaims); ok && custom != nil {
+		result.Email = custom.Email
+		result.EmailVerified = custom.EmailVerified
+		result.Name = custom.Name
+		result.GivenName = custom.GivenName
+		result.FamilyName = custom.FamilyName
+	}
+	return result, nil
+}
+
+func splitAndTrim(s, sep string) []string {
+	parts := strings.Split(s, sep)
+	out := make([]string, 0, len(parts))
+	for _, p := range parts {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		out = append(out, p)
+	}
+	return out
+}
@@ -0,0 +1,210 @@
+package auth0sync
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"eztrip/api-go/auth0"
+)
+
+// Source is where an event was delivered from
+type Source string
+
+const (
+	// SourceLog events come from an Auth0 log stream or the Management API logs endpoint
+	SourceLog Source = "log"
+	// SourceAction events are posted by Auth0 Actions
+	SourceAction Source = "action"
+)
+
+// Auth0 log event types that change users
+const (
+	logTypeDeletedUser  = "du"
+	logTypeAPIOperation = "sapi"
+)
+
+// Action event types
+const (
+	actionUserUpdated   = "user.updated"
+	actionUserDeleted   = "user.deleted"
+	actionUserBlocked   = "user.blocked"
+	actionUserUnblocked = "user.unblocked"
+)
+
+const userPathPrefix = "/api/v2/users/"
+
+// Changes are the profile fields an event sets. Nil fields are left as they are.
+type Changes struct {
+	Email     *string `json:"email,omitempty"`
+	FirstName *string `json:"firstName,omitempty"`
+	LastName  *string `json:"lastName,omitempty"`
+	Blocked   *bool   `json:"blocked,omitempty"`
+}
+
+func (c Changes) empty() bool {
+	return c.Email == nil && c.FirstName == nil && c.LastName == nil && c.Blocked == nil
+}
+
+// Event is a change to an Auth0 user, normalized from log and Action payloads
+type Event struct {
+	ID          string    `json:"id"`
+	Source      Source    `json:"source"`
+	Type        string    `json:"type"`
+	Auth0UserID string    `json:"auth0UserId"`
+	OccurredAt  time.Time `json:"occurredAt"`
+	Deleted     bool      `json:"deleted,omitempty"`
+	Changes     Changes   `json:"changes"`
+}
+
+// relevant reports whether the event changes a user
+func (e Event) relevant() bool {
+	return e.Auth0UserID != "" && (e.Deleted || !e.Changes.empty())
+}
+
+// logStreamEntry is one event of a custom webhook log stream batch
+type logStreamEntry struct {
+	LogID string         `json:"log_id"`
+	Data  auth0.LogEntry `json:"data"`
+}
+
+// actionPayload is the body Auth0 Actions post for a user change
+type actionPayload struct {
+	ID         string    `json:"id"`
+	Type       string    `json:"type"`
+	OccurredAt time.Time `json:"occurred_at"`
+	User       struct {
+		UserID     string  `json:"user_id"`
+		Email      *string `json:"email"`
+		GivenName  *string `json:"given_name"`
+		FamilyName *string `json:"family_name"`
+		Blocked    *bool   `json:"blocked"`
+	} `json:"user"`
+}
+
+// apiOperationDetails are the details of a Management API operation log event
+type apiOperationDetails struct {
+	Request struct {
+		Method string `json:"method"`
+		Path   string `json:"path"`
+		Body   struct {
+			Email      *string `json:"email"`
+			GivenName  *string `json:"given_name"`
+			FamilyName *string `json:"family_name"`
+			Blocked    *bool   `json:"blocked"`
+		} `json:"body"`
+	} `json:"request"`
+}
+
+// ParsePayload reads a webhook body: a log stream batch (a JSON array) or a single
+// Action event (a JSON object)
+func ParsePayload(body []byte) ([]Event, error) {
+	trimmed := strings.TrimSpace(string(body))
+	if strings.HasPrefix(trimmed, "[") {
+		var entries []logStreamEntry
+		if err := json.Unmarshal(body, &entries); err != nil {
+			return nil, fmt.Errorf("invalid log stream payload: %w", err)
+		}
+		events := make([]Event, 0, len(entries))
+		for _, entry := range entries {
+			if entry.Data.LogID == "" {
+				entry.Data.LogID = entry.LogID
+			}
+			events = append(events, FromLog(entry.Data))
+		}
+		return events, nil
+	}
+
+	var payload actionPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("invalid action payload: %w", err)
+	}
+	if payload.ID == "" || payload.Type == "" {
+		return nil, fmt.Errorf("action payload requires id and type")
+	}
+	return []Event{fromAction(payload)}, nil
+}
+
+// FromLog normalizes an Auth0 log event. Only user deletions and Management API updates
+// and deletions of users change anything; other log types give an event that is ignored.
+func FromLog(entry auth0.LogEntry) Event {
+	event := Event{
+		ID:          entry.LogID,
+		Source:      SourceLog,
+		Type:        entry.Type,
+		Auth0UserID: entry.UserID,
+		OccurredAt:  entry.Date,
+	}
+
+	switch entry.Type {
+	case logTypeDeletedUser:
+		event.Deleted = true
+	case logTypeAPIOperation:
+		var details apiOperationDetails
+		if err := json.Unmarshal(entry.Details, &details); err != nil {
+			return event
+		}
+		userID, ok := userIDFromPath(details.Request.Path)
+		if !ok {
+			return event
+		}
+		event.Auth0UserID = userID
+		switch strings.ToUpper(details.Request.Method) {
+		case "DELETE":
+			event.Deleted = true
+		case "PATCH":
+			body := details.Request.Body
+			event.Changes = Changes{
+				Email:     body.Email,
+				FirstName: body.GivenName,
+				LastName:  body.FamilyName,
+				Blocked:   body.Blocked,
+			}
+		}
+	}
+
+	return event
+}
+
+func fromAction(payload actionPayload) Event {
+	event := Event{
+		ID:          payload.ID,
+		Source:      SourceAction,
+		Type:        payload.Type,
+		Auth0UserID: payload.User.UserID,
+		OccurredAt:  payload.OccurredAt,
+	}
+
+	switch payload.Type {
+	case actionUserUpdated:
+		event.Changes = Changes{
+			Email:     payload.User.Email,
+			FirstName: payload.User.GivenName,
+			LastName:  payload.User.FamilyName,
+			Blocked:   payload.User.Blocked,
+		}
+	case actionUserDeleted:
+		event.Deleted = true
+	case actionUserBlocked, actionUserUnblocked:
+		blocked := payload.Type == actionUserBlocked
+		event.Changes.Blocked = &blocked
+	}
+
+	return event
+}
+
+// userIDFromPath returns the user ID of a Management API path for a single user
+func userIDFromPath(path string) (string, bool) {
+	path, _, _ = strings.Cut(path, "?")
+	rest, ok := strings.CutPrefix(path, userPathPrefix)
+	if !ok || rest == "" || strings.Contains(rest, "/") {
+		return "", false
+	}
+	userID, err := url.PathUnescape(rest)
+	if err != nil {
+		return "", false
+	}
+	return userID, true
+}
@@ -0,0 +1,67 @@
+package auth0sync
+
+import (
+	"io"
+	"net/http"
+	"time"
+
+	"eztrip/api-go/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// WebhookPath receives Auth0 events. It is public because requests carry their own
+// signature.
+const WebhookPath = "/webhooks/auth0"
+
+// maxBodyBytes bounds the size of a delivered batch
+const maxBodyBytes = 1 << 20
+
+// Handler receives Auth0 user lifecycle events over HTTP
+type Handler struct {
+	Service *Service
+	secret  string
+}
+
+// NewHandler creates a new webhook handler that accepts requests signed with secret
+func NewHandler(service *Service, secret string) *Handler {
+	return &Handler{
+		Service: service,
+		secret:  secret,
+	}
+}
+
+// Receive verifies and applies a log stream batch or Action event. Events that fail to
+// apply are recorded for the replay command, so only errors recording them ask Auth0
+// to deliver the batch again.
+func (h *Handler) Receive(c *gin.Context) {
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxBodyBytes))
+	if err != nil {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "bad_request", "message": "Request body too large"})
+		return
+	}
+
+	if err := Verify(h.secret, c.Request.Header, body, time.Now()); err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"component": "auth0sync",
+			"error":     err.Error(),
+		}).Warn("Rejected Auth0 webhook request")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized", "message": "Invalid webhook signature"})
+		return
+	}
+
+	events, err := ParsePayload(body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "bad_request", "message": err.Error()})
+		return
+	}
+
+	result, err := h.Service.Receive(c.Request.Context(), events)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error", "message": "Failed to record events"})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,316 @@
+// Package auth0sync applies user changes made in Auth0 to the users table. Events arrive
+// on a signed webhook from a log stream or Actions, and missed log events can be
+// replayed from the Management API.
+package auth0sync
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"eztrip/api-go/auth0"
+	"eztrip/api-go/logger"
+	"eztrip/api-go/user"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Status is the outcome of processing an event
+type Status string
+
+const (
+	// StatusPending events were received but not yet applied
+	StatusPending   Status = "pending"
+	StatusProcessed Status = "processed"
+	// StatusIgnored events don't change a user we know of
+	StatusIgnored Status = "ignored"
+	// StatusFailed events can be retried with the replay command
+	StatusFailed Status = "failed"
+)
+
+// replayPageSize is the number of log events fetched per Management API request
+const replayPageSize = 100
+
+// Record is a received event. Its ID is the event ID, so each event is applied once.
+type Record struct {
+	EventID     string     `gorm:"column:event_id;primaryKey"`
+	Source      Source     `gorm:"column:source;not null"`
+	Type        string     `gorm:"column:type;not null"`
+	Auth0UserID string     `gorm:"column:auth0_user_id"`
+	Payload     string     `gorm:"column:payload;type:jsonb;not null"`
+	Status      Status     `gorm:"column:status;not null"`
+	Error       *string    `gorm:"column:error"`
+	OccurredAt  *time.Time `gorm:"column:occurred_at"`
+	ReceivedAt  time.Time  `gorm:"column:received_at;autoCreateTime"`
+	ProcessedAt *time.Time `gorm:"column:processed_at"`
+}
+
+// TableName specifies the table name for the Record model
+func (Record) TableName() string {
+	return "auth0_events"
+}
+
+// Result counts the outcomes of a batch of events
+type Result struct {
+	Processed int `json:"processed"`
+	Ignored   int `json:"ignored"`
+	Failed    int `json:"failed"`
+	Duplicate int `json:"duplicate"`
+}
+
+func (r *Result) add(status Status, duplicate bool) {
+	switch {
+	case duplicate:
+		r.Duplicate++
+	case status == StatusProcessed:
+		r.Processed++
+	case status == StatusIgnored:
+		r.Ignored++
+	case status == StatusFailed:
+		r.Failed++
+	}
+}
+
+type Service struct {
+	db *gorm.DB
+}
+
+func NewService(db *gorm.DB) *Service {
+	return &Service{
+		db: db,
+	}
+}
+
+// Receive records and applies the events of a batch that change users. Events seen
+// before are skipped unless they failed, so redelivered batches are safe. Failing to apply an event is recorded
+// on the event rather than returned; an error means the batch should be delivered again.
+func (s *Service) Receive(ctx context.Context, events []Event) (Result, error) {
+	var result Result
+	for _, event := range events {
+		if event.ID == "" {
+			continue
+		}
+		// Log streams deliver every tenant event; only user changes are recorded
+		if !event.relevant() {
+			result.Ignored++
+			continue
+		}
+		status, duplicate, err := s.receive(ctx, event)
+		if err != nil {
+			return result, err
+		}
+		result.add(status, duplicate)
+	}
+	return result, nil
+}
+
+func (s *Service) receive(ctx context.Context, event Event) (Status, bool, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to encode event %s: %w", event.ID, err)
+	}
+
+	var status Status
+	duplicate := false
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		record := Record{
+			EventID:     event.ID,
+			Source:      event.Source,
+			Type:        event.Type,
+			Auth0UserID: event.Auth0UserID,
+			Payload:     string(payload),
+			Status:      StatusPending,
+		}
+		if !event.OccurredAt.IsZero() {
+			record.OccurredAt = &event.OccurredAt
+		}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&record).Error; err != nil {
+			return err
+		}
+
+		// Lock the record so concurrent deliveries of the same event apply it once
+		var stored Record
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("event_id = ?", event.ID).First(&stored).Error; err != nil {
+			return err
+		}
+		if stored.Status == StatusProcessed || stored.Status == StatusIgnored {
+			status = stored.Status
+			duplicate = true
+			return nil
+		}
+
+		status, err = s.process(tx, event)
+		return err
+	})
+	if err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"component": "auth0sync",
+			"event_id":  event.ID,
+			"error":     err,
+		}).Error("Failed to record Auth0 event")
+		return "", false, fmt.Errorf("failed to record event %s: %w", event.ID, err)
+	}
+	return status, duplicate, nil
+}
+
+// process applies an event in a savepoint and stores the outcome on its record
+func (s *Service) process(tx *gorm.DB, event Event) (Status, error) {
+	status := StatusProcessed
+	var errMessage *string
+
+	err := tx.Transaction(func(tx *gorm.DB) error {
+		applied, err := apply(tx, event)
+		if err == nil && !applied {
+			status = StatusIgnored
+		}
+		return err
+	})
+	if err != nil {
+		status = StatusFailed
+		message := err.Error()
+		errMessage = &message
+	}
+
+	fields := logrus.Fields{
+		"component":     "auth0sync",
+		"event_id":      event.ID,
+		"type":          event.Type,
+		"auth0_user_id": event.Auth0UserID,
+		"status":        status,
+	}
+	if err != nil {
+		logger.Log.WithFields(fields).WithError(err).Warn("Failed to apply Auth0 event")
+	} else {
+		logger.Log.WithFields(fields).Info("Auth0 event processed")
+	}
+
+	err = tx.Model(&Record{}).Where("event_id = ?", event.ID).Updates(map[string]interface{}{
+		"status":       status,
+		"error":        errMessage,
+		"processed_at": time.Now(),
+	}).Error
+	return status, err
+}
+
+// apply changes the user the event is about, and reports whether there was one
+func apply(tx *gorm.DB, event Event) (bool, error) {
+	var target user.User
+	if err := tx.Where("auth0_user_id = ?", event.Auth0UserID).First(&target).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	if event.Deleted {
+		return true, tx.Delete(&target).Error
+	}
+
+	updates := map[string]interface{}{}
+	if event.Changes.Email != nil && *event.Changes.Email != "" {
+		updates["email"] = *event.Changes.Email
+	}
+	if event.Changes.FirstName != nil && *event.Changes.FirstName != "" {
+		updates["first_name"] = *event.Changes.FirstName
+	}
+	if event.Changes.LastName != nil && *event.Changes.LastName != "" {
+		updates["last_name"] = *event.Changes.LastName
+	}
+	if event.Changes.Blocked != nil {
+		switch {
+		case *event.Changes.Blocked && target.BlockedAt == nil:
+			updates["blocked_at"] = blockedAt(event)
+		case !*event.Changes.Blocked:
+			updates["blocked_at"] = nil
+		}
+	}
+	if len(updates) == 0 {
+		return true, nil
+	}
+	return true, tx.Model(&target).Updates(updates).Error
+}
+
+func blockedAt(event Event) time.Time {
+	if event.OccurredAt.IsZero() {
+		return time.Now()
+	}
+	return event.OccurredAt
+}
+
+// ReplayLogs fetches the log events that follow the log event with ID from and receives
+// them. With an empty from, it continues after the latest user change received from logs.
+func (s *Service) ReplayLogs(ctx context.Context, client *auth0.Client, from string) (Result, error) {
+	var result Result
+	if from == "" {
+		latest, err := s.latestLogID(ctx)
+		if err != nil {
+			return result, err
+		}
+		if latest == "" {
+			return result, fmt.Errorf("no log events received yet; pass the log ID to replay from")
+		}
+		from = latest
+	}
+
+	for {
+		entries, err := client.ListLogs(from, replayPageSize)
+		if err != nil {
+			return result, err
+		}
+		if len(entries) == 0 {
+			return result, nil
+		}
+
+		events := make([]Event, 0, len(entries))
+		for _, entry := range entries {
+			events = append(events, FromLog(entry))
+		}
+		page, err := s.Receive(ctx, events)
+		result.Processed += page.Processed
+		result.Ignored += page.Ignored
+		result.Failed += page.Failed
+		result.Duplicate += page.Duplicate
+		if err != nil {
+			return result, err
+		}
+
+		from = entries[len(entries)-1].LogID
+	}
+}
+
+// RetryFailed applies the recorded events that failed, or were never applied, again
+func (s *Service) RetryFailed(ctx context.Context) (Result, error) {
+	var records []Record
+	if err := s.db.WithContext(ctx).Where("status IN ?", []Status{StatusPending, StatusFailed}).
+		Order("occurred_at ASC NULLS FIRST").Order("received_at ASC").Find(&records).Error; err != nil {
+		return Result{}, fmt.Errorf("failed to fetch failed events: %w", err)
+	}
+
+	events := make([]Event, 0, len(records))
+	for _, record := range records {
+		var event Event
+		if err := json.Unmarshal([]byte(record.Payload), &event); err != nil {
+			return Result{}, fmt.Errorf("failed to decode event %s: %w", record.EventID, err)
+		}
+		events = append(events, event)
+	}
+	return s.Receive(ctx, events)
+}
+
+func (s *Service) latestLogID(ctx context.Context) (string, error) {
+	var record Record
+	err := s.db.WithContext(ctx).Where("source = ?", SourceLog).
+		Order("occurred_at DESC NULLS LAST").Order("received_at DESC").First(&record).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch latest log event: %w", err)
+	}
+	return record.EventID, nil
+}
@@ -0,0 +1,82 @@
+package auth0sync
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	envWebhookSecret = "AUTH0_WEBHOOK_SECRET"
+
+	headerTimestamp     = "X-Webhook-Timestamp"
+	headerSignature     = "X-Webhook-Signature"
+	headerAuthorization = "Authorization"
+	signaturePrefix     = "sha256="
+
+	// signatureTolerance is how old a signed request may be, which bounds replays of
+	// captured requests
+	signatureTolerance = 5 * time.Minute
+)
+
+var (
+	ErrMissingSignature = errors.New("missing webhook signature")
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrExpiredSignature = errors.New("webhook signature timestamp outside tolerance")
+)
+
+// SecretFromEnv returns the shared webhook secret, or an empty string when the webhook
+// is not configured
+func SecretFromEnv() string {
+	return strings.TrimSpace(os.Getenv(envWebhookSecret))
+}
+
+// Verify checks that a request was sent by Auth0. Actions sign the body with
+// HMAC-SHA256 over "<timestamp>.<body>" in the X-Webhook-Signature header. Log streams
+// can't sign requests, so they send the secret as their authorization token instead.
+func Verify(secret string, header http.Header, body []byte, now time.Time) error {
+	signature := header.Get(headerSignature)
+	if signature == "" {
+		token := strings.TrimPrefix(header.Get(headerAuthorization), "Bearer ")
+		if token == "" {
+			return ErrMissingSignature
+		}
+		if subtle.ConstantTimeCompare([]byte(token), []byte(secret)) != 1 {
+			return ErrInvalidSignature
+		}
+		return nil
+	}
+
+	timestamp, err := strconv.ParseInt(header.Get(headerTimestamp), 10, 64)
+	if err != nil {
+		return ErrMissingSignature
+	}
+	signedAt := time.Unix(timestamp, 0)
+	if now.Sub(signedAt).Abs() > signatureTolerance {
+		return ErrExpiredSignature
+	}
+
+	expected, err := hex.DecodeString(strings.TrimPrefix(signature, signaturePrefix))
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	if !hmac.Equal(expected, sign(secret, timestamp, body)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func sign(secret string, timestamp int64, body []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,141 @@
+// Package authz implements the authorization directives of the GraphQL schema, so the
+// permissions of each field are declared next to it and checked before it resolves:
+//
+//	@auth                       the caller is signed in with an account
+//	@hasRole(role)              the caller holds the Casbin role, directly or inherited
+//	@tripRole(min, arg, of)     the caller is a trip member with at least the given role
+//
+// Callers using an access token also need its read scope for queries, its write scope
+// for mutations, and its admin scope for admin-only fields.
+package authz
+
+import (
+	"context"
+	"strings"
+
+	"eztrip/api-go/accesstoken"
+	appErrors "eztrip/api-go/errors"
+	"eztrip/api-go/logger"
+	"eztrip/api-go/rbac"
+	"eztrip/api-go/trip"
+	"eztrip/api-go/user"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/vektah/gqlparser/v2/ast"
+	"gorm.io/gorm"
+)
+
+// Directives checks permissions for the schema's authorization directives
+type Directives struct {
+	db          *gorm.DB
+	tripService *trip.Service
+}
+
+// NewDirectives creates the authorization directives
+func NewDirectives(db *gorm.DB, tripService *trip.Service) *Directives {
+	return &Directives{
+		db:          db,
+		tripService: tripService,
+	}
+}
+
+// Auth requires a signed-in caller with an account
+func (d *Directives) Auth(ctx context.Context, obj interface{}, next graphql.Resolver) (interface{}, error) {
+	if _, _, err := user.GetAuthenticatedUser(ctx, d.db); err != nil {
+		return nil, err
+	}
+	if err := requireOperationScope(ctx); err != nil {
+		return nil, err
+	}
+	return next(ctx)
+}
+
+// HasRole requires the caller to hold a Casbin role
+func (d *Directives) HasRole(ctx context.Context, obj interface{}, next graphql.Resolver, role string) (interface{}, error) {
+	_, userID, err := user.GetAuthenticatedUser(ctx, d.db)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := rbac.RequireRole(ctx, userID, role); err != nil {
+		return nil, err
+	}
+	if err := requireOperationScope(ctx); err != nil {
+		return nil, err
+	}
+	if role == "admin" {
+		if err := accesstoken.RequireScope(ctx, accesstoken.ScopeAdmin); err != nil {
+			return nil, err
+		}
+	}
+	return next(ctx)
+}
+
+// TripRole requires the caller to be a member of a trip with at least the minimum role.
+// The trip is the parent object for fields of Trip, and otherwise the trip of the object
+// of the given kind whose ID is in the field argument named by arg.
+func (d *Directives) TripRole(ctx context.Context, obj interface{}, next graphql.Resolver, min trip.MemberRole, arg string, of TripEntity) (interface{}, error) {
+	_, userID, err := user.GetAuthenticatedUser(ctx, d.db)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := requireOperationScope(ctx); err != nil {
+		return nil, err
+	}
+
+	tripID, err := d.tripIDFor(ctx, obj, arg, of)
+	if err != nil {
+		return nil, err
+	}
+
+	role, isMember, err := d.tripService.GetMemberRole(ctx, tripID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isMember || !role.AtLeast(min) {
+		logger.Log.WithFields(logrus.Fields{
+			"trip_id":       tripID,
+			"user_id":       userID,
+			"role":          role,
+			"required_role": min,
+			"field":         graphql.GetFieldContext(ctx).Field.Name,
+		}).Warn("Access denied - insufficient trip role")
+
+		if isMember {
+			return nil, appErrors.Forbidden("Only the trip " + string(min) + " can perform this action")
+		}
+		return nil, appErrors.Forbidden("You don't have permission to access this trip")
+	}
+
+	return next(ctx)
+}
+
+// requireOperationScope requires the write scope for mutations and the read scope
+// otherwise, for callers using an access token
+func requireOperationScope(ctx context.Context) error {
+	if graphql.GetOperationContext(ctx).Operation.Operation == ast.Mutation {
+		return accesstoken.RequireScope(ctx, accesstoken.ScopeWrite)
+	}
+	return accesstoken.RequireScope(ctx, accesstoken.ScopeRead)
+}
+
+// tripIDFor finds the trip a field is about
+func (d *Directives) tripIDFor(ctx context.Context, obj interface{}, arg string, of TripEntity) (uuid.UUID, error) {
+	if t, ok := obj.(*trip.Trip); ok {
+		return t.ID, nil
+	}
+
+	value, _ := graphql.GetFieldContext(ctx).Args[arg].(string)
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, appErrors.ValidationError(arg, "Invalid "+strings.ToLower(tripEntityNames[of])+" ID")
+	}
+	if of == TripEntityTrip {
+		return id, nil
+	}
+	return d.tripIDOf(ctx, of, id)
+}
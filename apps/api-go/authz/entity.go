@@ -0,0 +1,78 @@
+package authz
+
+import (
+	"context"
+
+	appErrors "eztrip/api-go/errors"
+	"eztrip/api-go/logger"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// TripEntity is a kind of object that belongs to a trip, so @tripRole can check
+// membership of the trip for fields that take the object's ID
+type TripEntity string
+
+const (
+	TripEntityTrip       TripEntity = "trip"
+	TripEntityActivity   TripEntity = "activity"
+	TripEntityLodging    TripEntity = "lodging"
+	TripEntityTraveler   TripEntity = "traveler"
+	TripEntityExpense    TripEntity = "expense"
+	TripEntitySettlement TripEntity = "settlement"
+	TripEntityShareLink  TripEntity = "shareLink"
+)
+
+// tripEntityNames name each kind of object in error messages
+var tripEntityNames = map[TripEntity]string{
+	TripEntityTrip:       "Trip",
+	TripEntityActivity:   "Activity",
+	TripEntityLodging:    "Lodging",
+	TripEntityTraveler:   "Traveler",
+	TripEntityExpense:    "Expense",
+	TripEntitySettlement: "Settlement",
+	TripEntityShareLink:  "Share link",
+}
+
+// tripEntityTables are the tables of objects with a trip_id column
+var tripEntityTables = map[TripEntity]string{
+	TripEntityLodging:    "lodgings",
+	TripEntityTraveler:   "travelers",
+	TripEntityExpense:    "expenses",
+	TripEntitySettlement: "settlements",
+	TripEntityShareLink:  "share_links",
+}
+
+// tripIDOf finds the trip an object belongs to. Activities belong to a trip through
+// their itinerary day.
+func (d *Directives) tripIDOf(ctx context.Context, of TripEntity, id uuid.UUID) (uuid.UUID, error) {
+	query := d.db.WithContext(ctx)
+	column := "trip_id"
+	if of == TripEntityActivity {
+		query = query.Table("activities").
+			Joins("JOIN itinerary_days ON itinerary_days.id = activities.itinerary_day_id").
+			Where("activities.id = ? AND activities.deleted_at IS NULL", id)
+		column = "itinerary_days.trip_id"
+	} else {
+		table, ok := tripEntityTables[of]
+		if !ok {
+			return uuid.Nil, appErrors.Internal("Unknown trip entity")
+		}
+		query = query.Table(table).Where("id = ? AND deleted_at IS NULL", id)
+	}
+
+	var tripIDs []uuid.UUID
+	if err := query.Limit(1).Pluck(column, &tripIDs).Error; err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"entity": of,
+			"id":     id,
+			"error":  err.Error(),
+		}).Error("Failed to fetch trip of entity")
+		return uuid.Nil, appErrors.Internal("Failed to fetch trip")
+	}
+	if len(tripIDs) == 0 {
+		return uuid.Nil, appErrors.NotFound(tripEntityNames[of])
+	}
+	return tripIDs[0], nil
+}
@@ -0,0 +1,49 @@
+package calendar
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const tokenBytes = 32
+
+// Feed is a trip member's secret calendar subscription. Only a hash of the token is
+// stored, so the subscription URL is shown once when the token is created or rotated.
+type Feed struct {
+	ID             uuid.UUID      `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	TripID         uuid.UUID      `gorm:"type:uuid;not null;index"`
+	UserID         uuid.UUID      `gorm:"type:uuid;not null;index"`
+	TokenHash      string         `gorm:"column:token_hash;not null;uniqueIndex"`
+	LastAccessedAt *time.Time     `gorm:"column:last_accessed_at"`
+	CreatedAt      time.Time      `gorm:"column:created_at"`
+	UpdatedAt      time.Time      `gorm:"column:updated_at"`
+	DeletedAt      gorm.DeletedAt `gorm:"column:deleted_at;index"` // Set when the token is rotated or revoked
+
+	URL *string `gorm:"-"` // Only populated right after the token is created
+}
+
+// TableName specifies the table name for the Feed model
+func (Feed) TableName() string {
+	return "calendar_feeds"
+}
+
+// generateToken returns a random URL-safe token and its hash
+func generateToken() (string, string, error) {
+	raw := make([]byte, tokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+	return token, hashToken(token), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
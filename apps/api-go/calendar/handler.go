@@ -0,0 +1,59 @@
+package calendar
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	appErrors "eztrip/api-go/errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const contentType = "text/calendar; charset=utf-8"
+
+// Handler serves calendar documents over plain HTTP for calendar apps
+type Handler struct {
+	Service *Service
+}
+
+// NewHandler creates a new calendar HTTP handler
+func NewHandler(service *Service) *Handler {
+	return &Handler{
+		Service: service,
+	}
+}
+
+// Export downloads a trip as an .ics file for the authenticated user
+func (h *Handler) Export(c *gin.Context) {
+	tripID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "bad_request", "message": "Invalid trip ID"})
+		return
+	}
+
+	t, body, err := h.Service.Export(c.Request.Context(), tripID)
+	if err != nil {
+		c.JSON(appErrors.HTTPStatus(err), gin.H{"error": "calendar_export_failed", "message": appErrors.Message(err)})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.ics"`, t.FileName()))
+	c.Data(http.StatusOK, contentType, body)
+}
+
+// Feed serves the subscription feed behind a secret token. Calendar apps can't send
+// bearer tokens, so this route is public and the token in the path is the credential.
+func (h *Handler) Feed(c *gin.Context) {
+	token := strings.TrimSuffix(c.Param("token"), ".ics")
+
+	body, err := h.Service.RenderFeed(c.Request.Context(), token)
+	if err != nil {
+		c.JSON(appErrors.HTTPStatus(err), gin.H{"error": "calendar_feed_unavailable", "message": appErrors.Message(err)})
+		return
+	}
+
+	c.Header("Cache-Control", "private, max-age=300")
+	c.Data(http.StatusOK, contentType, body)
+}
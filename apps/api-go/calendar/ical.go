@@ -0,0 +1,144 @@
+package calendar
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"eztrip/api-go/trip"
+)
+
+const (
+	productID      = "-//EZTrip//Trip Calendar//EN"
+	uidDomain      = "eztrip.ai"
+	icalTimeFormat = "20060102T150405Z"
+	maxLineOctets  = 75
+	refreshPeriod  = "PT1H" // How often subscribed calendars should poll for changes
+)
+
+// Render builds an iCalendar document with one event per activity of the trip.
+// Event UIDs are derived from activity IDs so calendar apps update events in place.
+func Render(t *trip.Trip) []byte {
+	var buf bytes.Buffer
+	w := &writer{buf: &buf}
+
+	w.line("BEGIN:VCALENDAR")
+	w.line("VERSION:2.0")
+	w.line("PRODID:" + productID)
+	w.line("CALSCALE:GREGORIAN")
+	w.line("METHOD:PUBLISH")
+	w.line("X-WR-CALNAME:" + escapeText(t.Title))
+	w.line("X-WR-TIMEZONE:" + t.TimeZone)
+	w.line("REFRESH-INTERVAL;VALUE=DURATION:" + refreshPeriod)
+	w.line("X-PUBLISHED-TTL:" + refreshPeriod)
+
+	for _, day := range t.Itinerary {
+		for i := range day.Activities {
+			writeEvent(w, &day.Activities[i])
+		}
+	}
+
+	w.line("END:VCALENDAR")
+	return buf.Bytes()
+}
+
+// writeEvent writes an activity as a VEVENT with times in UTC
+func writeEvent(w *writer, activity *trip.Activity) {
+	w.line("BEGIN:VEVENT")
+	w.line(fmt.Sprintf("UID:%s@%s", activity.ID, uidDomain))
+	w.line("DTSTAMP:" + formatTime(activity.UpdatedAt))
+	w.line("LAST-MODIFIED:" + formatTime(activity.UpdatedAt))
+	w.line("DTSTART:" + formatTime(activity.Time))
+	if activity.EndTime != nil && activity.EndTime.After(activity.Time) {
+		w.line("DTEND:" + formatTime(*activity.EndTime))
+	}
+	w.line("SUMMARY:" + escapeText(activity.Title))
+
+	if location := eventLocation(activity); location != "" {
+		w.line("LOCATION:" + escapeText(location))
+	}
+
+	if description := eventDescription(activity); description != "" {
+		w.line("DESCRIPTION:" + escapeText(description))
+	}
+
+	w.line("CATEGORIES:" + escapeText(string(activity.Category)))
+	w.line("END:VEVENT")
+}
+
+func eventLocation(activity *trip.Activity) string {
+	if activity.Location != "" {
+		return activity.Location
+	}
+	if activity.TransportLeg != nil {
+		return activity.TransportLeg.Origin + " → " + activity.TransportLeg.Destination
+	}
+	return ""
+}
+
+func eventDescription(activity *trip.Activity) string {
+	var parts []string
+	if activity.Description != "" {
+		parts = append(parts, activity.Description)
+	}
+
+	if leg := activity.TransportLeg; leg != nil {
+		details := []string{fmt.Sprintf("%s from %s to %s", leg.Mode, leg.Origin, leg.Destination)}
+		if leg.Carrier != nil {
+			details = append(details, "Carrier: "+*leg.Carrier)
+		}
+		if leg.ConfirmationNumber != nil {
+			details = append(details, "Confirmation: "+*leg.ConfirmationNumber)
+		}
+		if leg.Seat != nil {
+			details = append(details, "Seat: "+*leg.Seat)
+		}
+		parts = append(parts, strings.Join(details, "\n"))
+	}
+
+	if activity.Notes != "" {
+		parts = append(parts, "Notes: "+activity.Notes)
+	}
+
+	return strings.Join(parts, "\n\n")
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(icalTimeFormat)
+}
+
+// escapeText escapes a TEXT value as required by RFC 5545
+func escapeText(value string) string {
+	replacer := strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	)
+	return replacer.Replace(value)
+}
+
+// writer writes content lines, folding them at 75 octets without splitting characters
+type writer struct {
+	buf *bytes.Buffer
+}
+
+func (w *writer) line(content string) {
+	limit := maxLineOctets
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(content[cut]) {
+			cut--
+		}
+		w.buf.WriteString(content[:cut])
+		w.buf.WriteString("\r\n ")
+		content = content[cut:]
+		limit = maxLineOctets - 1 // Continuation lines start with a space
+	}
+	w.buf.WriteString(content)
+	w.buf.WriteString("\r\n")
+}
@@ -0,0 +1,49 @@
+package calendar
+
+import (
+	"context"
+
+	"eztrip/api-go/trip"
+
+	"github.com/google/uuid"
+)
+
+// Resolver handles GraphQL resolver operations for calendar feeds
+type Resolver struct {
+	Service *Service
+}
+
+// NewResolver creates a new calendar resolver
+func NewResolver(service *Service) *Resolver {
+	return &Resolver{
+		Service: service,
+	}
+}
+
+// TripCalendarFeed returns the authenticated user's feed for an already authorized trip
+func (r *Resolver) TripCalendarFeed(ctx context.Context, t *trip.Trip) (*Feed, error) {
+	return r.Service.GetFeed(ctx, t.ID)
+}
+
+// RotateCalendarFeed creates a new subscription URL for a trip, replacing any previous one
+func (r *Resolver) RotateCalendarFeed(ctx context.Context, tripID string) (*Feed, error) {
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Service.RotateToken(ctx, id)
+}
+
+// RevokeCalendarFeed disables the subscription URL for a trip
+func (r *Resolver) RevokeCalendarFeed(ctx context.Context, tripID string) (bool, error) {
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.Service.RevokeToken(ctx, id); err != nil {
+		return false, err
+	}
+	return true, nil
+}
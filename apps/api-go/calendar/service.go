@@ -0,0 +1,192 @@
+package calendar
+
+import (
+	"context"
+	"os"
+	"strings"
+	"time"
+
+	appErrors "eztrip/api-go/errors"
+	"eztrip/api-go/logger"
+	"eztrip/api-go/trip"
+	"eztrip/api-go/user"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	envPublicURL     = "PUBLIC_API_URL"
+	defaultPublicURL = "http://localhost:8080"
+
+	// FeedPathPrefix is where subscription feeds are served, authenticated by their token alone
+	FeedPathPrefix = "/calendar/"
+)
+
+// Service handles calendar export and subscription feeds
+type Service struct {
+	db          *gorm.DB
+	tripService *trip.Service
+	publicURL   string
+}
+
+// NewService creates a new calendar service
+func NewService(db *gorm.DB, tripService *trip.Service) *Service {
+	publicURL := strings.TrimRight(strings.TrimSpace(os.Getenv(envPublicURL)), "/")
+	if publicURL == "" {
+		publicURL = defaultPublicURL
+	}
+
+	return &Service{
+		db:          db,
+		tripService: tripService,
+		publicURL:   publicURL,
+	}
+}
+
+// Export renders a trip the user has access to as an iCalendar document
+func (s *Service) Export(ctx context.Context, tripID uuid.UUID) (*trip.Trip, []byte, error) {
+	t, err := s.tripService.GetByID(ctx, tripID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return t, Render(t), nil
+}
+
+// RenderFeed renders the trip behind a subscription token. The token's owner must still
+// be a member of the trip, so removing a collaborator also cuts off their feed.
+func (s *Service) RenderFeed(ctx context.Context, token string) ([]byte, error) {
+	var feed Feed
+	if err := s.db.WithContext(ctx).First(&feed, "token_hash = ?", hashToken(token)).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, appErrors.NotFound("Calendar feed")
+		}
+		logger.Log.WithField("error", err.Error()).Error("Failed to fetch calendar feed")
+		return nil, appErrors.Internal("Failed to fetch calendar feed")
+	}
+
+	t, err := s.tripService.GetByIDForUser(ctx, feed.TripID, feed.UserID)
+	if err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"feed_id": feed.ID,
+			"trip_id": feed.TripID,
+			"error":   err.Error(),
+		}).Warn("Calendar feed no longer grants access to its trip")
+		return nil, appErrors.NotFound("Calendar feed")
+	}
+
+	// Access tracking is best effort and shouldn't fail the feed
+	now := time.Now()
+	if err := s.db.WithContext(ctx).Model(&feed).UpdateColumn("last_accessed_at", now).Error; err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"feed_id": feed.ID,
+			"error":   err.Error(),
+		}).Warn("Failed to record calendar feed access")
+	}
+
+	return Render(t), nil
+}
+
+// GetFeed returns the authenticated user's active feed for a trip, or nil if there is none
+func (s *Service) GetFeed(ctx context.Context, tripID uuid.UUID) (*Feed, error) {
+	_, userID, err := user.GetAuthenticatedUser(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+
+	var feed Feed
+	err = s.db.WithContext(ctx).
+		Where("trip_id = ? AND user_id = ?", tripID, userID).
+		First(&feed).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		logger.Log.WithFields(logrus.Fields{
+			"trip_id": tripID,
+			"error":   err.Error(),
+		}).Error("Failed to fetch calendar feed for trip")
+		return nil, appErrors.Internal("Failed to fetch calendar feed")
+	}
+
+	return &feed, nil
+}
+
+// RotateToken replaces the user's feed token for a trip, invalidating any previous URL
+func (s *Service) RotateToken(ctx context.Context, tripID uuid.UUID) (*Feed, error) {
+	if _, err := s.tripService.GetByID(ctx, tripID); err != nil {
+		return nil, err
+	}
+
+	_, userID, err := user.GetAuthenticatedUser(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+
+	token, tokenHash, err := generateToken()
+	if err != nil {
+		logger.Log.WithField("error", err.Error()).Error("Failed to generate calendar feed token")
+		return nil, appErrors.Internal("Failed to create calendar feed")
+	}
+
+	feed := &Feed{
+		TripID:    tripID,
+		UserID:    userID,
+		TokenHash: tokenHash,
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("trip_id = ? AND user_id = ?", tripID, userID).Delete(&Feed{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(feed).Error
+	})
+
+	if err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"trip_id": tripID,
+			"error":   err.Error(),
+		}).Error("Failed to rotate calendar feed token")
+		return nil, appErrors.Internal("Failed to create calendar feed")
+	}
+
+	url := s.publicURL + FeedPathPrefix + token + ".ics"
+	feed.URL = &url
+
+	logger.Log.WithFields(logrus.Fields{
+		"trip_id": tripID,
+		"feed_id": feed.ID,
+	}).Info("Calendar feed token rotated successfully")
+
+	return feed, nil
+}
+
+// RevokeToken disables the user's feed for a trip
+func (s *Service) RevokeToken(ctx context.Context, tripID uuid.UUID) error {
+	if _, err := s.tripService.GetByID(ctx, tripID); err != nil {
+		return err
+	}
+
+	_, userID, err := user.GetAuthenticatedUser(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
+	result := s.db.WithContext(ctx).Where("trip_id = ? AND user_id = ?", tripID, userID).Delete(&Feed{})
+	if result.Error != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"trip_id": tripID,
+			"error":   result.Error.Error(),
+		}).Error("Failed to revoke calendar feed token")
+		return appErrors.Internal("Failed to revoke calendar feed")
+	}
+
+	if result.RowsAffected == 0 {
+		return appErrors.NotFound("Calendar feed")
+	}
+
+	logger.Log.WithField("trip_id", tripID).Info("Calendar feed token revoked successfully")
+	return nil
+}
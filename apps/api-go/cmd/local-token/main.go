@@ -0,0 +1,60 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+
+	"eztrip/api-go/identity"
+	"eztrip/api-go/identity/local"
+	"eztrip/api-go/logger"
+)
+
+// Mints an access token from the local identity provider. The user is looked up by
+// email in the local users file and added on first use, so repeated runs give tokens
+// for the same subject.
+func main() {
+	email := flag.String("email", "", "Email of the user to mint a token for (required)")
+	firstName := flag.String("first-name", "", "First name of a new user (defaults to the email's local part)")
+	lastName := flag.String("last-name", "", "Last name of a new user")
+	ttl := flag.Duration("ttl", local.DefaultTokenTTL, "How long the token is valid")
+	flag.Parse()
+
+	if *email == "" {
+		logger.Log.Fatal("-email is required")
+	}
+
+	config := local.ConfigFromEnv()
+	directory := local.NewDirectory(config)
+
+	account, err := directory.GetUserByEmail(*email)
+	if errors.Is(err, identity.ErrUserNotFound) {
+		if *firstName == "" {
+			*firstName, _, _ = strings.Cut(*email, "@")
+		}
+		account, err = directory.CreateUser(*email, "", *firstName, *lastName)
+	}
+	if err != nil {
+		logger.Log.Fatalf("Failed to find local user: %v", err)
+	}
+
+	issuer, err := local.NewIssuer(config)
+	if err != nil {
+		logger.Log.Fatalf("Failed to initialize local issuer: %v", err)
+	}
+
+	token, err := issuer.Mint(identity.Claims{
+		Subject:       account.ID,
+		Email:         account.Email,
+		EmailVerified: account.EmailVerified,
+		Name:          account.Name,
+		GivenName:     account.FirstName,
+		FamilyName:    account.LastName,
+	}, *ttl)
+	if err != nil {
+		logger.Log.Fatalf("Failed to mint token: %v", err)
+	}
+
+	fmt.Println(token)
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"eztrip/api-go/db"
+	"eztrip/api-go/importer"
+	"eztrip/api-go/logger"
+	"eztrip/api-go/privacy"
+	"eztrip/api-go/rbac"
+	"eztrip/api-go/trip"
+
+	"github.com/sirupsen/logrus"
+
+	// Register identity providers
+	_ "eztrip/api-go/auth0"
+	_ "eztrip/api-go/identity/local"
+)
+
+// Erases the users whose erasure grace period has ended and removes expired data export
+// archives. Meant to run on a schedule, such as daily.
+func main() {
+	dbConfig := db.GetConfigFromEnv()
+	database, err := db.NewGormDB(dbConfig)
+	if err != nil {
+		logger.Log.Fatalf("Failed to connect to database: %v", err)
+	}
+
+	enforcer, err := rbac.NewEnforcer(database)
+	if err != nil {
+		logger.Log.Fatalf("Failed to initialize RBAC enforcer: %v", err)
+	}
+
+	ctx := context.Background()
+	now := time.Now()
+	service := privacy.NewService(database, importer.NewService(database, trip.NewService(database)))
+
+	deleted, err := service.DeleteExpiredExports(ctx, now)
+	if err != nil {
+		logger.Log.Fatalf("Failed to delete expired data exports: %v", err)
+	}
+	logger.Log.WithField("deleted", deleted).Info("Expired data exports deleted")
+
+	result, err := service.ProcessDue(ctx, enforcer, now)
+	fields := logrus.Fields{
+		"erased": result.Erased,
+		"failed": result.Failed,
+	}
+	if err != nil {
+		logger.Log.WithFields(fields).Fatalf("Erasure run failed: %v", err)
+	}
+	if result.Failed > 0 {
+		logger.Log.WithFields(fields).Fatal("Some erasures failed and will be retried on the next run")
+	}
+	logger.Log.WithFields(fields).Info("Erasure run completed successfully")
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"flag"
+
+	"eztrip/api-go/auth0"
+	"eztrip/api-go/auth0sync"
+	"eztrip/api-go/db"
+	"eztrip/api-go/logger"
+
+	"github.com/sirupsen/logrus"
+)
+
+func main() {
+	from := flag.String("from", "", "Auth0 log event ID to replay after (defaults to the latest log event received)")
+	failed := flag.Bool("failed", false, "Retry recorded events that failed to apply instead of fetching logs")
+	flag.Parse()
+
+	dbConfig := db.GetConfigFromEnv()
+	database, err := db.NewGormDB(dbConfig)
+	if err != nil {
+		logger.Log.Fatalf("Failed to connect to database: %v", err)
+	}
+
+	ctx := context.Background()
+	service := auth0sync.NewService(database)
+
+	var result auth0sync.Result
+	if *failed {
+		logger.Log.Info("Retrying failed Auth0 events...")
+		result, err = service.RetryFailed(ctx)
+	} else {
+		client, clientErr := auth0.NewClient()
+		if clientErr != nil {
+			logger.Log.Fatalf("Failed to initialize Auth0 client: %v", clientErr)
+		}
+		logger.Log.WithField("from", *from).Info("Replaying Auth0 log events...")
+		result, err = service.ReplayLogs(ctx, client, *from)
+	}
+
+	fields := logrus.Fields{
+		"processed": result.Processed,
+		"ignored":   result.Ignored,
+		"failed":    result.Failed,
+		"duplicate": result.Duplicate,
+	}
+	if err != nil {
+		logger.Log.WithFields(fields).Fatalf("Replay failed: %v", err)
+	}
+	logger.Log.WithFields(fields).Info("Replay completed successfully")
+}
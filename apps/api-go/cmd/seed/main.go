@@ -4,6 +4,10 @@ import (
 	"eztrip/api-go/db"
 	"eztrip/api-go/logger"
 	"eztrip/api-go/seeds"
+
+	// Register identity providers
+	_ "eztrip/api-go/auth0"
+	_ "eztrip/api-go/identity/local"
 )
 
 func main() {
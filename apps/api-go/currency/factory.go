@@ -0,0 +1,51 @@
+package currency
+
+import (
+	"fmt"
+	"os"
+)
+
+const (
+	envProvider = "CURRENCY_RATE_PROVIDER"
+
+	ProviderFile = "file"
+)
+
+// providerFactory maps provider names to their constructor functions
+// Providers register themselves via RegisterProvider
+var providerFactory = map[string]func() (Provider, error){}
+
+// RegisterProvider registers a provider constructor
+func RegisterProvider(name string, factory func() (Provider, error)) {
+	providerFactory[name] = factory
+}
+
+// NewDefaultService creates a currency service using environment configuration
+// Set CURRENCY_RATE_PROVIDER env var to select provider
+// Defaults to file if not specified
+func NewDefaultService() (*Service, error) {
+	providerName := os.Getenv(envProvider)
+	if providerName == "" {
+		providerName = ProviderFile
+	}
+
+	factory, exists := providerFactory[providerName]
+	if !exists {
+		return nil, fmt.Errorf("unknown currency rate provider: %s (available: %v)", providerName, availableProviders())
+	}
+
+	provider, err := factory()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize %s provider: %w", providerName, err)
+	}
+
+	return NewService(provider), nil
+}
+
+func availableProviders() []string {
+	providers := make([]string, 0, len(providerFactory))
+	for name := range providerFactory {
+		providers = append(providers, name)
+	}
+	return providers
+}
@@ -0,0 +1,62 @@
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"eztrip/api-go/currency"
+)
+
+func init() {
+	currency.RegisterProvider(currency.ProviderFile, func() (currency.Provider, error) {
+		return NewProvider()
+	})
+}
+
+const (
+	envRatesFile = "CURRENCY_RATES_FILE"
+)
+
+// Provider implements the currency Provider interface by reading a JSON rate table from disk.
+// The file is re-read on every refresh so rates can be updated without a restart.
+type Provider struct {
+	path string
+}
+
+// NewProvider creates a new file provider using the CURRENCY_RATES_FILE environment variable
+func NewProvider() (*Provider, error) {
+	path := strings.TrimSpace(os.Getenv(envRatesFile))
+	if path == "" {
+		return nil, fmt.Errorf("CURRENCY_RATES_FILE environment variable is required")
+	}
+
+	return &Provider{path: path}, nil
+}
+
+// Rates reads and parses the rate table file
+func (p *Provider) Rates(_ context.Context) (*currency.RateTable, error) {
+	data, err := os.ReadFile(p.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rates file: %w", err)
+	}
+
+	var table currency.RateTable
+	if err := json.Unmarshal(data, &table); err != nil {
+		return nil, fmt.Errorf("failed to parse rates file: %w", err)
+	}
+
+	if table.Base == "" {
+		return nil, fmt.Errorf("rates file is missing a base currency")
+	}
+
+	normalized := make(map[string]float64, len(table.Rates))
+	for code, rate := range table.Rates {
+		normalized[strings.ToUpper(code)] = rate
+	}
+	table.Rates = normalized
+
+	return &table, nil
+}
@@ -0,0 +1,81 @@
+package currency
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultCacheTTL = 1 * time.Hour
+)
+
+// Service converts amounts between currencies using rates from a provider
+type Service struct {
+	provider Provider
+	cacheTTL time.Duration
+
+	mu        sync.Mutex
+	table     *RateTable
+	fetchedAt time.Time
+}
+
+// NewService creates a new currency service with the given provider
+func NewService(provider Provider) *Service {
+	return &Service{
+		provider: provider,
+		cacheTTL: defaultCacheTTL,
+	}
+}
+
+// Convert converts an amount from one currency to another, rounded to the decimals of
+// the target currency
+func (s *Service) Convert(ctx context.Context, amount float64, from, to string) (float64, error) {
+	if strings.EqualFold(from, to) {
+		return amount, nil
+	}
+
+	table, err := s.rates(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	rate, err := table.Rate(from, to)
+	if err != nil {
+		return 0, err
+	}
+
+	return RoundAmount(amount*rate, to), nil
+}
+
+// rates returns the cached rate table, refreshing it from the provider when stale
+func (s *Service) rates(ctx context.Context) (*RateTable, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.table != nil && time.Since(s.fetchedAt) < s.cacheTTL {
+		return s.table, nil
+	}
+
+	table, err := s.provider.Rates(ctx)
+	if err != nil {
+		if s.table != nil {
+			// Keep serving the last known rates rather than failing every conversion
+			return s.table, nil
+		}
+		return nil, fmt.Errorf("failed to load exchange rates: %w", err)
+	}
+
+	s.table = table
+	s.fetchedAt = time.Now()
+	return table, nil
+}
+
+// RoundAmount rounds a monetary amount to the decimals of its currency
+func RoundAmount(amount float64, code string) float64 {
+	scale := math.Pow10(MinorUnits(code))
+	return math.Round(amount*scale) / scale
+}
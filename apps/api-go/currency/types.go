@@ -0,0 +1,72 @@
+package currency
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// RateTable holds exchange rates relative to a base currency.
+// A rate of 0.92 for EUR with base USD means 1 USD = 0.92 EUR.
+type RateTable struct {
+	Base  string             `json:"base"`
+	Rates map[string]float64 `json:"rates"`
+}
+
+// Rate returns the multiplier that converts an amount in one currency into another,
+// crossing through the base currency when neither side is the base
+func (t *RateTable) Rate(from, to string) (float64, error) {
+	from = strings.ToUpper(from)
+	to = strings.ToUpper(to)
+
+	if from == to {
+		return 1, nil
+	}
+
+	fromRate, err := t.rateFromBase(from)
+	if err != nil {
+		return 0, err
+	}
+
+	toRate, err := t.rateFromBase(to)
+	if err != nil {
+		return 0, err
+	}
+
+	return toRate / fromRate, nil
+}
+
+func (t *RateTable) rateFromBase(code string) (float64, error) {
+	if code == strings.ToUpper(t.Base) {
+		return 1, nil
+	}
+
+	rate, ok := t.Rates[code]
+	if !ok || rate <= 0 {
+		return 0, fmt.Errorf("no exchange rate for %s", code)
+	}
+	return rate, nil
+}
+
+// Provider defines the interface for exchange rate sources
+type Provider interface {
+	// Rates returns the current exchange rate table
+	Rates(ctx context.Context) (*RateTable, error)
+}
+
+// minorUnits lists the ISO 4217 currencies that don't divide into hundredths
+var minorUnits = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0,
+	"PYG": 0, "RWF": 0, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+	"CLF": 4, "UYW": 4,
+}
+
+// MinorUnits returns the number of decimals a currency's amounts are kept to, such as
+// 0 for JPY and 3 for KWD. Other currencies use two.
+func MinorUnits(code string) int {
+	if units, ok := minorUnits[strings.ToUpper(code)]; ok {
+		return units
+	}
+	return 2
+}
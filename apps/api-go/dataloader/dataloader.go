@@ -0,0 +1,180 @@
+// Package dataloader batches and caches lookups made while resolving one GraphQL request.
+//
+// Field resolvers run concurrently, so instead of querying once per parent object they
+// call Load and the loader collects the keys requested within a short window into a
+// single batch query. Loaders live in a per-request Store, so results are never shared
+// between requests or users.
+package dataloader
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultWait is how long a loader collects keys before fetching them
+	DefaultWait = 2 * time.Millisecond
+	// DefaultMaxBatch is the largest number of keys fetched in one query
+	DefaultMaxBatch = 100
+)
+
+// BatchFunc fetches the values of a batch of keys. Keys missing from the result
+// load as the zero value, e.g. an empty slice or a nil pointer.
+type BatchFunc[K comparable, V any] func(ctx context.Context, keys []K) (map[K]V, error)
+
+// Loader batches and caches the values of one kind of key
+type Loader[K comparable, V any] struct {
+	fetch    BatchFunc[K, V]
+	wait     time.Duration
+	maxBatch int
+
+	mu      sync.Mutex
+	results map[K]*result[V]
+	pending *batch[K, V]
+}
+
+type result[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+type batch[K comparable, V any] struct {
+	ctx     context.Context
+	keys    []K
+	results []*result[V]
+	timer   *time.Timer
+}
+
+// New creates a loader that fetches keys with the given batch function
+func New[K comparable, V any](fetch BatchFunc[K, V]) *Loader[K, V] {
+	return &Loader[K, V]{
+		fetch:    fetch,
+		wait:     DefaultWait,
+		maxBatch: DefaultMaxBatch,
+		results:  make(map[K]*result[V]),
+	}
+}
+
+// Load returns the value of a key, waiting for the batch it is fetched in
+func (l *Loader[K, V]) Load(ctx context.Context, key K) (V, error) {
+	return l.await(ctx, l.enqueue(ctx, key))
+}
+
+// LoadAll returns the values of several keys in order, fetching them in as few batches as possible
+func (l *Loader[K, V]) LoadAll(ctx context.Context, keys []K) ([]V, error) {
+	pending := make([]*result[V], len(keys))
+	for i, key := range keys {
+		pending[i] = l.enqueue(ctx, key)
+	}
+
+	values := make([]V, len(keys))
+	for i, r := range pending {
+		value, err := l.await(ctx, r)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+// enqueue adds a key to the pending batch unless it was requested before
+func (l *Loader[K, V]) enqueue(ctx context.Context, key K) *result[V] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if r, ok := l.results[key]; ok {
+		return r
+	}
+
+	r := &result[V]{done: make(chan struct{})}
+	l.results[key] = r
+
+	if l.pending == nil {
+		b := &batch[K, V]{ctx: ctx}
+		b.timer = time.AfterFunc(l.wait, func() { l.dispatch(b) })
+		l.pending = b
+	}
+	l.pending.keys = append(l.pending.keys, key)
+	l.pending.results = append(l.pending.results, r)
+
+	if len(l.pending.keys) >= l.maxBatch {
+		b := l.pending
+		l.pending = nil
+		b.timer.Stop()
+		go l.run(b)
+	}
+
+	return r
+}
+
+// dispatch fetches a batch when its wait time is over, unless it filled up before
+func (l *Loader[K, V]) dispatch(b *batch[K, V]) {
+	l.mu.Lock()
+	if l.pending != b {
+		l.mu.Unlock()
+		return
+	}
+	l.pending = nil
+	l.mu.Unlock()
+
+	l.run(b)
+}
+
+// run fetches a batch and hands each key its value or the batch's error
+func (l *Loader[K, V]) run(b *batch[K, V]) {
+	values, err := l.fetch(b.ctx, b.keys)
+	for i, r := range b.results {
+		if err != nil {
+			r.err = err
+		} else {
+			r.value = values[b.keys[i]]
+		}
+		close(r.done)
+	}
+}
+
+func (l *Loader[K, V]) await(ctx context.Context, r *result[V]) (V, error) {
+	select {
+	case <-r.done:
+		return r.value, r.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+// Store holds the loaders of one request
+type Store struct {
+	mu      sync.Mutex
+	loaders map[any]any
+}
+
+type storeContextKey struct{}
+
+// WithStore returns a context carrying an empty loader store for a new request
+func WithStore(ctx context.Context) context.Context {
+	return context.WithValue(ctx, storeContextKey{}, &Store{loaders: make(map[any]any)})
+}
+
+// For returns the request's loader registered under name, creating it on first use.
+// Without a store in the context, such as in background jobs, every call gets a new
+// loader, so lookups still work but aren't batched.
+func For[K comparable, V any](ctx context.Context, name any, fetch BatchFunc[K, V]) *Loader[K, V] {
+	store, ok := ctx.Value(storeContextKey{}).(*Store)
+	if !ok {
+		return New(fetch)
+	}
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	if loader, ok := store.loaders[name].(*Loader[K, V]); ok {
+		return loader
+	}
+	loader := New(fetch)
+	store.loaders[name] = loader
+	return loader
+}
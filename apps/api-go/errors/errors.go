@@ -10,6 +10,12 @@ const (
 	ErrCodeForbidden    = "FORBIDDEN"
 	ErrCodeInternal     = "INTERNAL_ERROR"
 	ErrCodeBadRequest   = "BAD_REQUEST"
+
+	// Codes for operations rejected before execution
+	ErrCodeQueryTooDeep           = "QUERY_TOO_DEEP"
+	ErrCodeQueryTooComplex        = "QUERY_TOO_COMPLEX"
+	ErrCodePersistedQueryNotFound = "PERSISTED_QUERY_NOT_FOUND"
+	ErrCodeQueryNotAllowed        = "QUERY_NOT_ALLOWED"
 )
 
 // New creates a GraphQL error with a code and message
@@ -0,0 +1,39 @@
+package errors
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// HTTPStatus maps an error's code to an HTTP status for routes served outside GraphQL
+func HTTPStatus(err error) int {
+	var gqlErr *gqlerror.Error
+	if !errors.As(err, &gqlErr) {
+		return http.StatusInternalServerError
+	}
+
+	code, _ := gqlErr.Extensions["code"].(string)
+	switch code {
+	case ErrCodeValidation, ErrCodeBadRequest:
+		return http.StatusBadRequest
+	case ErrCodeNotFound:
+		return http.StatusNotFound
+	case ErrCodeUnauthorized:
+		return http.StatusUnauthorized
+	case ErrCodeForbidden:
+		return http.StatusForbidden
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// Message returns a client-safe message for an error served outside GraphQL
+func Message(err error) string {
+	var gqlErr *gqlerror.Error
+	if errors.As(err, &gqlErr) {
+		return gqlErr.Message
+	}
+	return "Internal server error"
+}
@@ -0,0 +1,43 @@
+package expense
+
+import (
+	appErrors "eztrip/api-go/errors"
+
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+const (
+	ErrCodeInvalidPayer    = "EXPENSE_INVALID_PAYER"
+	ErrCodeInvalidActivity = "EXPENSE_INVALID_ACTIVITY"
+	ErrCodeInvalidSplit    = "EXPENSE_INVALID_SPLIT"
+	ErrCodeInvalidMember   = "SETTLEMENT_INVALID_MEMBER"
+)
+
+// InvalidPayerError returns an error for when the payer is not a member of the trip
+func InvalidPayerError() *gqlerror.Error {
+	return appErrors.WithField(
+		appErrors.New(ErrCodeInvalidPayer, "Payer must be the trip owner or a collaborator"),
+		"payerId",
+	)
+}
+
+// InvalidActivityError returns an error for when the activity does not belong to the trip
+func InvalidActivityError() *gqlerror.Error {
+	return appErrors.WithField(
+		appErrors.New(ErrCodeInvalidActivity, "Activity does not belong to this trip"),
+		"activityId",
+	)
+}
+
+// InvalidSplitError returns an error for when a split participant is not a trip member or is listed twice
+func InvalidSplitError(message string) *gqlerror.Error {
+	return appErrors.WithField(appErrors.New(ErrCodeInvalidSplit, message), "splits")
+}
+
+// InvalidSettlementMemberError returns an error for when a settlement party is not a member of the trip
+func InvalidSettlementMemberError(field string) *gqlerror.Error {
+	return appErrors.WithField(
+		appErrors.New(ErrCodeInvalidMember, "Settlements can only be recorded between trip members"),
+		field,
+	)
+}
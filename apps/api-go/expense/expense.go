@@ -0,0 +1,104 @@
+package expense
+
+import (
+	"time"
+
+	"eztrip/api-go/trip"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Category represents what an expense was spent on
+type Category string
+
+const (
+	CategoryLodging    Category = "lodging"
+	CategoryFood       Category = "food"
+	CategoryTransport  Category = "transport"
+	CategoryActivities Category = "activities"
+	CategoryShopping   Category = "shopping"
+	CategoryOther      Category = "other"
+)
+
+// SplitMethod represents how an expense is divided among participants
+type SplitMethod string
+
+const (
+	SplitMethodEqual  SplitMethod = "equal"  // Divided evenly among participants
+	SplitMethodShares SplitMethod = "shares" // Divided proportionally to each participant's shares
+	SplitMethodExact  SplitMethod = "exact"  // Each participant owes an exact amount
+)
+
+// Expense represents money spent during a trip
+type Expense struct {
+	ID          uuid.UUID      `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	TripID      uuid.UUID      `gorm:"type:uuid;not null;index"`
+	ActivityID  *uuid.UUID     `gorm:"type:uuid;index"` // Nullable - references activities table
+	PayerID     uuid.UUID      `gorm:"type:uuid;not null;index"`
+	Amount      float64        `gorm:"column:amount;not null"`
+	Currency    string         `gorm:"column:currency;not null"` // ISO 4217 code
+	Category    Category       `gorm:"column:category;not null"`
+	Date        time.Time      `gorm:"column:date;type:date;not null"`
+	Description string         `gorm:"column:description;type:text"`
+	SplitMethod SplitMethod    `gorm:"column:split_method;not null;default:'equal'"`
+	CreatedAt   time.Time      `gorm:"column:created_at"`
+	UpdatedAt   time.Time      `gorm:"column:updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"column:deleted_at;index"`
+
+	// Relationships
+	Shares    []ExpenseShare  `gorm:"foreignKey:ExpenseID;constraint:OnDelete:CASCADE"`
+	Travelers []trip.Traveler `gorm:"many2many:expense_travelers"` // Travelers the expense was for, e.g. a child's ticket
+}
+
+// TableName specifies the table name for the Expense model
+func (Expense) TableName() string {
+	return "expenses"
+}
+
+// Input represents the details submitted when recording or updating an expense.
+// The payer defaults to the authenticated user, and without splits the expense
+// is divided equally among all trip members.
+type Input struct {
+	Amount      float64      `json:"amount" validate:"gt=0"`
+	Currency    string       `json:"currency" validate:"required,iso4217"`
+	Category    Category     `json:"category" validate:"required,oneof=lodging food transport activities shopping other"`
+	Date        string       `json:"date" validate:"required,datetime=2006-01-02"`
+	PayerID     *string      `json:"payerId" validate:"omitempty,uuid"`
+	ActivityID  *string      `json:"activityId" validate:"omitempty,uuid"`
+	Description *string      `json:"description" validate:"omitempty,max=1000"`
+	SplitMethod *SplitMethod `json:"splitMethod" validate:"omitempty,oneof=equal shares exact"`
+	Splits      []SplitInput `json:"splits" validate:"omitempty,dive"`
+	TravelerIDs []string     `json:"travelerIds" validate:"omitempty,dive,uuid"`
+}
+
+// SplitInput names a participant of an expense with their shares or exact amount.
+// Shares are used by the shares method and amounts by the exact method.
+type SplitInput struct {
+	UserID string   `json:"userId" validate:"required,uuid"`
+	Shares *float64 `json:"shares" validate:"omitempty,gt=0"`
+	Amount *float64 `json:"amount" validate:"omitempty,gte=0"`
+}
+
+// CategoryTotal is the amount spent in one category
+type CategoryTotal struct {
+	Category Category
+	Amount   float64
+}
+
+// DayTotal is the amount spent on one date
+type DayTotal struct {
+	Date   time.Time
+	Amount float64
+}
+
+// BudgetSummary reports spending against a trip's budget in the trip's home currency
+type BudgetSummary struct {
+	Currency              string
+	Budget                *float64
+	TotalSpent            float64
+	Remaining             *float64
+	ByCategory            []CategoryTotal
+	ByDay                 []DayTotal
+	UnconvertedExpenseIDs []uuid.UUID // Expenses excluded from totals because no exchange rate was available
+}
@@ -0,0 +1,123 @@
+package expense
+
+import (
+	"context"
+
+	"eztrip/api-go/trip"
+	"eztrip/api-go/validation"
+
+	"github.com/google/uuid"
+)
+
+// Resolver handles GraphQL resolver operations for expenses
+type Resolver struct {
+	Service *Service
+}
+
+// NewResolver creates a new expense resolver
+func NewResolver(service *Service) *Resolver {
+	return &Resolver{
+		Service: service,
+	}
+}
+
+// TripExpenses returns the expenses of an already authorized trip
+func (r *Resolver) TripExpenses(ctx context.Context, t *trip.Trip) ([]*Expense, error) {
+	expenses, err := r.Service.GetByTrip(ctx, t.ID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*Expense, len(expenses))
+	for i := range expenses {
+		result[i] = &expenses[i]
+	}
+	return result, nil
+}
+
+// BudgetSummary returns spending totals of an already authorized trip
+func (r *Resolver) BudgetSummary(ctx context.Context, t *trip.Trip) (*BudgetSummary, error) {
+	return r.Service.GetBudgetSummary(ctx, t)
+}
+
+// AddExpense validates and records an expense on a trip
+func (r *Resolver) AddExpense(ctx context.Context, tripID string, input Input) (*Expense, error) {
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validation.ValidateStruct(input); err != nil {
+		return nil, err
+	}
+
+	return r.Service.Create(ctx, id, input)
+}
+
+// UpdateExpense validates and replaces the details of an expense
+func (r *Resolver) UpdateExpense(ctx context.Context, expenseID string, input Input) (*Expense, error) {
+	id, err := uuid.Parse(expenseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validation.ValidateStruct(input); err != nil {
+		return nil, err
+	}
+
+	return r.Service.Update(ctx, id, input)
+}
+
+// RemoveExpense deletes an expense
+func (r *Resolver) RemoveExpense(ctx context.Context, expenseID string) (bool, error) {
+	id, err := uuid.Parse(expenseID)
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.Service.Delete(ctx, id); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// TripBalances returns member balances and suggested settle-up transfers for a trip
+func (r *Resolver) TripBalances(ctx context.Context, tripID string) (*TripBalances, error) {
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := r.Service.tripService.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Service.GetBalances(ctx, t)
+}
+
+// RecordSettlement validates and records a payment between trip members
+func (r *Resolver) RecordSettlement(ctx context.Context, tripID string, input SettlementInput) (*Settlement, error) {
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validation.ValidateStruct(input); err != nil {
+		return nil, err
+	}
+
+	return r.Service.RecordSettlement(ctx, id, input)
+}
+
+// RemoveSettlement deletes a settlement
+func (r *Resolver) RemoveSettlement(ctx context.Context, settlementID string) (bool, error) {
+	id, err := uuid.Parse(settlementID)
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.Service.DeleteSettlement(ctx, id); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,578 @@
+package expense
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"eztrip/api-go/currency"
+	appErrors "eztrip/api-go/errors"
+	"eztrip/api-go/logger"
+	"eztrip/api-go/trip"
+	"eztrip/api-go/user"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	dateFormat = "2006-01-02"
+)
+
+// Service handles expense operations
+type Service struct {
+	db          *gorm.DB
+	tripService *trip.Service
+	currency    *currency.Service
+}
+
+// NewService creates a new expense service
+func NewService(db *gorm.DB, tripService *trip.Service) *Service {
+	currencyService, err := currency.NewDefaultService()
+	if err != nil {
+		logger.Log.WithError(err).Warn("Currency conversion not configured, only same-currency totals are available")
+	}
+
+	return &Service{
+		db:          db,
+		tripService: tripService,
+		currency:    currencyService,
+	}
+}
+
+// GetByTrip retrieves all expenses of a trip the user has already been authorized for
+func (s *Service) GetByTrip(ctx context.Context, tripID uuid.UUID) ([]Expense, error) {
+	var expenses []Expense
+	err := s.db.WithContext(ctx).
+		Preload("Shares", func(db *gorm.DB) *gorm.DB {
+			return db.Order("expense_shares.amount DESC, expense_shares.user_id ASC")
+		}).
+		Preload("Travelers").
+		Where("trip_id = ?", tripID).
+		Order("date ASC, created_at ASC").
+		Find(&expenses).Error
+
+	if err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"trip_id": tripID,
+			"error":   err.Error(),
+		}).Error("Failed to fetch expenses for trip")
+		return nil, appErrors.Internal("Failed to fetch expenses")
+	}
+
+	return expenses, nil
+}
+
+// Create records a new expense on a trip the user has access to
+func (s *Service) Create(ctx context.Context, tripID uuid.UUID, input Input) (*Expense, error) {
+	t, err := s.tripService.GetByID(ctx, tripID)
+	if err != nil {
+		return nil, err
+	}
+
+	expense, err := s.buildExpense(ctx, t, input)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.db.WithContext(ctx).Create(expense).Error; err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"trip_id": tripID,
+			"error":   err.Error(),
+		}).Error("Failed to create expense")
+		return nil, appErrors.Internal("Failed to create expense")
+	}
+
+	logger.Log.WithFields(logrus.Fields{
+		"trip_id":    tripID,
+		"expense_id": expense.ID,
+	}).Info("Expense created successfully")
+
+	return expense, nil
+}
+
+// Update replaces the details of an existing expense
+func (s *Service) Update(ctx context.Context, id uuid.UUID, input Input) (*Expense, error) {
+	existing, t, err := s.getForUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	expense, err := s.buildExpense(ctx, t, input)
+	if err != nil {
+		return nil, err
+	}
+	expense.ID = existing.ID
+	expense.CreatedAt = existing.CreatedAt
+	for i := range expense.Shares {
+		expense.Shares[i].ExpenseID = existing.ID
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("expense_id = ?", existing.ID).Delete(&ExpenseShare{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Save(expense).Error; err != nil {
+			return err
+		}
+		return tx.Model(expense).Association("Travelers").Replace(expense.Travelers)
+	})
+	if err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"expense_id": id,
+			"error":      err.Error(),
+		}).Error("Failed to update expense")
+		return nil, appErrors.Internal("Failed to update expense")
+	}
+
+	logger.Log.WithField("expense_id", id).Info("Expense updated successfully")
+	return expense, nil
+}
+
+// Delete removes an expense
+func (s *Service) Delete(ctx context.Context, id uuid.UUID) error {
+	expense, _, err := s.getForUser(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.db.WithContext(ctx).Delete(expense).Error; err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"expense_id": id,
+			"error":      err.Error(),
+		}).Error("Failed to delete expense")
+		return appErrors.Internal("Failed to delete expense")
+	}
+
+	logger.Log.WithField("expense_id", id).Info("Expense deleted successfully")
+	return nil
+}
+
+// GetBudgetSummary totals a trip's expenses by category and day in the trip's home currency
+func (s *Service) GetBudgetSummary(ctx context.Context, t *trip.Trip) (*BudgetSummary, error) {
+	expenses, err := s.GetByTrip(ctx, t.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &BudgetSummary{
+		Currency:              t.HomeCurrency,
+		Budget:                t.Budget,
+		UnconvertedExpenseIDs: []uuid.UUID{},
+	}
+
+	byCategory := make(map[Category]float64)
+	byDay := make(map[time.Time]float64)
+
+	for _, expense := range expenses {
+		amount, ok := s.toHomeCurrency(ctx, expense.ID, expense.Amount, expense.Currency, t.HomeCurrency)
+		if !ok {
+			summary.UnconvertedExpenseIDs = append(summary.UnconvertedExpenseIDs, expense.ID)
+			continue
+		}
+
+		summary.TotalSpent += amount
+		byCategory[expense.Category] += amount
+		byDay[expense.Date] += amount
+	}
+
+	summary.TotalSpent = currency.RoundAmount(summary.TotalSpent, t.HomeCurrency)
+	summary.ByCategory = sortedCategoryTotals(byCategory, t.HomeCurrency)
+	summary.ByDay = sortedDayTotals(byDay, t.HomeCurrency)
+
+	if t.Budget != nil {
+		remaining := currency.RoundAmount(*t.Budget-summary.TotalSpent, t.HomeCurrency)
+		summary.Remaining = &remaining
+	}
+
+	return summary, nil
+}
+
+// toHomeCurrency converts an expense or settlement amount, returning false when no rate is available
+func (s *Service) toHomeCurrency(ctx context.Context, id uuid.UUID, amount float64, from, homeCurrency string) (float64, bool) {
+	if strings.EqualFold(from, homeCurrency) {
+		return amount, true
+	}
+
+	if s.currency == nil {
+		return 0, false
+	}
+
+	converted, err := s.currency.Convert(ctx, amount, from, homeCurrency)
+	if err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"id":    id,
+			"from":  from,
+			"to":    homeCurrency,
+			"error": err.Error(),
+		}).Warn("Failed to convert amount to home currency")
+		return 0, false
+	}
+
+	return converted, true
+}
+
+// GetBalances totals what each member paid and owes across a trip's expenses and settlements,
+// in the trip's home currency, and suggests the transfers that settle everyone up
+func (s *Service) GetBalances(ctx context.Context, t *trip.Trip) (*TripBalances, error) {
+	expenses, err := s.GetByTrip(ctx, t.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	settlements, err := s.GetSettlements(ctx, t.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &TripBalances{
+		Currency:                 t.HomeCurrency,
+		Settlements:              settlements,
+		UnconvertedExpenseIDs:    []uuid.UUID{},
+		UnconvertedSettlementIDs: []uuid.UUID{},
+	}
+
+	// Track everything in cents so balances always net to zero
+	paid := make(map[uuid.UUID]int64)
+	owed := make(map[uuid.UUID]int64)
+	sent := make(map[uuid.UUID]int64)
+	received := make(map[uuid.UUID]int64)
+
+	for _, expense := range expenses {
+		if len(expense.Shares) == 0 {
+			continue
+		}
+
+		amount, ok := s.toHomeCurrency(ctx, expense.ID, expense.Amount, expense.Currency, t.HomeCurrency)
+		if !ok {
+			result.UnconvertedExpenseIDs = append(result.UnconvertedExpenseIDs, expense.ID)
+			continue
+		}
+
+		// Converted shares keep the proportions of the original split
+		totalCents := toCents(amount, t.HomeCurrency)
+		weights := make([]float64, len(expense.Shares))
+		for i, share := range expense.Shares {
+			weights[i] = share.Amount
+		}
+
+		paid[expense.PayerID] += totalCents
+		for i, cents := range allocateCents(totalCents, weights) {
+			owed[expense.Shares[i].UserID] += cents
+		}
+	}
+
+	for _, settlement := range settlements {
+		amount, ok := s.toHomeCurrency(ctx, settlement.ID, settlement.Amount, settlement.Currency, t.HomeCurrency)
+		if !ok {
+			result.UnconvertedSettlementIDs = append(result.UnconvertedSettlementIDs, settlement.ID)
+			continue
+		}
+
+		cents := toCents(amount, t.HomeCurrency)
+		sent[settlement.FromUserID] += cents
+		received[settlement.ToUserID] += cents
+	}
+
+	userIDs := balanceUserIDs(t.MemberIDs(), paid, owed, sent, received)
+	balanceCents := make(map[uuid.UUID]int64, len(userIDs))
+	result.Balances = make([]MemberBalance, len(userIDs))
+	for i, userID := range userIDs {
+		balanceCents[userID] = paid[userID] - owed[userID] + sent[userID] - received[userID]
+		result.Balances[i] = MemberBalance{
+			UserID:   userID,
+			Paid:     fromCents(paid[userID], t.HomeCurrency),
+			Owed:     fromCents(owed[userID], t.HomeCurrency),
+			Sent:     fromCents(sent[userID], t.HomeCurrency),
+			Received: fromCents(received[userID], t.HomeCurrency),
+			Balance:  fromCents(balanceCents[userID], t.HomeCurrency),
+		}
+	}
+	result.Transfers = suggestTransfers(balanceCents, t.HomeCurrency)
+
+	return result, nil
+}
+
+// GetSettlements retrieves all settlements of a trip the user has already been authorized for
+func (s *Service) GetSettlements(ctx context.Context, tripID uuid.UUID) ([]Settlement, error) {
+	var settlements []Settlement
+	err := s.db.WithContext(ctx).
+		Where("trip_id = ?", tripID).
+		Order("date ASC, created_at ASC").
+		Find(&settlements).Error
+
+	if err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"trip_id": tripID,
+			"error":   err.Error(),
+		}).Error("Failed to fetch settlements for trip")
+		return nil, appErrors.Internal("Failed to fetch settlements")
+	}
+
+	return settlements, nil
+}
+
+// RecordSettlement records a payment from one trip member to another
+func (s *Service) RecordSettlement(ctx context.Context, tripID uuid.UUID, input SettlementInput) (*Settlement, error) {
+	t, err := s.tripService.GetByID(ctx, tripID)
+	if err != nil {
+		return nil, err
+	}
+
+	date, err := time.Parse(dateFormat, input.Date)
+	if err != nil {
+		return nil, appErrors.ValidationError("date", "Date must be formatted as YYYY-MM-DD")
+	}
+
+	fromUserID, err := uuid.Parse(input.FromUserID)
+	if err != nil || !t.HasMember(fromUserID) {
+		return nil, InvalidSettlementMemberError("fromUserId")
+	}
+
+	toUserID, err := uuid.Parse(input.ToUserID)
+	if err != nil || !t.HasMember(toUserID) {
+		return nil, InvalidSettlementMemberError("toUserId")
+	}
+
+	settlement := &Settlement{
+		TripID:     t.ID,
+		FromUserID: fromUserID,
+		ToUserID:   toUserID,
+		Amount:     currency.RoundAmount(input.Amount, input.Currency),
+		Currency:   strings.ToUpper(input.Currency),
+		Date:       date,
+	}
+
+	if input.Note != nil {
+		settlement.Note = *input.Note
+	}
+
+	if err := s.db.WithContext(ctx).Create(settlement).Error; err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"trip_id": tripID,
+			"error":   err.Error(),
+		}).Error("Failed to record settlement")
+		return nil, appErrors.Internal("Failed to record settlement")
+	}
+
+	logger.Log.WithFields(logrus.Fields{
+		"trip_id":       tripID,
+		"settlement_id": settlement.ID,
+	}).Info("Settlement recorded successfully")
+
+	return settlement, nil
+}
+
+// DeleteSettlement removes a settlement
+func (s *Service) DeleteSettlement(ctx context.Context, id uuid.UUID) error {
+	var settlement Settlement
+	if err := s.db.WithContext(ctx).First(&settlement, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return appErrors.NotFound("Settlement")
+		}
+		logger.Log.WithFields(logrus.Fields{
+			"settlement_id": id,
+			"error":         err.Error(),
+		}).Error("Failed to fetch settlement by ID")
+		return appErrors.Internal("Failed to fetch settlement")
+	}
+
+	// Verify the user has access to the settlement's trip
+	if _, err := s.tripService.GetByID(ctx, settlement.TripID); err != nil {
+		return err
+	}
+
+	if err := s.db.WithContext(ctx).Delete(&settlement).Error; err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"settlement_id": id,
+			"error":         err.Error(),
+		}).Error("Failed to delete settlement")
+		return appErrors.Internal("Failed to delete settlement")
+	}
+
+	logger.Log.WithField("settlement_id", id).Info("Settlement deleted successfully")
+	return nil
+}
+
+// buildExpense validates references against the trip and converts the input to a model
+func (s *Service) buildExpense(ctx context.Context, t *trip.Trip, input Input) (*Expense, error) {
+	date, err := time.Parse(dateFormat, input.Date)
+	if err != nil {
+		return nil, appErrors.ValidationError("date", "Date must be formatted as YYYY-MM-DD")
+	}
+
+	payerID, err := s.resolvePayer(ctx, t, input.PayerID)
+	if err != nil {
+		return nil, err
+	}
+
+	expense := &Expense{
+		TripID:   t.ID,
+		PayerID:  payerID,
+		Amount:   currency.RoundAmount(input.Amount, input.Currency),
+		Currency: strings.ToUpper(input.Currency),
+		Category: input.Category,
+		Date:     date,
+	}
+
+	if input.Description != nil {
+		expense.Description = *input.Description
+	}
+
+	if input.ActivityID != nil {
+		activityID, err := uuid.Parse(*input.ActivityID)
+		if err != nil || !t.HasActivity(activityID) {
+			return nil, InvalidActivityError()
+		}
+		expense.ActivityID = &activityID
+	}
+
+	if len(input.TravelerIDs) > 0 {
+		expense.Travelers, err = t.ResolveTravelers(input.TravelerIDs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	expense.SplitMethod = SplitMethodEqual
+	if input.SplitMethod != nil {
+		expense.SplitMethod = *input.SplitMethod
+	}
+
+	participants, err := resolveParticipants(t, expense.SplitMethod, input.Splits)
+	if err != nil {
+		return nil, err
+	}
+
+	expense.Shares, err = computeShares(expense.SplitMethod, expense.Amount, expense.Currency, participants)
+	if err != nil {
+		return nil, err
+	}
+
+	return expense, nil
+}
+
+// resolveParticipants checks the split entries against the trip's members. Equal splits
+// without entries include every member; the other methods need explicit entries.
+func resolveParticipants(t *trip.Trip, method SplitMethod, splits []SplitInput) ([]participant, error) {
+	if len(splits) == 0 {
+		if method != SplitMethodEqual {
+			return nil, InvalidSplitError("Splits are required for this split method")
+		}
+
+		memberIDs := t.MemberIDs()
+		participants := make([]participant, len(memberIDs))
+		for i, userID := range memberIDs {
+			participants[i] = participant{userID: userID}
+		}
+		return participants, nil
+	}
+
+	seen := make(map[uuid.UUID]bool, len(splits))
+	participants := make([]participant, len(splits))
+	for i, split := range splits {
+		userID, err := uuid.Parse(split.UserID)
+		if err != nil || !t.HasMember(userID) {
+			return nil, InvalidSplitError("Split participants must be the trip owner or collaborators")
+		}
+		if seen[userID] {
+			return nil, InvalidSplitError("Each participant can only be listed once")
+		}
+		seen[userID] = true
+
+		participants[i] = participant{
+			userID: userID,
+			shares: split.Shares,
+			amount: split.Amount,
+		}
+	}
+
+	return participants, nil
+}
+
+// resolvePayer defaults the payer to the authenticated user and checks they belong to the trip
+func (s *Service) resolvePayer(ctx context.Context, t *trip.Trip, payerID *string) (uuid.UUID, error) {
+	if payerID == nil {
+		_, userID, err := user.GetAuthenticatedUser(ctx, s.db)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		return userID, nil
+	}
+
+	id, err := uuid.Parse(*payerID)
+	if err != nil || !t.HasMember(id) {
+		return uuid.Nil, InvalidPayerError()
+	}
+	return id, nil
+}
+
+// getForUser retrieves an expense and verifies the user can access its trip
+func (s *Service) getForUser(ctx context.Context, id uuid.UUID) (*Expense, *trip.Trip, error) {
+	var expense Expense
+	if err := s.db.WithContext(ctx).First(&expense, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil, appErrors.NotFound("Expense")
+		}
+		logger.Log.WithFields(logrus.Fields{
+			"expense_id": id,
+			"error":      err.Error(),
+		}).Error("Failed to fetch expense by ID")
+		return nil, nil, appErrors.Internal("Failed to fetch expense")
+	}
+
+	t, err := s.tripService.GetByID(ctx, expense.TripID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &expense, t, nil
+}
+
+// balanceUserIDs lists the trip members followed by any former members who still appear in
+// expenses or settlements, so their outstanding balances aren't lost
+func balanceUserIDs(memberIDs []uuid.UUID, totals ...map[uuid.UUID]int64) []uuid.UUID {
+	seen := make(map[uuid.UUID]bool, len(memberIDs))
+	for _, userID := range memberIDs {
+		seen[userID] = true
+	}
+
+	var others []uuid.UUID
+	for _, total := range totals {
+		for userID := range total {
+			if !seen[userID] {
+				seen[userID] = true
+				others = append(others, userID)
+			}
+		}
+	}
+	sort.Slice(others, func(i, j int) bool {
+		return others[i].String() < others[j].String()
+	})
+
+	return append(memberIDs, others...)
+}
+
+func sortedCategoryTotals(totals map[Category]float64, code string) []CategoryTotal {
+	result := make([]CategoryTotal, 0, len(totals))
+	for category, amount := range totals {
+		result = append(result, CategoryTotal{Category: category, Amount: currency.RoundAmount(amount, code)})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Category < result[j].Category
+	})
+	return result
+}
+
+func sortedDayTotals(totals map[time.Time]float64, code string) []DayTotal {
+	result := make([]DayTotal, 0, len(totals))
+	for date, amount := range totals {
+		result = append(result, DayTotal{Date: date, Amount: currency.RoundAmount(amount, code)})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Date.Before(result[j].Date)
+	})
+	return result
+}
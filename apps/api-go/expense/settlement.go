@@ -0,0 +1,207 @@
+package expense
+
+import (
+	"math/bits"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Settlement records a payment between trip members that settles part of their balances
+type Settlement struct {
+	ID         uuid.UUID      `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	TripID     uuid.UUID      `gorm:"type:uuid;not null;index"`
+	FromUserID uuid.UUID      `gorm:"type:uuid;not null;index"`
+	ToUserID   uuid.UUID      `gorm:"type:uuid;not null;index"`
+	Amount     float64        `gorm:"column:amount;not null"`
+	Currency   string         `gorm:"column:currency;not null"` // ISO 4217 code
+	Date       time.Time      `gorm:"column:date;type:date;not null"`
+	Note       string         `gorm:"column:note;type:text"`
+	CreatedAt  time.Time      `gorm:"column:created_at"`
+	UpdatedAt  time.Time      `gorm:"column:updated_at"`
+	DeletedAt  gorm.DeletedAt `gorm:"column:deleted_at;index"`
+}
+
+// TableName specifies the table name for the Settlement model
+func (Settlement) TableName() string {
+	return "settlements"
+}
+
+// SettlementInput represents a payment submitted to settle up between trip members
+type SettlementInput struct {
+	FromUserID string  `json:"fromUserId" validate:"required,uuid"`
+	ToUserID   string  `json:"toUserId" validate:"required,uuid,nefield=FromUserID"`
+	Amount     float64 `json:"amount" validate:"gt=0"`
+	Currency   string  `json:"currency" validate:"required,iso4217"`
+	Date       string  `json:"date" validate:"required,datetime=2006-01-02"`
+	Note       *string `json:"note" validate:"omitempty,max=1000"`
+}
+
+// MemberBalance is a trip member's position across all expenses and settlements.
+// A positive balance means the member is owed money; negative means they owe money.
+type MemberBalance struct {
+	UserID   uuid.UUID
+	Paid     float64 // Expenses paid on behalf of the group
+	Owed     float64 // The member's share of all expenses
+	Sent     float64 // Settlements paid to other members
+	Received float64 // Settlements received from other members
+	Balance  float64
+}
+
+// Transfer is a suggested payment that settles balances
+type Transfer struct {
+	FromUserID uuid.UUID
+	ToUserID   uuid.UUID
+	Amount     float64
+}
+
+// TripBalances reports who owes whom on a trip, in the trip's home currency
+type TripBalances struct {
+	Currency                 string
+	Balances                 []MemberBalance
+	Transfers                []Transfer
+	Settlements              []Settlement
+	UnconvertedExpenseIDs    []uuid.UUID // Expenses left out because no exchange rate was available
+	UnconvertedSettlementIDs []uuid.UUID // Settlements left out because no exchange rate was available
+}
+
+// maxExactSettlementMembers is the most members with a non-zero balance for which the
+// fewest transfers are searched for exactly. The search doubles in cost and memory with
+// every member, so it is kept small enough that no trip can make balances expensive to
+// compute, and larger groups fall back to pairing debtors with creditors directly.
+const maxExactSettlementMembers = 12
+
+// position is a member's balance in cents while transfers are being suggested
+type position struct {
+	userID uuid.UUID
+	cents  int64
+}
+
+// suggestTransfers suggests the fewest transfers that settle every balance. A group of
+// members whose balances add up to zero can settle among themselves with one transfer
+// fewer than its size, so the minimum is found by splitting the members into as many
+// such groups as possible. Groups larger than maxExactSettlementMembers are settled as
+// one, which needs at most one transfer fewer than the number of members.
+func suggestTransfers(balanceCents map[uuid.UUID]int64, code string) []Transfer {
+	var positions []position
+	for userID, cents := range balanceCents {
+		if cents != 0 {
+			positions = append(positions, position{userID, cents})
+		}
+	}
+	sort.Slice(positions, func(i, j int) bool {
+		return positions[i].userID.String() < positions[j].userID.String()
+	})
+
+	transfers := make([]Transfer, 0)
+	for _, group := range zeroSumGroups(positions) {
+		transfers = append(transfers, settleGroup(group, code)...)
+	}
+	return transfers
+}
+
+// zeroSumGroups splits members into as many groups with a zero total balance as
+// possible. best[mask] is the most zero-sum groups the members in mask can be split
+// into, found by removing members one at a time and counting the subsets along the
+// way that add up to zero.
+func zeroSumGroups(positions []position) [][]position {
+	n := len(positions)
+	if n == 0 {
+		return nil
+	}
+	if n > maxExactSettlementMembers {
+		return [][]position{positions}
+	}
+
+	full := 1<<n - 1
+	sums := make([]int64, full+1)
+	best := make([]int8, full+1)
+	for mask := 1; mask <= full; mask++ {
+		lowest := bits.TrailingZeros(uint(mask))
+		sums[mask] = sums[mask&(mask-1)] + positions[lowest].cents
+
+		for rest := mask; rest != 0; rest &= rest - 1 {
+			without := mask &^ (1 << bits.TrailingZeros(uint(rest)))
+			best[mask] = max(best[mask], best[without])
+		}
+		if sums[mask] == 0 {
+			best[mask]++
+		}
+	}
+
+	// Walk back down from all members, closing a group whenever the members removed
+	// since the last one add up to zero
+	var groups [][]position
+	var group []position
+	for mask := full; mask != 0; {
+		for rest := mask; rest != 0; rest &= rest - 1 {
+			i := bits.TrailingZeros(uint(rest))
+			without := mask &^ (1 << i)
+			counted := best[without]
+			if sums[mask] == 0 {
+				counted++
+			}
+			if counted == best[mask] {
+				group = append(group, positions[i])
+				mask = without
+				break
+			}
+		}
+		if sums[mask] == 0 {
+			groups = append(groups, group)
+			group = nil
+		}
+	}
+	if len(group) > 0 {
+		groups = append(groups, group)
+	}
+
+	return groups
+}
+
+// settleGroup pairs the largest debtor with the largest creditor until every balance in
+// the group is settled, which takes at most one transfer fewer than the group's size
+func settleGroup(group []position, code string) []Transfer {
+	var creditors, debtors []position
+	for _, p := range group {
+		if p.cents > 0 {
+			creditors = append(creditors, p)
+		} else if p.cents < 0 {
+			debtors = append(debtors, position{p.userID, -p.cents})
+		}
+	}
+
+	byAmount := func(positions []position) func(i, j int) bool {
+		return func(i, j int) bool {
+			if positions[i].cents == positions[j].cents {
+				return positions[i].userID.String() < positions[j].userID.String()
+			}
+			return positions[i].cents > positions[j].cents
+		}
+	}
+	sort.Slice(creditors, byAmount(creditors))
+	sort.Slice(debtors, byAmount(debtors))
+
+	var transfers []Transfer
+	for i, j := 0, 0; i < len(debtors) && j < len(creditors); {
+		amount := min(debtors[i].cents, creditors[j].cents)
+		transfers = append(transfers, Transfer{
+			FromUserID: debtors[i].userID,
+			ToUserID:   creditors[j].userID,
+			Amount:     fromCents(amount, code),
+		})
+
+		debtors[i].cents -= amount
+		creditors[j].cents -= amount
+		if debtors[i].cents == 0 {
+			i++
+		}
+		if creditors[j].cents == 0 {
+			j++
+		}
+	}
+
+	return transfers
+}
@@ -0,0 +1,157 @@
+package expense
+
+import (
+	"math"
+	"time"
+
+	"eztrip/api-go/currency"
+	appErrors "eztrip/api-go/errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ExpenseShare is the portion of an expense owed by one participant, in the expense's currency
+type ExpenseShare struct {
+	ID        uuid.UUID      `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	ExpenseID uuid.UUID      `gorm:"type:uuid;not null;index"`
+	UserID    uuid.UUID      `gorm:"type:uuid;not null;index"`
+	Shares    *float64       `gorm:"column:shares"` // Nullable - only set for the shares split method
+	Amount    float64        `gorm:"column:amount;not null"`
+	CreatedAt time.Time      `gorm:"column:created_at"`
+	UpdatedAt time.Time      `gorm:"column:updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at;index"`
+}
+
+// TableName specifies the table name for the ExpenseShare model
+func (ExpenseShare) TableName() string {
+	return "expense_shares"
+}
+
+// participant is a validated split entry
+type participant struct {
+	userID uuid.UUID
+	shares *float64
+	amount *float64
+}
+
+// computeShares divides an expense among its participants according to the split method.
+// Amounts are computed in cents and any remainder is given to the first participants,
+// so shares always add up to the expense amount exactly.
+func computeShares(method SplitMethod, amount float64, code string, participants []participant) ([]ExpenseShare, error) {
+	if len(participants) == 0 {
+		return nil, appErrors.ValidationError("splits", "An expense needs at least one participant")
+	}
+
+	totalCents := toCents(amount, code)
+
+	var cents []int64
+	var err error
+	switch method {
+	case SplitMethodShares:
+		cents, err = splitByShares(totalCents, participants)
+	case SplitMethodExact:
+		cents, err = splitExact(totalCents, code, participants)
+	default:
+		cents = splitEqually(totalCents, len(participants))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	shares := make([]ExpenseShare, len(participants))
+	for i, p := range participants {
+		shares[i] = ExpenseShare{
+			UserID: p.userID,
+			Amount: fromCents(cents[i], code),
+		}
+		if method == SplitMethodShares {
+			shares[i].Shares = p.shares
+		}
+	}
+
+	return shares, nil
+}
+
+func splitEqually(totalCents int64, count int) []int64 {
+	cents := make([]int64, count)
+	base := totalCents / int64(count)
+	remainder := totalCents % int64(count)
+
+	for i := range cents {
+		cents[i] = base
+		if int64(i) < remainder {
+			cents[i]++
+		}
+	}
+	return cents
+}
+
+func splitByShares(totalCents int64, participants []participant) ([]int64, error) {
+	weights := make([]float64, len(participants))
+	for i, p := range participants {
+		if p.shares == nil || *p.shares <= 0 {
+			return nil, appErrors.ValidationError("splits", "Every participant needs a positive number of shares")
+		}
+		weights[i] = *p.shares
+	}
+
+	return allocateCents(totalCents, weights), nil
+}
+
+// allocateCents divides a total proportionally to the weights, handing leftover cents
+// to the first positively weighted entries so the parts always add up to the total
+func allocateCents(totalCents int64, weights []float64) []int64 {
+	cents := make([]int64, len(weights))
+
+	var totalWeight float64
+	for _, weight := range weights {
+		totalWeight += weight
+	}
+	if totalWeight <= 0 {
+		return cents
+	}
+
+	var allocated int64
+	for i, weight := range weights {
+		cents[i] = int64(math.Floor(float64(totalCents) * weight / totalWeight))
+		allocated += cents[i]
+	}
+
+	for i := 0; allocated < totalCents; i = (i + 1) % len(cents) {
+		if weights[i] > 0 {
+			cents[i]++
+			allocated++
+		}
+	}
+
+	return cents
+}
+
+func splitExact(totalCents int64, code string, participants []participant) ([]int64, error) {
+	cents := make([]int64, len(participants))
+	var allocated int64
+	for i, p := range participants {
+		if p.amount == nil {
+			return nil, appErrors.ValidationError("splits", "Every participant needs an amount")
+		}
+		cents[i] = toCents(*p.amount, code)
+		allocated += cents[i]
+	}
+
+	if allocated != totalCents {
+		return nil, appErrors.ValidationError("splits", "Split amounts must add up to the expense amount")
+	}
+
+	return cents, nil
+}
+
+// toCents converts an amount to a whole number of the currency's smallest unit, such as
+// cents for USD, yen for JPY or fils for KWD
+func toCents(amount float64, code string) int64 {
+	return int64(math.Round(amount * math.Pow10(currency.MinorUnits(code))))
+}
+
+func fromCents(cents int64, code string) float64 {
+	return float64(cents) / math.Pow10(currency.MinorUnits(code))
+}
@@ -0,0 +1,85 @@
+package gqlserver
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	envMaxDepth             = "GRAPHQL_MAX_DEPTH"
+	envMaxComplexity        = "GRAPHQL_MAX_COMPLEXITY"
+	envPersistedQueries     = "GRAPHQL_PERSISTED_QUERIES"
+	envPersistedQueriesFile = "GRAPHQL_PERSISTED_QUERIES_FILE"
+
+	DefaultMaxDepth      = 10
+	DefaultMaxComplexity = 5000
+)
+
+// PersistedQueryMode selects how queries sent by hash are handled
+type PersistedQueryMode string
+
+const (
+	// PersistedQueriesAuto caches queries clients register by hash and still accepts any query
+	PersistedQueriesAuto PersistedQueryMode = "auto"
+	// PersistedQueriesAllowList only runs queries listed in the persisted query manifest
+	PersistedQueriesAllowList PersistedQueryMode = "allowlist"
+)
+
+// Config limits what GraphQL operations the server accepts
+type Config struct {
+	MaxDepth         int
+	MaxComplexity    int
+	PersistedQueries PersistedQueryMode
+	// ManifestPath is an Apollo persisted query manifest, required in allow-list mode
+	ManifestPath string
+}
+
+// ConfigFromEnv reads the GraphQL limits from the environment, using the defaults for
+// unset values
+func ConfigFromEnv() (Config, error) {
+	config := Config{
+		MaxDepth:         DefaultMaxDepth,
+		MaxComplexity:    DefaultMaxComplexity,
+		PersistedQueries: PersistedQueriesAuto,
+		ManifestPath:     strings.TrimSpace(os.Getenv(envPersistedQueriesFile)),
+	}
+
+	var err error
+	if config.MaxDepth, err = positiveIntFromEnv(envMaxDepth, DefaultMaxDepth); err != nil {
+		return Config{}, err
+	}
+	if config.MaxComplexity, err = positiveIntFromEnv(envMaxComplexity, DefaultMaxComplexity); err != nil {
+		return Config{}, err
+	}
+
+	if mode := strings.TrimSpace(os.Getenv(envPersistedQueries)); mode != "" {
+		config.PersistedQueries = PersistedQueryMode(mode)
+	}
+
+	switch config.PersistedQueries {
+	case PersistedQueriesAuto:
+	case PersistedQueriesAllowList:
+		if config.ManifestPath == "" {
+			return Config{}, fmt.Errorf("%s is required when %s is %s", envPersistedQueriesFile, envPersistedQueries, PersistedQueriesAllowList)
+		}
+	default:
+		return Config{}, fmt.Errorf("unknown %s mode: %s (available: %s, %s)", envPersistedQueries, config.PersistedQueries, PersistedQueriesAuto, PersistedQueriesAllowList)
+	}
+
+	return config, nil
+}
+
+func positiveIntFromEnv(key string, defaultValue int) (int, error) {
+	raw := strings.TrimSpace(os.Getenv(key))
+	if raw == "" {
+		return defaultValue, nil
+	}
+
+	value, err := strconv.Atoi(raw)
+	if err != nil || value < 1 {
+		return 0, fmt.Errorf("%s must be a positive integer, got %q", key, raw)
+	}
+	return value, nil
+}
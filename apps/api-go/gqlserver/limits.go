@@ -0,0 +1,107 @@
+package gqlserver
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	appErrors "eztrip/api-go/errors"
+
+	"github.com/99designs/gqlgen/complexity"
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// depthLimit rejects operations that nest fields deeper than the limit. Introspection
+// fields are left to the parser's own introspection depth rule.
+type depthLimit struct {
+	max int
+}
+
+var _ interface {
+	graphql.OperationContextMutator
+	graphql.HandlerExtension
+} = depthLimit{}
+
+func (d depthLimit) ExtensionName() string {
+	return "DepthLimit"
+}
+
+func (d depthLimit) Validate(schema graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (d depthLimit) MutateOperationContext(ctx context.Context, opCtx *graphql.OperationContext) *gqlerror.Error {
+	depth := selectionDepth(opCtx.Operation.SelectionSet, make(map[string]bool))
+	if depth <= d.max {
+		return nil
+	}
+
+	return appErrors.WithDetails(
+		appErrors.New(appErrors.ErrCodeQueryTooDeep, fmt.Sprintf("Query depth %d exceeds the limit of %d", depth, d.max)),
+		map[string]interface{}{"depth": depth, "maxDepth": d.max},
+	)
+}
+
+// selectionDepth returns how many fields deep a selection set nests. Fragments count
+// toward the depth of the field they are spread into.
+func selectionDepth(selections ast.SelectionSet, visiting map[string]bool) int {
+	deepest := 0
+	for _, selection := range selections {
+		var depth int
+		switch s := selection.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(s.Name, "__") {
+				continue
+			}
+			depth = 1 + selectionDepth(s.SelectionSet, visiting)
+		case *ast.InlineFragment:
+			depth = selectionDepth(s.SelectionSet, visiting)
+		case *ast.FragmentSpread:
+			// Fragment cycles are rejected during validation; this only guards the walk
+			if s.Definition == nil || visiting[s.Name] {
+				continue
+			}
+			visiting[s.Name] = true
+			depth = selectionDepth(s.Definition.SelectionSet, visiting)
+			delete(visiting, s.Name)
+		}
+		deepest = max(deepest, depth)
+	}
+	return deepest
+}
+
+// complexityLimit rejects operations whose estimated cost exceeds the limit. Costs come
+// from the per-field complexity functions of the schema; scalar fields are free, so the
+// cost approximates the number of objects an operation loads.
+type complexityLimit struct {
+	max    int
+	schema graphql.ExecutableSchema
+}
+
+var _ interface {
+	graphql.OperationContextMutator
+	graphql.HandlerExtension
+} = &complexityLimit{}
+
+func (c *complexityLimit) ExtensionName() string {
+	return "ComplexityLimit"
+}
+
+func (c *complexityLimit) Validate(schema graphql.ExecutableSchema) error {
+	c.schema = schema
+	return nil
+}
+
+func (c *complexityLimit) MutateOperationContext(ctx context.Context, opCtx *graphql.OperationContext) *gqlerror.Error {
+	cost := complexity.Calculate(ctx, c.schema, opCtx.Operation, opCtx.Variables, complexity.WithFixedScalarValue(0))
+	if cost <= c.max {
+		return nil
+	}
+
+	return appErrors.WithDetails(
+		appErrors.New(appErrors.ErrCodeQueryTooComplex, fmt.Sprintf("Query complexity %d exceeds the limit of %d", cost, c.max)),
+		map[string]interface{}{"complexity": cost, "maxComplexity": c.max},
+	)
+}
@@ -0,0 +1,106 @@
+package gqlserver
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	appErrors "eztrip/api-go/errors"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+const manifestFormat = "apollo-persisted-query-manifest"
+
+// manifest is an Apollo persisted query manifest, as written by
+// @apollo/generate-persisted-query-manifest
+type manifest struct {
+	Format     string `json:"format"`
+	Version    int    `json:"version"`
+	Operations []struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+		Body string `json:"body"`
+	} `json:"operations"`
+}
+
+// loadManifest reads the queries of a persisted query manifest keyed by their SHA-256 hash
+func loadManifest(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read persisted query manifest: %w", err)
+	}
+
+	var m manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse persisted query manifest: %w", err)
+	}
+	if m.Format != manifestFormat || m.Version != 1 {
+		return nil, fmt.Errorf("unsupported persisted query manifest: format %q version %d", m.Format, m.Version)
+	}
+
+	queries := make(map[string]string, len(m.Operations))
+	for _, operation := range m.Operations {
+		if hash := queryHash(operation.Body); hash != operation.ID {
+			return nil, fmt.Errorf("persisted query %s has id %s but its body hashes to %s", operation.Name, operation.ID, hash)
+		}
+		queries[operation.ID] = operation.Body
+	}
+	return queries, nil
+}
+
+// allowList only runs the operations of a persisted query manifest. Clients may send the
+// hash alone, as automatic persisted queries do, or the full text of a listed query.
+type allowList struct {
+	queries map[string]string
+}
+
+var _ interface {
+	graphql.OperationParameterMutator
+	graphql.HandlerExtension
+} = allowList{}
+
+func (a allowList) ExtensionName() string {
+	return "PersistedQueryAllowList"
+}
+
+func (a allowList) Validate(schema graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (a allowList) MutateOperationParameters(ctx context.Context, rawParams *graphql.RawParams) *gqlerror.Error {
+	var extension struct {
+		Version    int64  `json:"version"`
+		Sha256Hash string `json:"sha256Hash"`
+	}
+	if raw, ok := rawParams.Extensions["persistedQuery"]; ok {
+		data, _ := json.Marshal(raw)
+		if err := json.Unmarshal(data, &extension); err != nil || extension.Version != 1 {
+			return appErrors.New(appErrors.ErrCodeBadRequest, "Invalid persisted query extension")
+		}
+	}
+
+	if rawParams.Query == "" {
+		query, ok := a.queries[extension.Sha256Hash]
+		if !ok {
+			// Apollo clients retry with the full query on this message and code
+			return appErrors.New(appErrors.ErrCodePersistedQueryNotFound, "PersistedQueryNotFound")
+		}
+		rawParams.Query = query
+		return nil
+	}
+
+	if _, ok := a.queries[queryHash(rawParams.Query)]; !ok {
+		return appErrors.New(appErrors.ErrCodeQueryNotAllowed, "Query is not in the persisted query allow-list")
+	}
+	return nil
+}
+
+func queryHash(query string) string {
+	sum := sha256.Sum256([]byte(query))
+	return hex.EncodeToString(sum[:])
+}
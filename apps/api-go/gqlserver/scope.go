@@ -0,0 +1,41 @@
+package gqlserver
+
+import (
+	"context"
+
+	"eztrip/api-go/accesstoken"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// accessTokenScope rejects operations an access token wasn't granted: mutations need the
+// write scope and everything else the read scope. Checking the whole operation covers
+// fields without an authorization directive, such as those resolved on a parent object.
+type accessTokenScope struct{}
+
+var _ interface {
+	graphql.OperationContextMutator
+	graphql.HandlerExtension
+} = accessTokenScope{}
+
+func (accessTokenScope) ExtensionName() string {
+	return "AccessTokenScope"
+}
+
+func (accessTokenScope) Validate(schema graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (accessTokenScope) MutateOperationContext(ctx context.Context, opCtx *graphql.OperationContext) *gqlerror.Error {
+	scope := accesstoken.ScopeRead
+	if opCtx.Operation.Operation == ast.Mutation {
+		scope = accesstoken.ScopeWrite
+	}
+
+	if token := accesstoken.FromContext(ctx); token == nil || token.HasScope(scope) {
+		return nil
+	}
+	return accesstoken.InsufficientScopeError(scope)
+}
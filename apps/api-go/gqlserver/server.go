@@ -0,0 +1,62 @@
+// Package gqlserver builds the GraphQL HTTP handler with limits on what operations it
+// runs: a maximum depth, a maximum estimated cost, and persisted queries that are either
+// cached automatically or restricted to an allow-list for production clients.
+package gqlserver
+
+import (
+	"time"
+
+	"eztrip/api-go/logger"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/lru"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/sirupsen/logrus"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// New creates a GraphQL handler with the transports of gqlgen's default server and the
+// configured limits
+func New(schema graphql.ExecutableSchema, config Config) (*handler.Server, error) {
+	srv := handler.New(schema)
+
+	srv.AddTransport(transport.Websocket{
+		KeepAlivePingInterval: 10 * time.Second,
+	})
+	srv.AddTransport(transport.Options{})
+	srv.AddTransport(transport.GET{})
+	srv.AddTransport(transport.POST{})
+	srv.AddTransport(transport.MultipartForm{})
+
+	srv.SetQueryCache(lru.New[*ast.QueryDocument](1000))
+
+	srv.Use(extension.Introspection{})
+
+	switch config.PersistedQueries {
+	case PersistedQueriesAllowList:
+		queries, err := loadManifest(config.ManifestPath)
+		if err != nil {
+			return nil, err
+		}
+		srv.Use(allowList{queries: queries})
+	default:
+		srv.Use(extension.AutomaticPersistedQuery{
+			Cache: lru.New[string](100),
+		})
+	}
+
+	srv.Use(depthLimit{max: config.MaxDepth})
+	srv.Use(&complexityLimit{max: config.MaxComplexity})
+	srv.Use(accessTokenScope{})
+
+	logger.Log.WithFields(logrus.Fields{
+		"component":         "graphql",
+		"max_depth":         config.MaxDepth,
+		"max_complexity":    config.MaxComplexity,
+		"persisted_queries": config.PersistedQueries,
+	}).Info("GraphQL limits configured")
+
+	return srv, nil
+}
@@ -0,0 +1,84 @@
+package graph
+
+import (
+	"eztrip/api-go/importer"
+	"eztrip/api-go/pagination"
+	"eztrip/api-go/trip"
+	"eztrip/api-go/user"
+)
+
+// Typical sizes of lists that aren't paginated, used to estimate what nested
+// selections cost. Together with the page size of connections they make an
+// operation's complexity roughly the number of objects it loads.
+const (
+	daysPerTrip          = 7
+	activitiesPerDay     = 5
+	collaboratorsPerTrip = 3
+	lodgingsPerTrip      = 3
+	travelersPerTrip     = 4
+	expensesPerTrip      = 20
+	shareLinksPerTrip    = 2
+	templatesPerQuery    = 20
+
+	// Fields that compute over a whole trip or call out to other services
+	scheduleCheckCost = 10
+	budgetSummaryCost = 10
+	suggestionCost    = 100
+	importCost        = 50
+)
+
+// NewComplexity assigns costs to fields that load lists or do expensive work; every
+// other field costs one per object.
+func NewComplexity() ComplexityRoot {
+	var c ComplexityRoot
+
+	c.Query.Trips = func(childComplexity int, first *int32, after *string, filter *trip.ListFilter, orderBy *trip.ListOrder) int {
+		return listCost(pagination.PageSize(first), childComplexity)
+	}
+	c.Query.Users = func(childComplexity int, first *int32, after *string, filter *user.ListFilter, orderBy *user.ListOrder) int {
+		return listCost(pagination.PageSize(first), childComplexity)
+	}
+	c.Query.TripTemplates = func(childComplexity int, filter *trip.TemplateFilter) int {
+		return listCost(templatesPerQuery, childComplexity)
+	}
+	c.Query.TripSuggestion = func(childComplexity int, prompt string) int {
+		return suggestionCost
+	}
+
+	c.Trip.Itinerary = fixedList(daysPerTrip)
+	c.Trip.Collaborators = fixedList(collaboratorsPerTrip)
+	c.Trip.Lodgings = fixedList(lodgingsPerTrip)
+	c.Trip.TravelerList = fixedList(travelersPerTrip)
+	c.Trip.Expenses = fixedList(expensesPerTrip)
+	c.Trip.ShareLinks = fixedList(shareLinksPerTrip)
+	c.Trip.Warnings = fixedCost(scheduleCheckCost)
+	c.Trip.BudgetSummary = fixedCost(budgetSummaryCost)
+	c.TripTemplate.Itinerary = fixedList(daysPerTrip)
+	c.ItineraryDay.Activities = fixedList(activitiesPerDay)
+
+	c.Mutation.PreviewTripImport = func(childComplexity int, input importer.Input) int {
+		return importCost + childComplexity
+	}
+	c.Mutation.ImportTrip = func(childComplexity int, input importer.Input) int {
+		return importCost + childComplexity
+	}
+
+	return c
+}
+
+// listCost is the cost of a list of objects, each costing one plus its selections
+func listCost(size, childComplexity int) int {
+	return size * (1 + childComplexity)
+}
+
+func fixedList(size int) func(childComplexity int) int {
+	return func(childComplexity int) int {
+		return listCost(size, childComplexity)
+	}
+}
+
+func fixedCost(cost int) func(childComplexity int) int {
+	return func(childComplexity int) int {
+		return cost + childComplexity
+	}
+}
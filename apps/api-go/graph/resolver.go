@@ -1,6 +1,13 @@
 package graph
 
 import (
+	"eztrip/api-go/accesstoken"
+	"eztrip/api-go/calendar"
+	"eztrip/api-go/expense"
+	"eztrip/api-go/importer"
+	"eztrip/api-go/privacy"
+	"eztrip/api-go/rbacadmin"
+	"eztrip/api-go/share"
 	"eztrip/api-go/trip"
 	"eztrip/api-go/user"
 
@@ -13,16 +20,37 @@ import (
 // here.
 
 type Resolver struct {
-	UserResolver *user.Resolver
-	TripResolver *trip.Resolver
+	UserResolver        *user.Resolver
+	TripResolver        *trip.Resolver
+	ExpenseResolver     *expense.Resolver
+	CalendarResolver    *calendar.Resolver
+	ImportResolver      *importer.Resolver
+	ShareResolver       *share.Resolver
+	AccessTokenResolver *accesstoken.Resolver
+	PrivacyResolver     *privacy.Resolver
+	RBACAdminResolver   *rbacadmin.Resolver
 }
 
 func NewResolver(db *gorm.DB) *Resolver {
 	userService := user.NewService(db)
 	tripService := trip.NewService(db)
+	expenseService := expense.NewService(db, tripService)
+	calendarService := calendar.NewService(db, tripService)
+	importService := importer.NewService(db, tripService)
+	shareService := share.NewService(db, tripService)
+	accessTokenService := accesstoken.NewService(db)
+	privacyService := privacy.NewService(db, importService)
+	rbacAdminService := rbacadmin.NewService(db)
 
 	return &Resolver{
-		UserResolver: user.NewResolver(userService),
-		TripResolver: trip.NewResolver(tripService),
+		UserResolver:        user.NewResolver(userService),
+		TripResolver:        trip.NewResolver(tripService),
+		ExpenseResolver:     expense.NewResolver(expenseService),
+		CalendarResolver:    calendar.NewResolver(calendarService),
+		ImportResolver:      importer.NewResolver(importService),
+		ShareResolver:       share.NewResolver(shareService),
+		AccessTokenResolver: accesstoken.NewResolver(accessTokenService),
+		PrivacyResolver:     privacy.NewResolver(privacyService),
+		RBACAdminResolver:   rbacadmin.NewResolver(rbacAdminService),
 	}
 }
@@ -7,12 +7,53 @@ package graph
 
 import (
 	"context"
-	"eztrip/api-go/graph/model"
+	"eztrip/api-go/accesstoken"
+	"eztrip/api-go/calendar"
+	"eztrip/api-go/expense"
+	"eztrip/api-go/importer"
+	"eztrip/api-go/privacy"
+	"eztrip/api-go/rbac"
+	"eztrip/api-go/rbacadmin"
+	"eztrip/api-go/share"
 	"eztrip/api-go/trip"
 	"eztrip/api-go/user"
-	"fmt"
+	"time"
 )
 
+// ID is the resolver for the id field.
+func (r *accessTokenResolver) ID(ctx context.Context, obj *accesstoken.Token) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// ExpiresAt is the resolver for the expiresAt field.
+func (r *accessTokenResolver) ExpiresAt(ctx context.Context, obj *accesstoken.Token) (string, error) {
+	return obj.ExpiresAt.Format(time.RFC3339), nil
+}
+
+// LastUsedAt is the resolver for the lastUsedAt field.
+func (r *accessTokenResolver) LastUsedAt(ctx context.Context, obj *accesstoken.Token) (*string, error) {
+	if obj.LastUsedAt == nil {
+		return nil, nil
+	}
+	lastUsedAt := obj.LastUsedAt.Format(time.RFC3339)
+	return &lastUsedAt, nil
+}
+
+// CreatedAt is the resolver for the createdAt field.
+func (r *accessTokenResolver) CreatedAt(ctx context.Context, obj *accesstoken.Token) (string, error) {
+	return obj.CreatedAt.Format(time.RFC3339), nil
+}
+
+// RequestedAt is the resolver for the requestedAt field.
+func (r *accountErasureResolver) RequestedAt(ctx context.Context, obj *privacy.Erasure) (string, error) {
+	return obj.RequestedAt.Format(time.RFC3339), nil
+}
+
+// DueAt is the resolver for the dueAt field.
+func (r *accountErasureResolver) DueAt(ctx context.Context, obj *privacy.Erasure) (string, error) {
+	return obj.DueAt.Format(time.RFC3339), nil
+}
+
 // ID is the resolver for the id field.
 func (r *activityResolver) ID(ctx context.Context, obj *trip.Activity) (string, error) {
 	return obj.ID.String(), nil
@@ -34,7 +75,141 @@ func (r *activityResolver) PlaceID(ctx context.Context, obj *trip.Activity) (*st
 
 // Time is the resolver for the time field.
 func (r *activityResolver) Time(ctx context.Context, obj *trip.Activity) (string, error) {
-	return obj.Time.Format("2006-01-02T15:04:05Z07:00"), nil
+	return trip.FormatDateTime(obj.Time, obj.TimeLocation()), nil
+}
+
+// EndTime is the resolver for the endTime field.
+func (r *activityResolver) EndTime(ctx context.Context, obj *trip.Activity) (*string, error) {
+	if obj.EndTime == nil {
+		return nil, nil
+	}
+	endTimeStr := trip.FormatDateTime(*obj.EndTime, obj.TimeLocation())
+	return &endTimeStr, nil
+}
+
+// DurationMinutes is the resolver for the durationMinutes field.
+func (r *activityResolver) DurationMinutes(ctx context.Context, obj *trip.Activity) (*int32, error) {
+	if obj.EndTime == nil {
+		return nil, nil
+	}
+	minutes := int32(obj.Duration().Minutes())
+	return &minutes, nil
+}
+
+// TimeZone is the resolver for the timeZone field.
+func (r *activityResolver) TimeZone(ctx context.Context, obj *trip.Activity) (string, error) {
+	return obj.TimeLocation().String(), nil
+}
+
+// UnconvertedExpenseIds is the resolver for the unconvertedExpenseIds field.
+func (r *budgetSummaryResolver) UnconvertedExpenseIds(ctx context.Context, obj *expense.BudgetSummary) ([]string, error) {
+	expenseIDs := make([]string, len(obj.UnconvertedExpenseIDs))
+	for i, id := range obj.UnconvertedExpenseIDs {
+		expenseIDs[i] = id.String()
+	}
+	return expenseIDs, nil
+}
+
+// ID is the resolver for the id field.
+func (r *calendarFeedResolver) ID(ctx context.Context, obj *calendar.Feed) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// TripID is the resolver for the tripId field.
+func (r *calendarFeedResolver) TripID(ctx context.Context, obj *calendar.Feed) (string, error) {
+	return obj.TripID.String(), nil
+}
+
+// CreatedAt is the resolver for the createdAt field.
+func (r *calendarFeedResolver) CreatedAt(ctx context.Context, obj *calendar.Feed) (string, error) {
+	return obj.CreatedAt.Format(time.RFC3339), nil
+}
+
+// LastAccessedAt is the resolver for the lastAccessedAt field.
+func (r *calendarFeedResolver) LastAccessedAt(ctx context.Context, obj *calendar.Feed) (*string, error) {
+	if obj.LastAccessedAt == nil {
+		return nil, nil
+	}
+	lastAccessedAt := obj.LastAccessedAt.Format(time.RFC3339)
+	return &lastAccessedAt, nil
+}
+
+// ID is the resolver for the id field.
+func (r *dataExportResolver) ID(ctx context.Context, obj *privacy.Export) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// ExpiresAt is the resolver for the expiresAt field.
+func (r *dataExportResolver) ExpiresAt(ctx context.Context, obj *privacy.Export) (string, error) {
+	return obj.ExpiresAt.Format(time.RFC3339), nil
+}
+
+// CreatedAt is the resolver for the createdAt field.
+func (r *dataExportResolver) CreatedAt(ctx context.Context, obj *privacy.Export) (string, error) {
+	return obj.CreatedAt.Format(time.RFC3339), nil
+}
+
+// Date is the resolver for the date field.
+func (r *dayTotalResolver) Date(ctx context.Context, obj *expense.DayTotal) (string, error) {
+	return obj.Date.Format("2006-01-02"), nil
+}
+
+// ID is the resolver for the id field.
+func (r *expenseResolver) ID(ctx context.Context, obj *expense.Expense) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// TripID is the resolver for the tripId field.
+func (r *expenseResolver) TripID(ctx context.Context, obj *expense.Expense) (string, error) {
+	return obj.TripID.String(), nil
+}
+
+// ActivityID is the resolver for the activityId field.
+func (r *expenseResolver) ActivityID(ctx context.Context, obj *expense.Expense) (*string, error) {
+	if obj.ActivityID == nil {
+		return nil, nil
+	}
+	activityIDStr := obj.ActivityID.String()
+	return &activityIDStr, nil
+}
+
+// PayerID is the resolver for the payerId field.
+func (r *expenseResolver) PayerID(ctx context.Context, obj *expense.Expense) (string, error) {
+	return obj.PayerID.String(), nil
+}
+
+// Date is the resolver for the date field.
+func (r *expenseResolver) Date(ctx context.Context, obj *expense.Expense) (string, error) {
+	return obj.Date.Format("2006-01-02"), nil
+}
+
+// Splits is the resolver for the splits field.
+func (r *expenseResolver) Splits(ctx context.Context, obj *expense.Expense) ([]*expense.ExpenseShare, error) {
+	splits := make([]*expense.ExpenseShare, len(obj.Shares))
+	for i := range obj.Shares {
+		splits[i] = &obj.Shares[i]
+	}
+	return splits, nil
+}
+
+// UserID is the resolver for the userId field.
+func (r *expenseSplitResolver) UserID(ctx context.Context, obj *expense.ExpenseShare) (string, error) {
+	return obj.UserID.String(), nil
+}
+
+// Line is the resolver for the line field.
+func (r *importActivityPreviewResolver) Line(ctx context.Context, obj *importer.ActivityPreview) (int32, error) {
+	return int32(obj.Line), nil
+}
+
+// DayNumber is the resolver for the dayNumber field.
+func (r *importDayPreviewResolver) DayNumber(ctx context.Context, obj *importer.DayPreview) (int32, error) {
+	return int32(obj.DayNumber), nil
+}
+
+// Line is the resolver for the line field.
+func (r *importErrorResolver) Line(ctx context.Context, obj *importer.Issue) (int32, error) {
+	return int32(obj.Line), nil
 }
 
 // ID is the resolver for the id field.
@@ -49,7 +224,7 @@ func (r *itineraryDayResolver) TripID(ctx context.Context, obj *trip.ItineraryDa
 
 // Date is the resolver for the date field.
 func (r *itineraryDayResolver) Date(ctx context.Context, obj *trip.ItineraryDay) (string, error) {
-	return obj.Date.Format("2006-01-02"), nil
+	return trip.FormatDate(obj.Date, obj.TimeLocation()), nil
 }
 
 // DayNumber is the resolver for the dayNumber field.
@@ -57,9 +232,268 @@ func (r *itineraryDayResolver) DayNumber(ctx context.Context, obj *trip.Itinerar
 	return int32(obj.DayNumber), nil
 }
 
+// Activities is the resolver for the activities field.
+func (r *itineraryDayResolver) Activities(ctx context.Context, obj *trip.ItineraryDay) ([]*trip.Activity, error) {
+	return r.TripResolver.DayActivities(ctx, obj)
+}
+
+// Warnings is the resolver for the warnings field.
+func (r *itineraryDayResolver) Warnings(ctx context.Context, obj *trip.ItineraryDay) ([]*trip.ScheduleWarning, error) {
+	return r.TripResolver.DayWarnings(ctx, obj)
+}
+
+// ID is the resolver for the id field.
+func (r *lodgingResolver) ID(ctx context.Context, obj *trip.Lodging) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// TripID is the resolver for the tripId field.
+func (r *lodgingResolver) TripID(ctx context.Context, obj *trip.Lodging) (string, error) {
+	return obj.TripID.String(), nil
+}
+
+// PlaceID is the resolver for the placeId field.
+func (r *lodgingResolver) PlaceID(ctx context.Context, obj *trip.Lodging) (*string, error) {
+	if obj.PlaceID == nil {
+		return nil, nil
+	}
+	placeIDStr := obj.PlaceID.String()
+	return &placeIDStr, nil
+}
+
+// CheckInDate is the resolver for the checkInDate field.
+func (r *lodgingResolver) CheckInDate(ctx context.Context, obj *trip.Lodging) (string, error) {
+	return obj.CheckInDate.Format("2006-01-02"), nil
+}
+
+// CheckOutDate is the resolver for the checkOutDate field.
+func (r *lodgingResolver) CheckOutDate(ctx context.Context, obj *trip.Lodging) (string, error) {
+	return obj.CheckOutDate.Format("2006-01-02"), nil
+}
+
+// Nights is the resolver for the nights field.
+func (r *lodgingResolver) Nights(ctx context.Context, obj *trip.Lodging) (int32, error) {
+	return int32(obj.Nights()), nil
+}
+
+// UserID is the resolver for the userId field.
+func (r *memberBalanceResolver) UserID(ctx context.Context, obj *expense.MemberBalance) (string, error) {
+	return obj.UserID.String(), nil
+}
+
 // CreateUser is the resolver for the createUser field.
-func (r *mutationResolver) CreateUser(ctx context.Context, input model.CreateUserInput) (*user.User, error) {
-	panic(fmt.Errorf("not implemented: CreateUser - createUser"))
+func (r *mutationResolver) CreateUser(ctx context.Context, input user.CreateUserInput) (*user.User, error) {
+	return r.UserResolver.CreateUser(ctx, input)
+}
+
+// UpdateMe is the resolver for the updateMe field.
+func (r *mutationResolver) UpdateMe(ctx context.Context, input user.UpdateUserInput) (*user.User, error) {
+	return r.UserResolver.UpdateMe(ctx, input)
+}
+
+// UpdateMyPreferences is the resolver for the updateMyPreferences field.
+func (r *mutationResolver) UpdateMyPreferences(ctx context.Context, input user.PreferencesInput) (*user.User, error) {
+	return r.UserResolver.UpdateMyPreferences(ctx, input)
+}
+
+// DeleteMe is the resolver for the deleteMe field.
+func (r *mutationResolver) DeleteMe(ctx context.Context) (bool, error) {
+	return r.UserResolver.DeleteMe(ctx)
+}
+
+// UpdateUser is the resolver for the updateUser field.
+func (r *mutationResolver) UpdateUser(ctx context.Context, id string, input user.UpdateUserInput) (*user.User, error) {
+	return r.UserResolver.UpdateUser(ctx, id, input)
+}
+
+// DeactivateUser is the resolver for the deactivateUser field.
+func (r *mutationResolver) DeactivateUser(ctx context.Context, id string) (*user.User, error) {
+	return r.UserResolver.DeactivateUser(ctx, id)
+}
+
+// SetUserRole is the resolver for the setUserRole field.
+func (r *mutationResolver) SetUserRole(ctx context.Context, id string, role string) (*user.User, error) {
+	return r.UserResolver.SetUserRole(ctx, id, role)
+}
+
+// RequestMyDataExport is the resolver for the requestMyDataExport field.
+func (r *mutationResolver) RequestMyDataExport(ctx context.Context) (*privacy.Export, error) {
+	return r.PrivacyResolver.RequestMyDataExport(ctx)
+}
+
+// RequestAccountErasure is the resolver for the requestAccountErasure field.
+func (r *mutationResolver) RequestAccountErasure(ctx context.Context) (*privacy.Erasure, error) {
+	return r.PrivacyResolver.RequestAccountErasure(ctx)
+}
+
+// CancelAccountErasure is the resolver for the cancelAccountErasure field.
+func (r *mutationResolver) CancelAccountErasure(ctx context.Context) (bool, error) {
+	return r.PrivacyResolver.CancelAccountErasure(ctx)
+}
+
+// SetTransportLeg is the resolver for the setTransportLeg field.
+func (r *mutationResolver) SetTransportLeg(ctx context.Context, activityID string, input trip.TransportLegInput) (*trip.TransportLeg, error) {
+	return r.TripResolver.SetTransportLeg(ctx, activityID, input)
+}
+
+// RemoveTransportLeg is the resolver for the removeTransportLeg field.
+func (r *mutationResolver) RemoveTransportLeg(ctx context.Context, activityID string) (bool, error) {
+	return r.TripResolver.RemoveTransportLeg(ctx, activityID)
+}
+
+// AddLodging is the resolver for the addLodging field.
+func (r *mutationResolver) AddLodging(ctx context.Context, tripID string, input trip.LodgingInput) (*trip.Lodging, error) {
+	return r.TripResolver.AddLodging(ctx, tripID, input)
+}
+
+// UpdateLodging is the resolver for the updateLodging field.
+func (r *mutationResolver) UpdateLodging(ctx context.Context, id string, input trip.LodgingInput) (*trip.Lodging, error) {
+	return r.TripResolver.UpdateLodging(ctx, id, input)
+}
+
+// RemoveLodging is the resolver for the removeLodging field.
+func (r *mutationResolver) RemoveLodging(ctx context.Context, id string) (bool, error) {
+	return r.TripResolver.RemoveLodging(ctx, id)
+}
+
+// CloneTrip is the resolver for the cloneTrip field.
+func (r *mutationResolver) CloneTrip(ctx context.Context, tripID string, newStartDate string, title *string) (*trip.Trip, error) {
+	return r.TripResolver.CloneTrip(ctx, tripID, newStartDate, title)
+}
+
+// SetTripTemplate is the resolver for the setTripTemplate field.
+func (r *mutationResolver) SetTripTemplate(ctx context.Context, tripID string, isTemplate bool) (*trip.Trip, error) {
+	return r.TripResolver.SetTripTemplate(ctx, tripID, isTemplate)
+}
+
+// PreviewTripImport is the resolver for the previewTripImport field.
+func (r *mutationResolver) PreviewTripImport(ctx context.Context, input importer.Input) (*importer.Preview, error) {
+	return r.ImportResolver.PreviewTripImport(ctx, input)
+}
+
+// ImportTrip is the resolver for the importTrip field.
+func (r *mutationResolver) ImportTrip(ctx context.Context, input importer.Input) (*importer.Preview, error) {
+	return r.ImportResolver.ImportTrip(ctx, input)
+}
+
+// AddTraveler is the resolver for the addTraveler field.
+func (r *mutationResolver) AddTraveler(ctx context.Context, tripID string, input trip.TravelerInput) (*trip.Traveler, error) {
+	return r.TripResolver.AddTraveler(ctx, tripID, input)
+}
+
+// UpdateTraveler is the resolver for the updateTraveler field.
+func (r *mutationResolver) UpdateTraveler(ctx context.Context, id string, input trip.TravelerInput) (*trip.Traveler, error) {
+	return r.TripResolver.UpdateTraveler(ctx, id, input)
+}
+
+// RemoveTraveler is the resolver for the removeTraveler field.
+func (r *mutationResolver) RemoveTraveler(ctx context.Context, id string) (bool, error) {
+	return r.TripResolver.RemoveTraveler(ctx, id)
+}
+
+// SetActivityTravelers is the resolver for the setActivityTravelers field.
+func (r *mutationResolver) SetActivityTravelers(ctx context.Context, activityID string, travelerIds []string) (*trip.Activity, error) {
+	return r.TripResolver.SetActivityTravelers(ctx, activityID, travelerIds)
+}
+
+// RotateCalendarFeed is the resolver for the rotateCalendarFeed field.
+func (r *mutationResolver) RotateCalendarFeed(ctx context.Context, tripID string) (*calendar.Feed, error) {
+	return r.CalendarResolver.RotateCalendarFeed(ctx, tripID)
+}
+
+// RevokeCalendarFeed is the resolver for the revokeCalendarFeed field.
+func (r *mutationResolver) RevokeCalendarFeed(ctx context.Context, tripID string) (bool, error) {
+	return r.CalendarResolver.RevokeCalendarFeed(ctx, tripID)
+}
+
+// CreateShareLink is the resolver for the createShareLink field.
+func (r *mutationResolver) CreateShareLink(ctx context.Context, tripID string, input share.LinkInput) (*share.Link, error) {
+	return r.ShareResolver.CreateShareLink(ctx, tripID, input)
+}
+
+// RevokeShareLink is the resolver for the revokeShareLink field.
+func (r *mutationResolver) RevokeShareLink(ctx context.Context, id string) (bool, error) {
+	return r.ShareResolver.RevokeShareLink(ctx, id)
+}
+
+// CreateAccessToken is the resolver for the createAccessToken field.
+func (r *mutationResolver) CreateAccessToken(ctx context.Context, input accesstoken.TokenInput) (*accesstoken.Token, error) {
+	return r.AccessTokenResolver.CreateAccessToken(ctx, input)
+}
+
+// RevokeAccessToken is the resolver for the revokeAccessToken field.
+func (r *mutationResolver) RevokeAccessToken(ctx context.Context, id string) (bool, error) {
+	return r.AccessTokenResolver.RevokeAccessToken(ctx, id)
+}
+
+// CreateServiceAccount is the resolver for the createServiceAccount field.
+func (r *mutationResolver) CreateServiceAccount(ctx context.Context, input accesstoken.ServiceAccountInput) (*accesstoken.ServiceAccount, error) {
+	return r.AccessTokenResolver.CreateServiceAccount(ctx, input)
+}
+
+// DeleteServiceAccount is the resolver for the deleteServiceAccount field.
+func (r *mutationResolver) DeleteServiceAccount(ctx context.Context, id string) (bool, error) {
+	return r.AccessTokenResolver.DeleteServiceAccount(ctx, id)
+}
+
+// CreateServiceAccountToken is the resolver for the createServiceAccountToken field.
+func (r *mutationResolver) CreateServiceAccountToken(ctx context.Context, serviceAccountID string, input accesstoken.TokenInput) (*accesstoken.Token, error) {
+	return r.AccessTokenResolver.CreateServiceAccountToken(ctx, serviceAccountID, input)
+}
+
+// AddRBACPolicy is the resolver for the addRBACPolicy field.
+func (r *mutationResolver) AddRBACPolicy(ctx context.Context, input rbacadmin.PolicyInput) (*rbacadmin.Policy, error) {
+	return r.RBACAdminResolver.AddPolicy(ctx, input)
+}
+
+// RemoveRBACPolicy is the resolver for the removeRBACPolicy field.
+func (r *mutationResolver) RemoveRBACPolicy(ctx context.Context, input rbacadmin.PolicyInput) (bool, error) {
+	return r.RBACAdminResolver.RemovePolicy(ctx, input)
+}
+
+// AssignRBACRole is the resolver for the assignRBACRole field.
+func (r *mutationResolver) AssignRBACRole(ctx context.Context, input rbacadmin.RoleAssignmentInput) (*rbacadmin.RoleAssignment, error) {
+	return r.RBACAdminResolver.AssignRole(ctx, input)
+}
+
+// UnassignRBACRole is the resolver for the unassignRBACRole field.
+func (r *mutationResolver) UnassignRBACRole(ctx context.Context, input rbacadmin.RoleAssignmentInput) (bool, error) {
+	return r.RBACAdminResolver.UnassignRole(ctx, input)
+}
+
+// SetTripTimeZone is the resolver for the setTripTimeZone field.
+func (r *mutationResolver) SetTripTimeZone(ctx context.Context, tripID string, timeZone string) (*trip.Trip, error) {
+	return r.TripResolver.SetTimeZone(ctx, tripID, timeZone)
+}
+
+// SetTripBudget is the resolver for the setTripBudget field.
+func (r *mutationResolver) SetTripBudget(ctx context.Context, tripID string, input trip.BudgetInput) (*trip.Trip, error) {
+	return r.TripResolver.SetBudget(ctx, tripID, input)
+}
+
+// AddExpense is the resolver for the addExpense field.
+func (r *mutationResolver) AddExpense(ctx context.Context, tripID string, input expense.Input) (*expense.Expense, error) {
+	return r.ExpenseResolver.AddExpense(ctx, tripID, input)
+}
+
+// UpdateExpense is the resolver for the updateExpense field.
+func (r *mutationResolver) UpdateExpense(ctx context.Context, id string, input expense.Input) (*expense.Expense, error) {
+	return r.ExpenseResolver.UpdateExpense(ctx, id, input)
+}
+
+// RemoveExpense is the resolver for the removeExpense field.
+func (r *mutationResolver) RemoveExpense(ctx context.Context, id string) (bool, error) {
+	return r.ExpenseResolver.RemoveExpense(ctx, id)
+}
+
+// RecordSettlement is the resolver for the recordSettlement field.
+func (r *mutationResolver) RecordSettlement(ctx context.Context, tripID string, input expense.SettlementInput) (*expense.Settlement, error) {
+	return r.ExpenseResolver.RecordSettlement(ctx, tripID, input)
+}
+
+// RemoveSettlement is the resolver for the removeSettlement field.
+func (r *mutationResolver) RemoveSettlement(ctx context.Context, id string) (bool, error) {
+	return r.ExpenseResolver.RemoveSettlement(ctx, id)
 }
 
 // CurrentUser is the resolver for the currentUser field.
@@ -68,8 +502,8 @@ func (r *queryResolver) CurrentUser(ctx context.Context) (*user.User, error) {
 }
 
 // Users is the resolver for the users field.
-func (r *queryResolver) Users(ctx context.Context) ([]*user.User, error) {
-	return r.UserResolver.Users(ctx)
+func (r *queryResolver) Users(ctx context.Context, first *int32, after *string, filter *user.ListFilter, orderBy *user.ListOrder) (*user.Connection, error) {
+	return r.UserResolver.Users(ctx, first, after, filter, orderBy)
 }
 
 // User is the resolver for the user field.
@@ -77,17 +511,49 @@ func (r *queryResolver) User(ctx context.Context, id string) (*user.User, error)
 	return r.UserResolver.User(ctx, id)
 }
 
+// AccessTokens is the resolver for the accessTokens field.
+func (r *queryResolver) AccessTokens(ctx context.Context) ([]*accesstoken.Token, error) {
+	return r.AccessTokenResolver.AccessTokens(ctx)
+}
+
+// ServiceAccounts is the resolver for the serviceAccounts field.
+func (r *queryResolver) ServiceAccounts(ctx context.Context) ([]*accesstoken.ServiceAccount, error) {
+	return r.AccessTokenResolver.ServiceAccounts(ctx)
+}
+
+// MyAccountErasure is the resolver for the myAccountErasure field.
+func (r *queryResolver) MyAccountErasure(ctx context.Context) (*privacy.Erasure, error) {
+	return r.PrivacyResolver.MyAccountErasure(ctx)
+}
+
+// RbacPolicies is the resolver for the rbacPolicies field.
+func (r *queryResolver) RbacPolicies(ctx context.Context, role *string) ([]*rbacadmin.Policy, error) {
+	return r.RBACAdminResolver.Policies(ctx, role)
+}
+
+// RbacRoleAssignments is the resolver for the rbacRoleAssignments field.
+func (r *queryResolver) RbacRoleAssignments(ctx context.Context, filter *rbacadmin.RoleAssignmentFilter) ([]*rbacadmin.RoleAssignment, error) {
+	return r.RBACAdminResolver.RoleAssignments(ctx, filter)
+}
+
+// RbacResources is the resolver for the rbacResources field.
+func (r *queryResolver) RbacResources(ctx context.Context) ([]string, error) {
+	return r.RBACAdminResolver.Resources(ctx), nil
+}
+
+// RbacActions is the resolver for the rbacActions field.
+func (r *queryResolver) RbacActions(ctx context.Context) ([]string, error) {
+	return r.RBACAdminResolver.Actions(ctx), nil
+}
+
+// RbacAuditLog is the resolver for the rbacAuditLog field.
+func (r *queryResolver) RbacAuditLog(ctx context.Context, first *int32, after *string) (*rbacadmin.AuditConnection, error) {
+	return r.RBACAdminResolver.AuditLog(ctx, first, after)
+}
+
 // Trips is the resolver for the trips field.
-func (r *queryResolver) Trips(ctx context.Context) ([]*trip.Trip, error) {
-	trips, err := r.TripResolver.Trips(ctx)
-	if err != nil {
-		return nil, err
-	}
-	result := make([]*trip.Trip, len(trips))
-	for i := range trips {
-		result[i] = &trips[i]
-	}
-	return result, nil
+func (r *queryResolver) Trips(ctx context.Context, first *int32, after *string, filter *trip.ListFilter, orderBy *trip.ListOrder) (*trip.Connection, error) {
+	return r.TripResolver.Trips(ctx, first, after, filter, orderBy)
 }
 
 // Trip is the resolver for the trip field.
@@ -100,11 +566,234 @@ func (r *queryResolver) Activity(ctx context.Context, id string) (*trip.Activity
 	return r.TripResolver.Activity(ctx, id)
 }
 
+// TripTemplates is the resolver for the tripTemplates field.
+func (r *queryResolver) TripTemplates(ctx context.Context, filter *trip.TemplateFilter) ([]*trip.Trip, error) {
+	templates, err := r.TripResolver.TripTemplates(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*trip.Trip, len(templates))
+	for i := range templates {
+		result[i] = &templates[i]
+	}
+	return result, nil
+}
+
+// TripBalances is the resolver for the tripBalances field.
+func (r *queryResolver) TripBalances(ctx context.Context, tripID string) (*expense.TripBalances, error) {
+	return r.ExpenseResolver.TripBalances(ctx, tripID)
+}
+
+// ExportTrip is the resolver for the exportTrip field.
+func (r *queryResolver) ExportTrip(ctx context.Context, tripID string) (string, error) {
+	return r.ImportResolver.ExportTrip(ctx, tripID)
+}
+
 // TripSuggestion is the resolver for the tripSuggestion field.
 func (r *queryResolver) TripSuggestion(ctx context.Context, prompt string) (string, error) {
 	return r.TripResolver.TripSuggestion(ctx, prompt)
 }
 
+// TotalCount is the resolver for the totalCount field.
+func (r *rBACAuditConnectionResolver) TotalCount(ctx context.Context, obj *rbacadmin.AuditConnection) (int32, error) {
+	return int32(obj.TotalCount), nil
+}
+
+// ID is the resolver for the id field.
+func (r *rBACAuditEntryResolver) ID(ctx context.Context, obj *rbac.AuditEntry) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// ActorID is the resolver for the actorId field.
+func (r *rBACAuditEntryResolver) ActorID(ctx context.Context, obj *rbac.AuditEntry) (*string, error) {
+	if obj.ActorID == nil {
+		return nil, nil
+	}
+	actorID := obj.ActorID.String()
+	return &actorID, nil
+}
+
+// Actor is the resolver for the actor field.
+func (r *rBACAuditEntryResolver) Actor(ctx context.Context, obj *rbac.AuditEntry) (*user.User, error) {
+	if obj.ActorID == nil {
+		return nil, nil
+	}
+	return r.UserResolver.Member(ctx, *obj.ActorID)
+}
+
+// UserID is the resolver for the userId field.
+func (r *rBACAuditEntryResolver) UserID(ctx context.Context, obj *rbac.AuditEntry) (*string, error) {
+	if obj.UserID == nil {
+		return nil, nil
+	}
+	userID := obj.UserID.String()
+	return &userID, nil
+}
+
+// User is the resolver for the user field.
+func (r *rBACAuditEntryResolver) User(ctx context.Context, obj *rbac.AuditEntry) (*user.User, error) {
+	if obj.UserID == nil {
+		return nil, nil
+	}
+	return r.UserResolver.Member(ctx, *obj.UserID)
+}
+
+// CreatedAt is the resolver for the createdAt field.
+func (r *rBACAuditEntryResolver) CreatedAt(ctx context.Context, obj *rbac.AuditEntry) (string, error) {
+	return obj.CreatedAt.Format(time.RFC3339), nil
+}
+
+// UserID is the resolver for the userId field.
+func (r *rBACRoleAssignmentResolver) UserID(ctx context.Context, obj *rbacadmin.RoleAssignment) (string, error) {
+	return obj.UserID.String(), nil
+}
+
+// User is the resolver for the user field.
+func (r *rBACRoleAssignmentResolver) User(ctx context.Context, obj *rbacadmin.RoleAssignment) (*user.User, error) {
+	return r.UserResolver.Member(ctx, obj.UserID)
+}
+
+// ItineraryDayID is the resolver for the itineraryDayId field.
+func (r *scheduleWarningResolver) ItineraryDayID(ctx context.Context, obj *trip.ScheduleWarning) (string, error) {
+	return obj.ItineraryDayID.String(), nil
+}
+
+// ActivityIds is the resolver for the activityIds field.
+func (r *scheduleWarningResolver) ActivityIds(ctx context.Context, obj *trip.ScheduleWarning) ([]string, error) {
+	activityIDs := make([]string, len(obj.ActivityIDs))
+	for i, id := range obj.ActivityIDs {
+		activityIDs[i] = id.String()
+	}
+	return activityIDs, nil
+}
+
+// ID is the resolver for the id field.
+func (r *serviceAccountResolver) ID(ctx context.Context, obj *accesstoken.ServiceAccount) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// CreatedAt is the resolver for the createdAt field.
+func (r *serviceAccountResolver) CreatedAt(ctx context.Context, obj *accesstoken.ServiceAccount) (string, error) {
+	return obj.CreatedAt.Format(time.RFC3339), nil
+}
+
+// AccessTokens is the resolver for the accessTokens field.
+func (r *serviceAccountResolver) AccessTokens(ctx context.Context, obj *accesstoken.ServiceAccount) ([]*accesstoken.Token, error) {
+	return r.AccessTokenResolver.ServiceAccountAccessTokens(ctx, obj)
+}
+
+// ID is the resolver for the id field.
+func (r *settlementResolver) ID(ctx context.Context, obj *expense.Settlement) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// TripID is the resolver for the tripId field.
+func (r *settlementResolver) TripID(ctx context.Context, obj *expense.Settlement) (string, error) {
+	return obj.TripID.String(), nil
+}
+
+// FromUserID is the resolver for the fromUserId field.
+func (r *settlementResolver) FromUserID(ctx context.Context, obj *expense.Settlement) (string, error) {
+	return obj.FromUserID.String(), nil
+}
+
+// ToUserID is the resolver for the toUserId field.
+func (r *settlementResolver) ToUserID(ctx context.Context, obj *expense.Settlement) (string, error) {
+	return obj.ToUserID.String(), nil
+}
+
+// Date is the resolver for the date field.
+func (r *settlementResolver) Date(ctx context.Context, obj *expense.Settlement) (string, error) {
+	return obj.Date.Format("2006-01-02"), nil
+}
+
+// FromUserID is the resolver for the fromUserId field.
+func (r *settlementTransferResolver) FromUserID(ctx context.Context, obj *expense.Transfer) (string, error) {
+	return obj.FromUserID.String(), nil
+}
+
+// ToUserID is the resolver for the toUserId field.
+func (r *settlementTransferResolver) ToUserID(ctx context.Context, obj *expense.Transfer) (string, error) {
+	return obj.ToUserID.String(), nil
+}
+
+// ID is the resolver for the id field.
+func (r *shareLinkResolver) ID(ctx context.Context, obj *share.Link) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// TripID is the resolver for the tripId field.
+func (r *shareLinkResolver) TripID(ctx context.Context, obj *share.Link) (string, error) {
+	return obj.TripID.String(), nil
+}
+
+// ExpiresAt is the resolver for the expiresAt field.
+func (r *shareLinkResolver) ExpiresAt(ctx context.Context, obj *share.Link) (*string, error) {
+	if obj.ExpiresAt == nil {
+		return nil, nil
+	}
+	expiresAt := obj.ExpiresAt.Format(time.RFC3339)
+	return &expiresAt, nil
+}
+
+// CreatedAt is the resolver for the createdAt field.
+func (r *shareLinkResolver) CreatedAt(ctx context.Context, obj *share.Link) (string, error) {
+	return obj.CreatedAt.Format(time.RFC3339), nil
+}
+
+// LastAccessedAt is the resolver for the lastAccessedAt field.
+func (r *shareLinkResolver) LastAccessedAt(ctx context.Context, obj *share.Link) (*string, error) {
+	if obj.LastAccessedAt == nil {
+		return nil, nil
+	}
+	lastAccessedAt := obj.LastAccessedAt.Format(time.RFC3339)
+	return &lastAccessedAt, nil
+}
+
+// AccessCount is the resolver for the accessCount field.
+func (r *shareLinkResolver) AccessCount(ctx context.Context, obj *share.Link) (int32, error) {
+	return int32(obj.AccessCount), nil
+}
+
+// ID is the resolver for the id field.
+func (r *transportLegResolver) ID(ctx context.Context, obj *trip.TransportLeg) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// ActivityID is the resolver for the activityId field.
+func (r *transportLegResolver) ActivityID(ctx context.Context, obj *trip.TransportLeg) (string, error) {
+	return obj.ActivityID.String(), nil
+}
+
+// DepartTime is the resolver for the departTime field.
+func (r *transportLegResolver) DepartTime(ctx context.Context, obj *trip.TransportLeg) (string, error) {
+	return trip.FormatDateTime(obj.DepartTime, trip.LoadLocation(obj.DepartTimeZone)), nil
+}
+
+// ArriveTime is the resolver for the arriveTime field.
+func (r *transportLegResolver) ArriveTime(ctx context.Context, obj *trip.TransportLeg) (string, error) {
+	return trip.FormatDateTime(obj.ArriveTime, trip.LoadLocation(obj.ArriveTimeZone)), nil
+}
+
+// ID is the resolver for the id field.
+func (r *travelerResolver) ID(ctx context.Context, obj *trip.Traveler) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// TripID is the resolver for the tripId field.
+func (r *travelerResolver) TripID(ctx context.Context, obj *trip.Traveler) (string, error) {
+	return obj.TripID.String(), nil
+}
+
+// UserID is the resolver for the userId field.
+func (r *travelerResolver) UserID(ctx context.Context, obj *trip.Traveler) (*string, error) {
+	if obj.UserID == nil {
+		return nil, nil
+	}
+	userIDStr := obj.UserID.String()
+	return &userIDStr, nil
+}
+
 // ID is the resolver for the id field.
 func (r *tripResolver) ID(ctx context.Context, obj *trip.Trip) (string, error) {
 	return obj.ID.String(), nil
@@ -115,19 +804,86 @@ func (r *tripResolver) OwnerID(ctx context.Context, obj *trip.Trip) (string, err
 	return obj.OwnerID.String(), nil
 }
 
+// Owner is the resolver for the owner field.
+func (r *tripResolver) Owner(ctx context.Context, obj *trip.Trip) (*user.User, error) {
+	return r.UserResolver.Member(ctx, obj.OwnerID)
+}
+
 // StartDate is the resolver for the startDate field.
 func (r *tripResolver) StartDate(ctx context.Context, obj *trip.Trip) (string, error) {
-	return obj.StartDate.Format("2006-01-02"), nil
+	return trip.FormatDate(obj.StartDate, obj.TimeLocation()), nil
 }
 
 // EndDate is the resolver for the endDate field.
 func (r *tripResolver) EndDate(ctx context.Context, obj *trip.Trip) (string, error) {
-	return obj.EndDate.Format("2006-01-02"), nil
+	return trip.FormatDate(obj.EndDate, obj.TimeLocation()), nil
 }
 
 // Travelers is the resolver for the travelers field.
 func (r *tripResolver) Travelers(ctx context.Context, obj *trip.Trip) (int32, error) {
-	return int32(obj.Travelers), nil
+	return int32(obj.TravelerCount()), nil
+}
+
+// ClonedFromID is the resolver for the clonedFromId field.
+func (r *tripResolver) ClonedFromID(ctx context.Context, obj *trip.Trip) (*string, error) {
+	if obj.ClonedFromID == nil {
+		return nil, nil
+	}
+	clonedFromID := obj.ClonedFromID.String()
+	return &clonedFromID, nil
+}
+
+// Itinerary is the resolver for the itinerary field.
+func (r *tripResolver) Itinerary(ctx context.Context, obj *trip.Trip) ([]*trip.ItineraryDay, error) {
+	return r.TripResolver.Itinerary(ctx, obj)
+}
+
+// Collaborators is the resolver for the collaborators field.
+func (r *tripResolver) Collaborators(ctx context.Context, obj *trip.Trip) ([]*trip.TripCollaborator, error) {
+	return r.TripResolver.Collaborators(ctx, obj)
+}
+
+// Expenses is the resolver for the expenses field.
+func (r *tripResolver) Expenses(ctx context.Context, obj *trip.Trip) ([]*expense.Expense, error) {
+	return r.ExpenseResolver.TripExpenses(ctx, obj)
+}
+
+// BudgetSummary is the resolver for the budgetSummary field.
+func (r *tripResolver) BudgetSummary(ctx context.Context, obj *trip.Trip) (*expense.BudgetSummary, error) {
+	return r.ExpenseResolver.BudgetSummary(ctx, obj)
+}
+
+// CalendarFeed is the resolver for the calendarFeed field.
+func (r *tripResolver) CalendarFeed(ctx context.Context, obj *trip.Trip) (*calendar.Feed, error) {
+	return r.CalendarResolver.TripCalendarFeed(ctx, obj)
+}
+
+// ShareLinks is the resolver for the shareLinks field.
+func (r *tripResolver) ShareLinks(ctx context.Context, obj *trip.Trip) ([]*share.Link, error) {
+	return r.ShareResolver.TripShareLinks(ctx, obj)
+}
+
+// Warnings is the resolver for the warnings field.
+func (r *tripResolver) Warnings(ctx context.Context, obj *trip.Trip) ([]*trip.ScheduleWarning, error) {
+	return r.TripResolver.TripWarnings(ctx, obj)
+}
+
+// UnconvertedExpenseIds is the resolver for the unconvertedExpenseIds field.
+func (r *tripBalancesResolver) UnconvertedExpenseIds(ctx context.Context, obj *expense.TripBalances) ([]string, error) {
+	expenseIDs := make([]string, len(obj.UnconvertedExpenseIDs))
+	for i, id := range obj.UnconvertedExpenseIDs {
+		expenseIDs[i] = id.String()
+	}
+	return expenseIDs, nil
+}
+
+// UnconvertedSettlementIds is the resolver for the unconvertedSettlementIds field.
+func (r *tripBalancesResolver) UnconvertedSettlementIds(ctx context.Context, obj *expense.TripBalances) ([]string, error) {
+	settlementIDs := make([]string, len(obj.UnconvertedSettlementIDs))
+	for i, id := range obj.UnconvertedSettlementIDs {
+		settlementIDs[i] = id.String()
+	}
+	return settlementIDs, nil
 }
 
 // TripID is the resolver for the tripId field.
@@ -140,36 +896,202 @@ func (r *tripCollaboratorResolver) UserID(ctx context.Context, obj *trip.TripCol
 	return obj.UserID.String(), nil
 }
 
+// User is the resolver for the user field.
+func (r *tripCollaboratorResolver) User(ctx context.Context, obj *trip.TripCollaborator) (*user.User, error) {
+	return r.UserResolver.Member(ctx, obj.UserID)
+}
+
+// TotalCount is the resolver for the totalCount field.
+func (r *tripConnectionResolver) TotalCount(ctx context.Context, obj *trip.Connection) (int32, error) {
+	return int32(obj.TotalCount), nil
+}
+
+// TripID is the resolver for the tripId field.
+func (r *tripImportPreviewResolver) TripID(ctx context.Context, obj *importer.Preview) (*string, error) {
+	if obj.TripID == nil {
+		return nil, nil
+	}
+	tripIDStr := obj.TripID.String()
+	return &tripIDStr, nil
+}
+
+// ID is the resolver for the id field.
+func (r *tripTemplateResolver) ID(ctx context.Context, obj *trip.Trip) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// LengthDays is the resolver for the lengthDays field.
+func (r *tripTemplateResolver) LengthDays(ctx context.Context, obj *trip.Trip) (int32, error) {
+	return int32(obj.LengthDays()), nil
+}
+
+// Itinerary is the resolver for the itinerary field.
+func (r *tripTemplateResolver) Itinerary(ctx context.Context, obj *trip.Trip) ([]*trip.ItineraryDay, error) {
+	return r.TripResolver.TemplateItinerary(ctx, obj)
+}
+
 // ID is the resolver for the id field.
 func (r *userResolver) ID(ctx context.Context, obj *user.User) (string, error) {
 	return obj.ID.String(), nil
 }
 
+// Preferences is the resolver for the preferences field.
+func (r *userResolver) Preferences(ctx context.Context, obj *user.User) (*user.Preferences, error) {
+	return r.UserResolver.Preferences(ctx, obj)
+}
+
+// TotalCount is the resolver for the totalCount field.
+func (r *userConnectionResolver) TotalCount(ctx context.Context, obj *user.Connection) (int32, error) {
+	return int32(obj.TotalCount), nil
+}
+
+// AccessToken returns AccessTokenResolver implementation.
+func (r *Resolver) AccessToken() AccessTokenResolver { return &accessTokenResolver{r} }
+
+// AccountErasure returns AccountErasureResolver implementation.
+func (r *Resolver) AccountErasure() AccountErasureResolver { return &accountErasureResolver{r} }
+
 // Activity returns ActivityResolver implementation.
 func (r *Resolver) Activity() ActivityResolver { return &activityResolver{r} }
 
+// BudgetSummary returns BudgetSummaryResolver implementation.
+func (r *Resolver) BudgetSummary() BudgetSummaryResolver { return &budgetSummaryResolver{r} }
+
+// CalendarFeed returns CalendarFeedResolver implementation.
+func (r *Resolver) CalendarFeed() CalendarFeedResolver { return &calendarFeedResolver{r} }
+
+// DataExport returns DataExportResolver implementation.
+func (r *Resolver) DataExport() DataExportResolver { return &dataExportResolver{r} }
+
+// DayTotal returns DayTotalResolver implementation.
+func (r *Resolver) DayTotal() DayTotalResolver { return &dayTotalResolver{r} }
+
+// Expense returns ExpenseResolver implementation.
+func (r *Resolver) Expense() ExpenseResolver { return &expenseResolver{r} }
+
+// ExpenseSplit returns ExpenseSplitResolver implementation.
+func (r *Resolver) ExpenseSplit() ExpenseSplitResolver { return &expenseSplitResolver{r} }
+
+// ImportActivityPreview returns ImportActivityPreviewResolver implementation.
+func (r *Resolver) ImportActivityPreview() ImportActivityPreviewResolver {
+	return &importActivityPreviewResolver{r}
+}
+
+// ImportDayPreview returns ImportDayPreviewResolver implementation.
+func (r *Resolver) ImportDayPreview() ImportDayPreviewResolver { return &importDayPreviewResolver{r} }
+
+// ImportError returns ImportErrorResolver implementation.
+func (r *Resolver) ImportError() ImportErrorResolver { return &importErrorResolver{r} }
+
 // ItineraryDay returns ItineraryDayResolver implementation.
 func (r *Resolver) ItineraryDay() ItineraryDayResolver { return &itineraryDayResolver{r} }
 
+// Lodging returns LodgingResolver implementation.
+func (r *Resolver) Lodging() LodgingResolver { return &lodgingResolver{r} }
+
+// MemberBalance returns MemberBalanceResolver implementation.
+func (r *Resolver) MemberBalance() MemberBalanceResolver { return &memberBalanceResolver{r} }
+
 // Mutation returns MutationResolver implementation.
 func (r *Resolver) Mutation() MutationResolver { return &mutationResolver{r} }
 
 // Query returns QueryResolver implementation.
 func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
 
+// RBACAuditConnection returns RBACAuditConnectionResolver implementation.
+func (r *Resolver) RBACAuditConnection() RBACAuditConnectionResolver {
+	return &rBACAuditConnectionResolver{r}
+}
+
+// RBACAuditEntry returns RBACAuditEntryResolver implementation.
+func (r *Resolver) RBACAuditEntry() RBACAuditEntryResolver { return &rBACAuditEntryResolver{r} }
+
+// RBACRoleAssignment returns RBACRoleAssignmentResolver implementation.
+func (r *Resolver) RBACRoleAssignment() RBACRoleAssignmentResolver {
+	return &rBACRoleAssignmentResolver{r}
+}
+
+// ScheduleWarning returns ScheduleWarningResolver implementation.
+func (r *Resolver) ScheduleWarning() ScheduleWarningResolver { return &scheduleWarningResolver{r} }
+
+// ServiceAccount returns ServiceAccountResolver implementation.
+func (r *Resolver) ServiceAccount() ServiceAccountResolver { return &serviceAccountResolver{r} }
+
+// Settlement returns SettlementResolver implementation.
+func (r *Resolver) Settlement() SettlementResolver { return &settlementResolver{r} }
+
+// SettlementTransfer returns SettlementTransferResolver implementation.
+func (r *Resolver) SettlementTransfer() SettlementTransferResolver {
+	return &settlementTransferResolver{r}
+}
+
+// ShareLink returns ShareLinkResolver implementation.
+func (r *Resolver) ShareLink() ShareLinkResolver { return &shareLinkResolver{r} }
+
+// TransportLeg returns TransportLegResolver implementation.
+func (r *Resolver) TransportLeg() TransportLegResolver { return &transportLegResolver{r} }
+
+// Traveler returns TravelerResolver implementation.
+func (r *Resolver) Traveler() TravelerResolver { return &travelerResolver{r} }
+
 // Trip returns TripResolver implementation.
 func (r *Resolver) Trip() TripResolver { return &tripResolver{r} }
 
+// TripBalances returns TripBalancesResolver implementation.
+func (r *Resolver) TripBalances() TripBalancesResolver { return &tripBalancesResolver{r} }
+
 // TripCollaborator returns TripCollaboratorResolver implementation.
 func (r *Resolver) TripCollaborator() TripCollaboratorResolver { return &tripCollaboratorResolver{r} }
 
+// TripConnection returns TripConnectionResolver implementation.
+func (r *Resolver) TripConnection() TripConnectionResolver { return &tripConnectionResolver{r} }
+
+// TripImportPreview returns TripImportPreviewResolver implementation.
+func (r *Resolver) TripImportPreview() TripImportPreviewResolver {
+	return &tripImportPreviewResolver{r}
+}
+
+// TripTemplate returns TripTemplateResolver implementation.
+func (r *Resolver) TripTemplate() TripTemplateResolver { return &tripTemplateResolver{r} }
+
 // User returns UserResolver implementation.
 func (r *Resolver) User() UserResolver { return &userResolver{r} }
 
+// UserConnection returns UserConnectionResolver implementation.
+func (r *Resolver) UserConnection() UserConnectionResolver { return &userConnectionResolver{r} }
+
+type accessTokenResolver struct{ *Resolver }
+type accountErasureResolver struct{ *Resolver }
 type activityResolver struct{ *Resolver }
+type budgetSummaryResolver struct{ *Resolver }
+type calendarFeedResolver struct{ *Resolver }
+type dataExportResolver struct{ *Resolver }
+type dayTotalResolver struct{ *Resolver }
+type expenseResolver struct{ *Resolver }
+type expenseSplitResolver struct{ *Resolver }
+type importActivityPreviewResolver struct{ *Resolver }
+type importDayPreviewResolver struct{ *Resolver }
+type importErrorResolver struct{ *Resolver }
 type itineraryDayResolver struct{ *Resolver }
+type lodgingResolver struct{ *Resolver }
+type memberBalanceResolver struct{ *Resolver }
 type mutationResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
+type rBACAuditConnectionResolver struct{ *Resolver }
+type rBACAuditEntryResolver struct{ *Resolver }
+type rBACRoleAssignmentResolver struct{ *Resolver }
+type scheduleWarningResolver struct{ *Resolver }
+type serviceAccountResolver struct{ *Resolver }
+type settlementResolver struct{ *Resolver }
+type settlementTransferResolver struct{ *Resolver }
+type shareLinkResolver struct{ *Resolver }
+type transportLegResolver struct{ *Resolver }
+type travelerResolver struct{ *Resolver }
 type tripResolver struct{ *Resolver }
+type tripBalancesResolver struct{ *Resolver }
 type tripCollaboratorResolver struct{ *Resolver }
+type tripConnectionResolver struct{ *Resolver }
+type tripImportPreviewResolver struct{ *Resolver }
+type tripTemplateResolver struct{ *Resolver }
 type userResolver struct{ *Resolver }
+type userConnectionResolver struct{ *Resolver }
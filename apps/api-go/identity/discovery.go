@@ -0,0 +1,53 @@
+package identity
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// Well-known paths of an issuer, relative to its URL. They are public so other
+// services can validate the issuer's tokens.
+const (
+	WellKnownPathPrefix = "/.well-known/"
+	KeySetPath          = WellKnownPathPrefix + "jwks.json"
+	DiscoveryPath       = WellKnownPathPrefix + "openid-configuration"
+)
+
+// discoveryDocument is the subset of OpenID Provider Metadata needed to find the keys
+type discoveryDocument struct {
+	Issuer                           string   `json:"issuer"`
+	JWKSURI                          string   `json:"jwks_uri"`
+	IDTokenSigningAlgValuesSupported []string `json:"id_token_signing_alg_values_supported"`
+	SubjectTypesSupported            []string `json:"subject_types_supported"`
+	ResponseTypesSupported           []string `json:"response_types_supported"`
+}
+
+// KeySetHandler serves the publisher's JSON Web Key Set
+func KeySetHandler(publisher KeySetPublisher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		keySet, err := publisher.KeySet()
+		if err != nil {
+			http.Error(w, "failed to encode key set", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(keySet)
+	}
+}
+
+// DiscoveryHandler serves the publisher's OpenID discovery document
+func DiscoveryHandler(publisher KeySetPublisher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		issuer := publisher.Issuer()
+		document := discoveryDocument{
+			Issuer:                           issuer,
+			JWKSURI:                          strings.TrimSuffix(issuer, "/") + KeySetPath,
+			IDTokenSigningAlgValuesSupported: []string{"RS256"},
+			SubjectTypesSupported:            []string{"public"},
+			ResponseTypesSupported:           []string{"token"},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(document)
+	}
+}
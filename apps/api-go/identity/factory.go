@@ -0,0 +1,80 @@
+package identity
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	envProvider = "IDENTITY_PROVIDER"
+
+	ProviderAuth0 = "auth0"
+	ProviderLocal = "local"
+)
+
+// Factory constructs the parts of a provider. Providers register themselves via
+// RegisterProvider.
+type Factory struct {
+	Validator func() (TokenValidator, error)
+	Directory func() (Directory, error)
+}
+
+// providerFactory maps provider names to their constructors
+var providerFactory = map[string]Factory{}
+
+// RegisterProvider registers a provider's constructors
+func RegisterProvider(name string, factory Factory) {
+	providerFactory[name] = factory
+}
+
+// NewDefaultValidator creates the token validator of the provider selected by the
+// IDENTITY_PROVIDER env var, which defaults to auth0
+func NewDefaultValidator() (TokenValidator, error) {
+	name, factory, err := defaultFactory()
+	if err != nil {
+		return nil, err
+	}
+
+	validator, err := factory.Validator()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize %s token validator: %w", name, err)
+	}
+	return validator, nil
+}
+
+// NewDefaultDirectory creates the user directory of the provider selected by the
+// IDENTITY_PROVIDER env var, which defaults to auth0
+func NewDefaultDirectory() (Directory, error) {
+	name, factory, err := defaultFactory()
+	if err != nil {
+		return nil, err
+	}
+
+	directory, err := factory.Directory()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize %s user directory: %w", name, err)
+	}
+	return directory, nil
+}
+
+func defaultFactory() (string, Factory, error) {
+	name := strings.TrimSpace(os.Getenv(envProvider))
+	if name == "" {
+		name = ProviderAuth0
+	}
+
+	factory, exists := providerFactory[name]
+	if !exists {
+		return name, Factory{}, fmt.Errorf("unknown identity provider: %s (available: %v)", name, availableProviders())
+	}
+	return name, factory, nil
+}
+
+func availableProviders() []string {
+	providers := make([]string, 0, len(providerFactory))
+	for name := range providerFactory {
+		providers = append(providers, name)
+	}
+	return providers
+}
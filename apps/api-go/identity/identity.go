@@ -0,0 +1,62 @@
+// Package identity abstracts the identity provider that issues access tokens and manages
+// user accounts. Auth0 is used in deployed environments; the local provider issues its
+// own tokens so the API can run offline.
+package identity
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrUserNotFound = errors.New("identity provider user not found")
+
+// Claims are what a validated access token says about its user
+type Claims struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	GivenName     string
+	FamilyName    string
+}
+
+// TokenValidator verifies access tokens
+type TokenValidator interface {
+	ValidateToken(ctx context.Context, token string) (*Claims, error)
+}
+
+// User is an account at the identity provider
+type User struct {
+	ID            string
+	Email         string
+	EmailVerified bool
+	Name          string
+	FirstName     string
+	LastName      string
+}
+
+// UserUpdate holds the changes to an account. Nil fields are left unchanged.
+type UserUpdate struct {
+	FirstName *string
+	LastName  *string
+	Blocked   *bool
+}
+
+// Directory manages the accounts of the identity provider
+type Directory interface {
+	CreateUser(email, password, firstName, lastName string) (*User, error)
+	GetUser(userID string) (*User, error)
+	GetUserByEmail(email string) (*User, error)
+	UpdateUser(userID string, update UserUpdate) error
+	DeleteUser(userID string) error
+	ListUsers() ([]*User, error)
+}
+
+// KeySetPublisher is implemented by token validators that issue tokens themselves and
+// publish their signing keys for other services
+type KeySetPublisher interface {
+	// Issuer is the URL tokens are issued by
+	Issuer() string
+	// KeySet is the JSON Web Key Set of the public signing keys
+	KeySet() ([]byte, error)
+}
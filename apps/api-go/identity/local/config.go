@@ -0,0 +1,58 @@
+package local
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	envDir      = "LOCAL_IDP_DIR"
+	envIssuer   = "LOCAL_IDP_ISSUER"
+	envAudience = "LOCAL_IDP_AUDIENCE"
+
+	DefaultDir      = ".local-idp"
+	DefaultIssuer   = "http://localhost:8080/"
+	DefaultAudience = "eztrip-local"
+
+	keyFile   = "signing-key.pem"
+	usersFile = "users.json"
+)
+
+// Config locates the local issuer's state and names the tokens it issues. The API
+// and the token CLI share the directory, so tokens minted by one validate in the other.
+type Config struct {
+	Dir      string
+	Issuer   string
+	Audience string
+}
+
+// ConfigFromEnv reads the local issuer configuration, using the defaults for unset values
+func ConfigFromEnv() Config {
+	config := Config{
+		Dir:      strings.TrimSpace(os.Getenv(envDir)),
+		Issuer:   strings.TrimSpace(os.Getenv(envIssuer)),
+		Audience: strings.TrimSpace(os.Getenv(envAudience)),
+	}
+	if config.Dir == "" {
+		config.Dir = DefaultDir
+	}
+	if config.Issuer == "" {
+		config.Issuer = DefaultIssuer
+	}
+	if !strings.HasSuffix(config.Issuer, "/") {
+		config.Issuer += "/"
+	}
+	if config.Audience == "" {
+		config.Audience = DefaultAudience
+	}
+	return config
+}
+
+func (c Config) keyPath() string {
+	return filepath.Join(c.Dir, keyFile)
+}
+
+func (c Config) usersPath() string {
+	return filepath.Join(c.Dir, usersFile)
+}
@@ -0,0 +1,217 @@
+package local
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"eztrip/api-go/identity"
+
+	"github.com/google/uuid"
+)
+
+// userIDPrefix marks local subjects the way Auth0 prefixes them with their connection
+const userIDPrefix = "local|"
+
+var ErrDuplicateEmail = errors.New("a local user with this email already exists")
+
+// storedUser is a user as saved in the users file. Passwords aren't kept: local users
+// sign in with tokens minted by the CLI.
+type storedUser struct {
+	ID            string `json:"id"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"emailVerified"`
+	FirstName     string `json:"firstName"`
+	LastName      string `json:"lastName"`
+	Blocked       bool   `json:"blocked,omitempty"`
+}
+
+func (u storedUser) toIdentity() *identity.User {
+	return &identity.User{
+		ID:            u.ID,
+		Email:         u.Email,
+		EmailVerified: u.EmailVerified,
+		Name:          strings.TrimSpace(u.FirstName + " " + u.LastName),
+		FirstName:     u.FirstName,
+		LastName:      u.LastName,
+	}
+}
+
+// fileMu serializes changes to the users file by the directories of this process
+var fileMu sync.Mutex
+
+// Directory keeps local users in a JSON file
+type Directory struct {
+	path string
+}
+
+var _ identity.Directory = (*Directory)(nil)
+
+// NewDirectory creates a directory backed by the users file of the config's directory
+func NewDirectory(config Config) *Directory {
+	return &Directory{path: config.usersPath()}
+}
+
+// CreateUser adds a user with a verified email; local accounts have no password
+func (d *Directory) CreateUser(email, _, firstName, lastName string) (*identity.User, error) {
+	fileMu.Lock()
+	defer fileMu.Unlock()
+
+	users, err := d.load()
+	if err != nil {
+		return nil, err
+	}
+	if _, found := findByEmail(users, email); found {
+		return nil, ErrDuplicateEmail
+	}
+
+	created := storedUser{
+		ID:            userIDPrefix + uuid.NewString(),
+		Email:         email,
+		EmailVerified: true,
+		FirstName:     firstName,
+		LastName:      lastName,
+	}
+	if err := d.save(append(users, created)); err != nil {
+		return nil, err
+	}
+	return created.toIdentity(), nil
+}
+
+func (d *Directory) GetUser(userID string) (*identity.User, error) {
+	fileMu.Lock()
+	defer fileMu.Unlock()
+
+	users, err := d.load()
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range users {
+		if u.ID == userID {
+			return u.toIdentity(), nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", identity.ErrUserNotFound, userID)
+}
+
+func (d *Directory) GetUserByEmail(email string) (*identity.User, error) {
+	fileMu.Lock()
+	defer fileMu.Unlock()
+
+	users, err := d.load()
+	if err != nil {
+		return nil, err
+	}
+	if u, found := findByEmail(users, email); found {
+		return u.toIdentity(), nil
+	}
+	return nil, fmt.Errorf("%w: %s", identity.ErrUserNotFound, email)
+}
+
+func (d *Directory) UpdateUser(userID string, update identity.UserUpdate) error {
+	fileMu.Lock()
+	defer fileMu.Unlock()
+
+	users, err := d.load()
+	if err != nil {
+		return err
+	}
+	for i := range users {
+		if users[i].ID != userID {
+			continue
+		}
+		if update.FirstName != nil {
+			users[i].FirstName = *update.FirstName
+		}
+		if update.LastName != nil {
+			users[i].LastName = *update.LastName
+		}
+		if update.Blocked != nil {
+			users[i].Blocked = *update.Blocked
+		}
+		return d.save(users)
+	}
+	return fmt.Errorf("%w: %s", identity.ErrUserNotFound, userID)
+}
+
+func (d *Directory) DeleteUser(userID string) error {
+	fileMu.Lock()
+	defer fileMu.Unlock()
+
+	users, err := d.load()
+	if err != nil {
+		return err
+	}
+	kept := users[:0]
+	for _, u := range users {
+		if u.ID != userID {
+			kept = append(kept, u)
+		}
+	}
+	return d.save(kept)
+}
+
+func (d *Directory) ListUsers() ([]*identity.User, error) {
+	fileMu.Lock()
+	defer fileMu.Unlock()
+
+	users, err := d.load()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*identity.User, 0, len(users))
+	for _, u := range users {
+		result = append(result, u.toIdentity())
+	}
+	return result, nil
+}
+
+func findByEmail(users []storedUser, email string) (storedUser, bool) {
+	for _, u := range users {
+		if strings.EqualFold(u.Email, email) {
+			return u, true
+		}
+	}
+	return storedUser{}, false
+}
+
+func (d *Directory) load() ([]storedUser, error) {
+	data, err := os.ReadFile(d.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read local users: %w", err)
+	}
+
+	var users []storedUser
+	if err := json.Unmarshal(data, &users); err != nil {
+		return nil, fmt.Errorf("failed to parse local users: %w", err)
+	}
+	return users, nil
+}
+
+// save replaces the users file, writing a temporary file first so readers in other
+// processes never see a partial file
+func (d *Directory) save(users []storedUser) error {
+	data, err := json.MarshalIndent(users, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode local users: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(d.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create local users directory: %w", err)
+	}
+
+	tmp := d.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write local users: %w", err)
+	}
+	if err := os.Rename(tmp, d.path); err != nil {
+		return fmt.Errorf("failed to write local users: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+// Package local is an identity provider for development and tests. It signs access
+// tokens with a self-generated RSA key, publishes the key as a JWKS, and keeps its
+// users in a JSON file, so the API runs without an Auth0 tenant.
+package local
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"eztrip/api-go/identity"
+
+	jose "gopkg.in/go-jose/go-jose.v2"
+	"gopkg.in/go-jose/go-jose.v2/jwt"
+)
+
+const (
+	// DefaultTokenTTL is how long minted tokens are valid unless asked otherwise
+	DefaultTokenTTL = 24 * time.Hour
+
+	clockSkew = 60 * time.Second
+)
+
+var ErrInvalidToken = errors.New("invalid local token")
+
+// profileClaims are the profile claims the issuer adds next to the registered ones
+type profileClaims struct {
+	Email         string `json:"email,omitempty"`
+	EmailVerified bool   `json:"email_verified"`
+	Name          string `json:"name,omitempty"`
+	GivenName     string `json:"given_name,omitempty"`
+	FamilyName    string `json:"family_name,omitempty"`
+}
+
+// Issuer signs and validates the local provider's access tokens
+type Issuer struct {
+	config Config
+	signer jose.Signer
+	public jose.JSONWebKey
+}
+
+var (
+	_ identity.TokenValidator  = (*Issuer)(nil)
+	_ identity.KeySetPublisher = (*Issuer)(nil)
+)
+
+// NewIssuer loads the signing key from the config's directory, creating it on first use
+func NewIssuer(config Config) (*Issuer, error) {
+	key, err := loadOrCreateKey(config.keyPath())
+	if err != nil {
+		return nil, err
+	}
+	kid, err := keyID(key)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.RS256, Key: jose.JSONWebKey{Key: key, KeyID: kid}},
+		(&jose.SignerOptions{}).WithType("JWT"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token signer: %w", err)
+	}
+
+	return &Issuer{
+		config: config,
+		signer: signer,
+		public: jose.JSONWebKey{Key: &key.PublicKey, KeyID: kid, Algorithm: string(jose.RS256), Use: "sig"},
+	}, nil
+}
+
+// Mint issues an access token for the claims, valid for ttl
+func (i *Issuer) Mint(claims identity.Claims, ttl time.Duration) (string, error) {
+	now := time.Now()
+	registered := jwt.Claims{
+		Issuer:    i.config.Issuer,
+		Subject:   claims.Subject,
+		Audience:  jwt.Audience{i.config.Audience},
+		IssuedAt:  jwt.NewNumericDate(now),
+		NotBefore: jwt.NewNumericDate(now),
+		Expiry:    jwt.NewNumericDate(now.Add(ttl)),
+	}
+	profile := profileClaims{
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+		Name:          claims.Name,
+		GivenName:     claims.GivenName,
+		FamilyName:    claims.FamilyName,
+	}
+
+	token, err := jwt.Signed(i.signer).Claims(registered).Claims(profile).CompactSerialize()
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token: %w", err)
+	}
+	return token, nil
+}
+
+// ValidateToken checks a token's signature, issuer, audience and expiry and returns
+// its claims
+func (i *Issuer) ValidateToken(_ context.Context, token string) (*identity.Claims, error) {
+	parsed, err := jwt.ParseSigned(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	if len(parsed.Headers) != 1 || parsed.Headers[0].Algorithm != string(jose.RS256) {
+		return nil, fmt.Errorf("%w: unexpected signing algorithm", ErrInvalidToken)
+	}
+
+	var registered jwt.Claims
+	var profile profileClaims
+	if err := parsed.Claims(i.public.Key, &registered, &profile); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	expected := jwt.Expected{
+		Issuer:   i.config.Issuer,
+		Audience: jwt.Audience{i.config.Audience},
+		Time:     time.Now(),
+	}
+	if err := registered.ValidateWithLeeway(expected, clockSkew); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	return &identity.Claims{
+		Subject:       registered.Subject,
+		Email:         profile.Email,
+		EmailVerified: profile.EmailVerified,
+		Name:          profile.Name,
+		GivenName:     profile.GivenName,
+		FamilyName:    profile.FamilyName,
+	}, nil
+}
+
+// Issuer is the URL in the iss claim of minted tokens
+func (i *Issuer) Issuer() string {
+	return i.config.Issuer
+}
+
+// KeySet is the JWKS of the public signing key
+func (i *Issuer) KeySet() ([]byte, error) {
+	return json.Marshal(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{i.public}})
+}
@@ -0,0 +1,82 @@
+package local
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	jose "gopkg.in/go-jose/go-jose.v2"
+)
+
+const (
+	keyBits     = 2048
+	pemKeyBlock = "RSA PRIVATE KEY"
+)
+
+// loadOrCreateKey reads the signing key, generating and saving a new one on first use
+func loadOrCreateKey(path string) (*rsa.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return createKey(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signing key: %w", err)
+	}
+	return parseKey(data)
+}
+
+func createKey(path string) (*rsa.PrivateKey, error) {
+	key, err := rsa.GenerateKey(rand.Reader, keyBits)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate signing key: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create key directory: %w", err)
+	}
+
+	// Another process may create the key at the same time; the first one wins
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if errors.Is(err, os.ErrExist) {
+		return loadOrCreateKey(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to save signing key: %w", err)
+	}
+	defer file.Close()
+
+	block := &pem.Block{Type: pemKeyBlock, Bytes: x509.MarshalPKCS1PrivateKey(key)}
+	if err := pem.Encode(file, block); err != nil {
+		return nil, fmt.Errorf("failed to save signing key: %w", err)
+	}
+	return key, nil
+}
+
+func parseKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != pemKeyBlock {
+		return nil, fmt.Errorf("signing key is not a PEM encoded RSA private key")
+	}
+	key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signing key: %w", err)
+	}
+	return key, nil
+}
+
+// keyID identifies a key by its JWK thumbprint, so the ID changes with the key
+func keyID(key *rsa.PrivateKey) (string, error) {
+	jwk := jose.JSONWebKey{Key: &key.PublicKey}
+	thumbprint, err := jwk.Thumbprint(crypto.SHA256)
+	if err != nil {
+		return "", fmt.Errorf("failed to compute key ID: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(thumbprint), nil
+}
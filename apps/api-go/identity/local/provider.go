@@ -0,0 +1,14 @@
+package local
+
+import "eztrip/api-go/identity"
+
+func init() {
+	identity.RegisterProvider(identity.ProviderLocal, identity.Factory{
+		Validator: func() (identity.TokenValidator, error) {
+			return NewIssuer(ConfigFromEnv())
+		},
+		Directory: func() (identity.Directory, error) {
+			return NewDirectory(ConfigFromEnv()), nil
+		},
+	})
+}
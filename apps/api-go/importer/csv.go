@@ -0,0 +1,258 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"eztrip/api-go/trip"
+)
+
+// csvColumn identifies an activity field read from a CSV column
+type csvColumn string
+
+const (
+	columnDate        csvColumn = "date"
+	columnTime        csvColumn = "time"
+	columnEndTime     csvColumn = "endTime"
+	columnTitle       csvColumn = "title"
+	columnLocation    csvColumn = "location"
+	columnCategory    csvColumn = "category"
+	columnType        csvColumn = "type"
+	columnDescription csvColumn = "description"
+	columnNotes       csvColumn = "notes"
+)
+
+// defaultHeaders are the header names recognized for each field when no mapping is given
+var defaultHeaders = map[csvColumn][]string{
+	columnDate:        {"date", "day"},
+	columnTime:        {"time", "start time", "start"},
+	columnEndTime:     {"end time", "end"},
+	columnTitle:       {"title", "activity", "name", "summary"},
+	columnLocation:    {"location", "place", "address"},
+	columnCategory:    {"category"},
+	columnType:        {"type"},
+	columnDescription: {"description", "details"},
+	columnNotes:       {"notes"},
+}
+
+var csvDateFormats = []string{
+	"2006-01-02",
+	"2006/01/02",
+	"01/02/2006",
+	"1/2/2006",
+	"Jan 2, 2006",
+	"January 2, 2006",
+}
+
+var csvTimeFormats = []string{
+	"15:04",
+	"15:04:05",
+	"3:04 PM",
+	"3:04PM",
+	"3 PM",
+	"3PM",
+}
+
+// parseCSV reads one activity per row, locating columns by header name
+func parseCSV(content string, mapping *ColumnMapping, location *time.Location) ([]event, []Issue) {
+	reader := csv.NewReader(strings.NewReader(content))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, []Issue{issue(0, "", "The file has no header row")}
+	}
+
+	columns, issues := resolveColumns(header, mapping)
+	if len(issues) > 0 {
+		return nil, issues
+	}
+
+	var events []event
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		line, _ := reader.FieldPos(0)
+		if err != nil {
+			issues = append(issues, issue(line, "", "Malformed CSV row: "+err.Error()))
+			continue
+		}
+
+		get := func(column csvColumn) string {
+			index, ok := columns[column]
+			if !ok || index >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[index])
+		}
+
+		if isBlank(record) {
+			continue
+		}
+
+		e := event{
+			line:         line,
+			title:        get(columnTitle),
+			location:     get(columnLocation),
+			description:  get(columnDescription),
+			notes:        get(columnNotes),
+			category:     get(columnCategory),
+			activityType: get(columnType),
+		}
+
+		start, ok := parseCSVDateTime(get(columnDate), get(columnTime), location)
+		if !ok {
+			issues = append(issues, issue(line, string(columnDate), fmt.Sprintf("Invalid date or time %q", strings.TrimSpace(get(columnDate)+" "+get(columnTime)))))
+			continue
+		}
+		e.start = start
+
+		if rawEnd := get(columnEndTime); rawEnd != "" {
+			end, ok := parseCSVEndTime(rawEnd, start, location)
+			if !ok {
+				issues = append(issues, issue(line, string(columnEndTime), fmt.Sprintf("Invalid end time %q", rawEnd)))
+				continue
+			}
+			e.end = &end
+		}
+
+		events = append(events, e)
+	}
+
+	if len(events) == 0 && len(issues) == 0 {
+		issues = append(issues, issue(0, "", "The file has no activities"))
+	}
+
+	return events, issues
+}
+
+// resolveColumns maps each field to a column index using the mapping or the default headers
+func resolveColumns(header []string, mapping *ColumnMapping) (map[csvColumn]int, []Issue) {
+	indexes := make(map[string]int, len(header))
+	for i, name := range header {
+		indexes[normalizeHeader(name)] = i
+	}
+
+	mapped := map[csvColumn]*string{}
+	if mapping != nil {
+		mapped = map[csvColumn]*string{
+			columnDate:        mapping.Date,
+			columnTime:        mapping.Time,
+			columnEndTime:     mapping.EndTime,
+			columnTitle:       mapping.Title,
+			columnLocation:    mapping.Location,
+			columnCategory:    mapping.Category,
+			columnType:        mapping.Type,
+			columnDescription: mapping.Description,
+			columnNotes:       mapping.Notes,
+		}
+	}
+
+	columns := make(map[csvColumn]int)
+	var issues []Issue
+	for column, names := range defaultHeaders {
+		if name := mapped[column]; name != nil {
+			index, ok := indexes[normalizeHeader(*name)]
+			if !ok {
+				issues = append(issues, issue(1, string(column), fmt.Sprintf("Column %q not found", *name)))
+				continue
+			}
+			columns[column] = index
+			continue
+		}
+
+		for _, name := range names {
+			if index, ok := indexes[name]; ok {
+				columns[column] = index
+				break
+			}
+		}
+	}
+
+	for _, required := range []csvColumn{columnDate, columnTitle} {
+		if _, ok := columns[required]; !ok && mapped[required] == nil {
+			issues = append(issues, issue(1, string(required), fmt.Sprintf("A %s column is required", required)))
+		}
+	}
+
+	return columns, issues
+}
+
+// parseCSVDateTime combines a date and optional time column. The date column may also
+// hold a full date-time. Rows without a time start at midnight.
+func parseCSVDateTime(rawDate, rawTime string, location *time.Location) (time.Time, bool) {
+	if rawDate == "" {
+		return time.Time{}, false
+	}
+
+	if rawTime == "" {
+		if parsed, err := trip.ParseDateTime(strings.Replace(rawDate, " ", "T", 1), location); err == nil {
+			return parsed, true
+		}
+	}
+
+	date, ok := parseFirst(csvDateFormats, rawDate, location)
+	if !ok {
+		return time.Time{}, false
+	}
+	if rawTime == "" {
+		return date, true
+	}
+
+	clock, ok := parseFirst(csvTimeFormats, strings.ToUpper(rawTime), time.UTC)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	return time.Date(date.Year(), date.Month(), date.Day(), clock.Hour(), clock.Minute(), clock.Second(), 0, location), true
+}
+
+// parseCSVEndTime parses an end time on the start's date, or a full date-time.
+// A time-only end earlier than the start is taken to be after midnight.
+func parseCSVEndTime(rawEnd string, start time.Time, location *time.Location) (time.Time, bool) {
+	if parsed, err := trip.ParseDateTime(strings.Replace(rawEnd, " ", "T", 1), location); err == nil {
+		return parsed, true
+	}
+
+	clock, ok := parseFirst(csvTimeFormats, strings.ToUpper(rawEnd), time.UTC)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	local := start.In(location)
+	end := time.Date(local.Year(), local.Month(), local.Day(), clock.Hour(), clock.Minute(), clock.Second(), 0, location)
+	if end.Before(start) {
+		end = end.AddDate(0, 0, 1)
+	}
+	return end, true
+}
+
+func parseFirst(formats []string, value string, location *time.Location) (time.Time, bool) {
+	for _, format := range formats {
+		if parsed, err := time.ParseInLocation(format, value, location); err == nil {
+			return parsed, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func normalizeHeader(name string) string {
+	name = strings.TrimPrefix(name, "\ufeff") // Spreadsheet exports often start with a byte order mark
+	return strings.ToLower(strings.Join(strings.Fields(strings.ReplaceAll(name, "_", " ")), " "))
+}
+
+func isBlank(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
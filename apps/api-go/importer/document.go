@@ -0,0 +1,120 @@
+package importer
+
+import (
+	"time"
+)
+
+const (
+	// DocumentFormat identifies JSON trip documents
+	DocumentFormat = "eztrip.trip"
+	// DocumentVersion is the current version of the JSON trip document. Bump it when the
+	// document changes in a way older readers can't handle.
+	DocumentVersion = 1
+)
+
+// Document is the portable JSON representation of a trip. IDs are only meaningful within
+// the document: they link activities to places and travelers, and are replaced on import.
+// Users are referenced by email so documents can move between environments.
+type Document struct {
+	Format     string       `json:"format"`
+	Version    int          `json:"version"`
+	ExportedAt time.Time    `json:"exportedAt"`
+	Trip       TripDocument `json:"trip"`
+}
+
+// TripDocument holds a trip's settings and everything planned for it
+type TripDocument struct {
+	ID                 string             `json:"id"`
+	Title              string             `json:"title"`
+	Destination        string             `json:"destination"`
+	StartDate          time.Time          `json:"startDate"`
+	EndDate            time.Time          `json:"endDate"`
+	TimeZone           string             `json:"timeZone"`
+	TravelerCount      int                `json:"travelerCount"`
+	HomeCurrency       string             `json:"homeCurrency"`
+	Budget             *float64           `json:"budget,omitempty"`
+	OwnerEmail         string             `json:"ownerEmail,omitempty"`
+	CollaboratorEmails []string           `json:"collaboratorEmails"`
+	Travelers          []TravelerDocument `json:"travelers"`
+	Places             []PlaceDocument    `json:"places"`
+	Days               []DayDocument      `json:"days"`
+	Lodgings           []LodgingDocument  `json:"lodgings"`
+}
+
+// TravelerDocument is a named traveler, optionally linked to a user by email
+type TravelerDocument struct {
+	ID        string  `json:"id"`
+	Name      string  `json:"name"`
+	AgeGroup  string  `json:"ageGroup"`
+	UserEmail *string `json:"userEmail,omitempty"`
+}
+
+// PlaceDocument carries the cached place details so the trip can be imported into an
+// environment that hasn't fetched the place yet
+type PlaceDocument struct {
+	ID               string   `json:"id"`
+	GooglePlaceID    string   `json:"googlePlaceId"`
+	Name             string   `json:"name"`
+	Rating           float64  `json:"rating,omitempty"`
+	ReviewCount      int      `json:"reviewCount,omitempty"`
+	PrimaryPhotoURL  string   `json:"primaryPhotoUrl,omitempty"`
+	Address          string   `json:"address,omitempty"`
+	FormattedAddress string   `json:"formattedAddress,omitempty"`
+	Website          string   `json:"website,omitempty"`
+	PhoneNumber      string   `json:"phoneNumber,omitempty"`
+	PriceLevel       int      `json:"priceLevel,omitempty"`
+	Latitude         *float64 `json:"latitude,omitempty"`
+	Longitude        *float64 `json:"longitude,omitempty"`
+}
+
+// DayDocument is an itinerary day with its activities
+type DayDocument struct {
+	ID         string             `json:"id"`
+	Date       time.Time          `json:"date"`
+	DayNumber  int                `json:"dayNumber"`
+	Activities []ActivityDocument `json:"activities"`
+}
+
+// ActivityDocument is an activity with its booking details and participants
+type ActivityDocument struct {
+	ID           string                `json:"id"`
+	Type         string                `json:"type"`
+	Time         time.Time             `json:"time"`
+	EndTime      *time.Time            `json:"endTime,omitempty"`
+	TimeZone     *string               `json:"timeZone,omitempty"`
+	Title        string                `json:"title"`
+	Location     string                `json:"location,omitempty"`
+	Category     string                `json:"category"`
+	Description  string                `json:"description,omitempty"`
+	Notes        string                `json:"notes,omitempty"`
+	PlaceID      *string               `json:"placeId,omitempty"`
+	TravelerIDs  []string              `json:"travelerIds,omitempty"`
+	TransportLeg *TransportLegDocument `json:"transportLeg,omitempty"`
+}
+
+// TransportLegDocument holds the booking details of a transport activity
+type TransportLegDocument struct {
+	Mode               string    `json:"mode"`
+	Origin             string    `json:"origin"`
+	Destination        string    `json:"destination"`
+	DepartTime         time.Time `json:"departTime"`
+	DepartTimeZone     string    `json:"departTimeZone"`
+	ArriveTime         time.Time `json:"arriveTime"`
+	ArriveTimeZone     string    `json:"arriveTimeZone"`
+	Carrier            *string   `json:"carrier,omitempty"`
+	ConfirmationNumber *string   `json:"confirmationNumber,omitempty"`
+	Seat               *string   `json:"seat,omitempty"`
+}
+
+// LodgingDocument is a lodging stay with calendar dates formatted as YYYY-MM-DD
+type LodgingDocument struct {
+	ID                 string   `json:"id"`
+	PlaceID            *string  `json:"placeId,omitempty"`
+	Name               string   `json:"name"`
+	Address            string   `json:"address,omitempty"`
+	CheckInDate        string   `json:"checkInDate"`
+	CheckOutDate       string   `json:"checkOutDate"`
+	ConfirmationNumber *string  `json:"confirmationNumber,omitempty"`
+	CostAmount         *float64 `json:"costAmount,omitempty"`
+	CostCurrency       *string  `json:"costCurrency,omitempty"`
+}
@@ -0,0 +1,232 @@
+package importer
+
+import (
+	"context"
+	"time"
+
+	appErrors "eztrip/api-go/errors"
+	"eztrip/api-go/logger"
+	"eztrip/api-go/place"
+	"eztrip/api-go/trip"
+	"eztrip/api-go/user"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+const dateFormat = "2006-01-02"
+
+// Export builds the portable JSON document of a trip the user has access to
+func (s *Service) Export(ctx context.Context, tripID uuid.UUID) (*Document, error) {
+	t, err := s.tripService.GetByID(ctx, tripID)
+	if err != nil {
+		return nil, err
+	}
+
+	places, err := s.loadPlaces(ctx, t)
+	if err != nil {
+		return nil, err
+	}
+
+	emails, err := s.loadEmails(ctx, t)
+	if err != nil {
+		return nil, err
+	}
+
+	return toDocument(t, places, emails), nil
+}
+
+// loadPlaces fetches the places referenced by the trip's activities and lodgings
+func (s *Service) loadPlaces(ctx context.Context, t *trip.Trip) ([]place.Place, error) {
+	var placeIDs []uuid.UUID
+	for _, day := range t.Itinerary {
+		for _, activity := range day.Activities {
+			if activity.PlaceID != nil {
+				placeIDs = append(placeIDs, *activity.PlaceID)
+			}
+		}
+	}
+	for _, lodging := range t.Lodgings {
+		if lodging.PlaceID != nil {
+			placeIDs = append(placeIDs, *lodging.PlaceID)
+		}
+	}
+
+	places := []place.Place{}
+	if len(placeIDs) == 0 {
+		return places, nil
+	}
+
+	if err := s.db.WithContext(ctx).Where("id IN ?", placeIDs).Order("name ASC").Find(&places).Error; err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"trip_id": t.ID,
+			"error":   err.Error(),
+		}).Error("Failed to fetch places for trip export")
+		return nil, appErrors.Internal("Failed to export trip")
+	}
+
+	return places, nil
+}
+
+// loadEmails maps the IDs of the trip's members and linked travelers to their emails
+func (s *Service) loadEmails(ctx context.Context, t *trip.Trip) (map[uuid.UUID]string, error) {
+	userIDs := t.MemberIDs()
+	for _, traveler := range t.TravelerList {
+		if traveler.UserID != nil {
+			userIDs = append(userIDs, *traveler.UserID)
+		}
+	}
+
+	var users []user.User
+	if err := s.db.WithContext(ctx).Where("id IN ?", userIDs).Find(&users).Error; err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"trip_id": t.ID,
+			"error":   err.Error(),
+		}).Error("Failed to fetch users for trip export")
+		return nil, appErrors.Internal("Failed to export trip")
+	}
+
+	emails := make(map[uuid.UUID]string, len(users))
+	for _, u := range users {
+		emails[u.ID] = u.Email
+	}
+	return emails, nil
+}
+
+// toDocument converts a loaded trip to its portable representation
+func toDocument(t *trip.Trip, places []place.Place, emails map[uuid.UUID]string) *Document {
+	doc := &Document{
+		Format:     DocumentFormat,
+		Version:    DocumentVersion,
+		ExportedAt: time.Now().UTC(),
+		Trip: TripDocument{
+			ID:                 t.ID.String(),
+			Title:              t.Title,
+			Destination:        t.Destination,
+			StartDate:          t.StartDate,
+			EndDate:            t.EndDate,
+			TimeZone:           t.TimeZone,
+			TravelerCount:      t.Travelers,
+			HomeCurrency:       t.HomeCurrency,
+			Budget:             t.Budget,
+			OwnerEmail:         emails[t.OwnerID],
+			CollaboratorEmails: []string{},
+			Travelers:          []TravelerDocument{},
+			Places:             []PlaceDocument{},
+			Days:               []DayDocument{},
+			Lodgings:           []LodgingDocument{},
+		},
+	}
+
+	for _, collaborator := range t.Collaborators {
+		if email, ok := emails[collaborator.UserID]; ok {
+			doc.Trip.CollaboratorEmails = append(doc.Trip.CollaboratorEmails, email)
+		}
+	}
+
+	for _, traveler := range t.TravelerList {
+		travelerDoc := TravelerDocument{
+			ID:       traveler.ID.String(),
+			Name:     traveler.Name,
+			AgeGroup: string(traveler.AgeGroup),
+		}
+		if traveler.UserID != nil {
+			if email, ok := emails[*traveler.UserID]; ok {
+				travelerDoc.UserEmail = &email
+			}
+		}
+		doc.Trip.Travelers = append(doc.Trip.Travelers, travelerDoc)
+	}
+
+	for _, p := range places {
+		doc.Trip.Places = append(doc.Trip.Places, PlaceDocument{
+			ID:               p.ID.String(),
+			GooglePlaceID:    p.GooglePlaceID,
+			Name:             p.Name,
+			Rating:           p.Rating,
+			ReviewCount:      p.ReviewCount,
+			PrimaryPhotoURL:  p.PrimaryPhotoURL,
+			Address:          p.Address,
+			FormattedAddress: p.FormattedAddress,
+			Website:          p.Website,
+			PhoneNumber:      p.PhoneNumber,
+			PriceLevel:       p.PriceLevel,
+			Latitude:         p.Latitude,
+			Longitude:        p.Longitude,
+		})
+	}
+
+	for _, day := range t.Itinerary {
+		dayDoc := DayDocument{
+			ID:         day.ID.String(),
+			Date:       day.Date,
+			DayNumber:  day.DayNumber,
+			Activities: []ActivityDocument{},
+		}
+		for _, activity := range day.Activities {
+			dayDoc.Activities = append(dayDoc.Activities, toActivityDocument(activity))
+		}
+		doc.Trip.Days = append(doc.Trip.Days, dayDoc)
+	}
+
+	for _, lodging := range t.Lodgings {
+		doc.Trip.Lodgings = append(doc.Trip.Lodgings, LodgingDocument{
+			ID:                 lodging.ID.String(),
+			PlaceID:            uuidString(lodging.PlaceID),
+			Name:               lodging.Name,
+			Address:            lodging.Address,
+			CheckInDate:        lodging.CheckInDate.Format(dateFormat),
+			CheckOutDate:       lodging.CheckOutDate.Format(dateFormat),
+			ConfirmationNumber: lodging.ConfirmationNumber,
+			CostAmount:         lodging.CostAmount,
+			CostCurrency:       lodging.CostCurrency,
+		})
+	}
+
+	return doc
+}
+
+func toActivityDocument(activity trip.Activity) ActivityDocument {
+	doc := ActivityDocument{
+		ID:          activity.ID.String(),
+		Type:        string(activity.Type),
+		Time:        activity.Time,
+		EndTime:     activity.EndTime,
+		TimeZone:    activity.TimeZone,
+		Title:       activity.Title,
+		Location:    activity.Location,
+		Category:    string(activity.Category),
+		Description: activity.Description,
+		Notes:       activity.Notes,
+		PlaceID:     uuidString(activity.PlaceID),
+	}
+
+	for _, traveler := range activity.Travelers {
+		doc.TravelerIDs = append(doc.TravelerIDs, traveler.ID.String())
+	}
+
+	if leg := activity.TransportLeg; leg != nil {
+		doc.TransportLeg = &TransportLegDocument{
+			Mode:               string(leg.Mode),
+			Origin:             leg.Origin,
+			Destination:        leg.Destination,
+			DepartTime:         leg.DepartTime,
+			DepartTimeZone:     leg.DepartTimeZone,
+			ArriveTime:         leg.ArriveTime,
+			ArriveTimeZone:     leg.ArriveTimeZone,
+			Carrier:            leg.Carrier,
+			ConfirmationNumber: leg.ConfirmationNumber,
+			Seat:               leg.Seat,
+		}
+	}
+
+	return doc
+}
+
+func uuidString(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	value := id.String()
+	return &value
+}
@@ -0,0 +1,242 @@
+package importer
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"eztrip/api-go/trip"
+)
+
+// icsProperty is a content line such as DTSTART;TZID=Europe/Paris:20260110T090000
+type icsProperty struct {
+	line   int
+	name   string
+	params map[string]string
+	value  string
+}
+
+// icsCalendar holds the calendar-level properties and the events of an .ics file
+type icsCalendar struct {
+	name     string
+	timeZone string
+	events   [][]icsProperty
+}
+
+var icsDurationPattern = regexp.MustCompile(`^(-)?P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// parseICS unfolds content lines and collects VEVENT properties. Components nested in
+// events, such as alarms, are skipped, and zones are resolved by TZID rather than VTIMEZONE.
+func parseICS(content string) (*icsCalendar, []Issue) {
+	properties := unfoldICS(content)
+	cal := &icsCalendar{}
+	var issues []Issue
+
+	var current []icsProperty
+	var inEvent bool
+	var nested int
+
+	for _, prop := range properties {
+		switch {
+		case prop.name == "BEGIN" && strings.EqualFold(prop.value, "VEVENT"):
+			inEvent = true
+			nested = 0
+			current = []icsProperty{prop}
+		case prop.name == "END" && strings.EqualFold(prop.value, "VEVENT"):
+			if inEvent {
+				cal.events = append(cal.events, current)
+			}
+			inEvent = false
+			current = nil
+		case inEvent && prop.name == "BEGIN":
+			nested++
+		case inEvent && prop.name == "END":
+			nested--
+		case inEvent && nested == 0:
+			current = append(current, prop)
+		case !inEvent && prop.name == "X-WR-CALNAME":
+			cal.name = unescapeICS(prop.value)
+		case !inEvent && prop.name == "X-WR-TIMEZONE":
+			cal.timeZone = prop.value
+		}
+	}
+
+	if inEvent {
+		issues = append(issues, issue(0, "", "The calendar ends in the middle of an event"))
+	}
+	if len(cal.events) == 0 && len(issues) == 0 {
+		issues = append(issues, issue(0, "", "The calendar has no events"))
+	}
+
+	return cal, issues
+}
+
+// unfoldICS joins folded lines and splits each content line into name, parameters and value
+func unfoldICS(content string) []icsProperty {
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+
+	var properties []icsProperty
+	for i, line := range lines {
+		if line == "" {
+			continue
+		}
+		if (line[0] == ' ' || line[0] == '\t') && len(properties) > 0 {
+			properties[len(properties)-1].value += line[1:]
+			continue
+		}
+
+		colon := indexOutsideQuotes(line, ':')
+		if colon < 0 {
+			continue
+		}
+
+		parts := strings.Split(line[:colon], ";")
+		prop := icsProperty{
+			line:   i + 1,
+			name:   strings.ToUpper(parts[0]),
+			params: make(map[string]string),
+			value:  line[colon+1:],
+		}
+		for _, param := range parts[1:] {
+			if key, value, ok := strings.Cut(param, "="); ok {
+				prop.params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+			}
+		}
+		properties = append(properties, prop)
+	}
+
+	return properties
+}
+
+// toEvents converts VEVENT properties to import entries. Floating times and all-day
+// dates are interpreted in the trip's time zone.
+func (cal *icsCalendar) toEvents(location *time.Location) ([]event, []Issue) {
+	var events []event
+	var issues []Issue
+
+	for _, props := range cal.events {
+		e := event{line: props[0].line}
+		var duration *time.Duration
+		valid := true
+
+		for _, prop := range props[1:] {
+			switch prop.name {
+			case "SUMMARY":
+				e.title = unescapeICS(prop.value)
+			case "LOCATION":
+				e.location = unescapeICS(prop.value)
+			case "DESCRIPTION":
+				e.description = unescapeICS(prop.value)
+			case "CATEGORIES":
+				first, _, _ := strings.Cut(prop.value, ",")
+				e.category = unescapeICS(first)
+			case "DTSTART":
+				start, ok := parseICSTime(prop, location)
+				if !ok {
+					issues = append(issues, issue(prop.line, "DTSTART", fmt.Sprintf("Invalid start time %q", prop.value)))
+					valid = false
+					continue
+				}
+				e.start = start
+			case "DTEND":
+				end, ok := parseICSTime(prop, location)
+				if !ok {
+					issues = append(issues, issue(prop.line, "DTEND", fmt.Sprintf("Invalid end time %q", prop.value)))
+					valid = false
+					continue
+				}
+				e.end = &end
+			case "DURATION":
+				d, ok := parseICSDuration(prop.value)
+				if !ok {
+					issues = append(issues, issue(prop.line, "DURATION", fmt.Sprintf("Invalid duration %q", prop.value)))
+					valid = false
+					continue
+				}
+				duration = &d
+			}
+		}
+
+		if e.start.IsZero() {
+			if valid {
+				issues = append(issues, issue(e.line, "DTSTART", "Event has no start time"))
+			}
+			continue
+		}
+		if e.end == nil && duration != nil {
+			end := e.start.Add(*duration)
+			e.end = &end
+		}
+		if valid {
+			events = append(events, e)
+		}
+	}
+
+	return events, issues
+}
+
+// parseICSTime parses a DATE or DATE-TIME value, honoring the TZID parameter
+func parseICSTime(prop icsProperty, location *time.Location) (time.Time, bool) {
+	value := strings.TrimSpace(prop.value)
+
+	if prop.params["VALUE"] == "DATE" || len(value) == len("20060102") {
+		parsed, err := time.ParseInLocation("20060102", value, location)
+		return parsed, err == nil
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		parsed, err := time.Parse("20060102T150405Z", value)
+		return parsed, err == nil
+	}
+
+	if tzid := prop.params["TZID"]; tzid != "" && trip.IsValidTimeZone(tzid) {
+		location = trip.LoadLocation(tzid)
+	}
+
+	parsed, err := time.ParseInLocation("20060102T150405", value, location)
+	return parsed, err == nil
+}
+
+// parseICSDuration parses a non-negative RFC 5545 duration such as PT1H30M or P1D
+func parseICSDuration(value string) (time.Duration, bool) {
+	match := icsDurationPattern.FindStringSubmatch(strings.TrimSpace(value))
+	if match == nil || match[1] == "-" {
+		return 0, false
+	}
+
+	units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+	var total time.Duration
+	for i, unit := range units {
+		if match[i+2] == "" {
+			continue
+		}
+		n, _ := strconv.Atoi(match[i+2])
+		total += time.Duration(n) * unit
+	}
+
+	return total, true
+}
+
+// unescapeICS reverses RFC 5545 TEXT escaping
+func unescapeICS(value string) string {
+	replacer := strings.NewReplacer(`\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";", `\\`, `\`)
+	return strings.TrimSpace(replacer.Replace(value))
+}
+
+// indexOutsideQuotes finds the first separator that isn't inside a quoted parameter value
+func indexOutsideQuotes(s string, sep byte) int {
+	quoted := false
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			quoted = !quoted
+		case sep:
+			if !quoted {
+				return i
+			}
+		}
+	}
+	return -1
+}
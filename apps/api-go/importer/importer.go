@@ -0,0 +1,115 @@
+package importer
+
+import (
+	"time"
+
+	"eztrip/api-go/trip"
+
+	"github.com/google/uuid"
+)
+
+// Format identifies the kind of file being imported
+type Format string
+
+const (
+	FormatICS  Format = "ics"
+	FormatCSV  Format = "csv"
+	FormatJSON Format = "json"
+)
+
+const (
+	maxTripDays    = 90
+	maxTitleLength = 255
+)
+
+// Input represents a file submitted for import along with the details of the new trip.
+// Title and time zone fall back to the calendar's name and zone for .ics files, and to the
+// document's values for JSON trip documents, which also carry the destination.
+type Input struct {
+	Format        Format         `json:"format" validate:"required,oneof=ics csv json"`
+	Content       string         `json:"content" validate:"required,max=2097152"`
+	Title         *string        `json:"title" validate:"omitempty,min=1,max=255"`
+	Destination   *string        `json:"destination" validate:"omitempty,min=1,max=255"`
+	TimeZone      *string        `json:"timeZone" validate:"omitempty,timezone"`
+	ColumnMapping *ColumnMapping `json:"columnMapping"`
+}
+
+// ColumnMapping names the CSV header of each activity field. Unset fields use the
+// default header names, matched case-insensitively.
+type ColumnMapping struct {
+	Date        *string `json:"date"`
+	Time        *string `json:"time"`
+	EndTime     *string `json:"endTime"`
+	Title       *string `json:"title"`
+	Location    *string `json:"location"`
+	Category    *string `json:"category"`
+	Type        *string `json:"type"`
+	Description *string `json:"description"`
+	Notes       *string `json:"notes"`
+}
+
+// Issue is a validation problem found in the imported file.
+// Line is the 1-based line in the file, or 0 for problems with the file as a whole.
+type Issue struct {
+	Line    int
+	Field   *string
+	Message string
+}
+
+// ActivityPreview is an activity as it will be created, with times in the trip's time zone
+type ActivityPreview struct {
+	Line        int
+	Title       string
+	Type        trip.ActivityType
+	Category    trip.ActivityCategory
+	Time        string
+	EndTime     *string
+	Location    string
+	Description string
+	Notes       string
+}
+
+// DayPreview is an itinerary day as it will be created
+type DayPreview struct {
+	Date       string
+	DayNumber  int
+	Activities []ActivityPreview
+}
+
+// Preview shows the trip an import produces. Nothing is saved unless the preview is valid
+// and the import was committed, in which case TripID is set. Warnings report parts of the
+// file that will be skipped without blocking the import.
+type Preview struct {
+	Valid     bool
+	Committed bool
+	TripID    *uuid.UUID
+	Title     string
+	TimeZone  string
+	StartDate string
+	EndDate   string
+	Days      []DayPreview
+	Errors    []Issue
+	Warnings  []Issue
+}
+
+// event is an entry parsed from a file, before it is mapped to an activity
+type event struct {
+	line         int
+	title        string
+	start        time.Time
+	end          *time.Time
+	location     string
+	description  string
+	notes        string
+	category     string
+	activityType string
+}
+
+// issue builds an Issue for a field of an entry
+func issue(line int, field, message string) Issue {
+	result := Issue{Line: line, Message: message}
+	if field != "" {
+		result.Field = &field
+	}
+	return result
+}
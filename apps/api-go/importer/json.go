@@ -0,0 +1,408 @@
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	appErrors "eztrip/api-go/errors"
+	"eztrip/api-go/logger"
+	"eztrip/api-go/place"
+	"eztrip/api-go/trip"
+	"eztrip/api-go/user"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	activityTypes = map[string]bool{
+		string(trip.ActivityTypePlaceBased): true,
+		string(trip.ActivityTypeCustom):     true,
+		string(trip.ActivityTypeTransport):  true,
+	}
+	transportModes = map[string]bool{
+		string(trip.TransportModeFlight): true,
+		string(trip.TransportModeTrain):  true,
+		string(trip.TransportModeBus):    true,
+		string(trip.TransportModeCar):    true,
+		string(trip.TransportModeFerry):  true,
+		string(trip.TransportModeOther):  true,
+	}
+	ageGroups = map[string]bool{
+		string(trip.AgeGroupAdult):  true,
+		string(trip.AgeGroupSenior): true,
+		string(trip.AgeGroupTeen):   true,
+		string(trip.AgeGroupChild):  true,
+		string(trip.AgeGroupInfant): true,
+	}
+)
+
+// placeRef records where an imported place ID must be written once the place exists
+type placeRef struct {
+	docID  string
+	target **uuid.UUID
+}
+
+// documentImport is a trip built from a JSON document, with the places it needs
+type documentImport struct {
+	trip      *trip.Trip
+	places    map[string]PlaceDocument
+	placeRefs []placeRef
+}
+
+// parseDocument decodes a JSON trip document and checks its format and version
+func parseDocument(content string) (*Document, []Issue) {
+	var doc Document
+	if err := json.Unmarshal([]byte(content), &doc); err != nil {
+		return nil, []Issue{issue(0, "", "Invalid JSON: "+err.Error())}
+	}
+
+	if doc.Format != DocumentFormat {
+		return nil, []Issue{issue(0, "format", fmt.Sprintf("Expected a %q document", DocumentFormat))}
+	}
+	if doc.Version < 1 || doc.Version > DocumentVersion {
+		return nil, []Issue{issue(0, "version", fmt.Sprintf("Unsupported document version %d, expected at most %d", doc.Version, DocumentVersion))}
+	}
+
+	return &doc, nil
+}
+
+// fromDocument builds the trip described by a document with fresh IDs. References between
+// activities, places and travelers are remapped, and users are matched by email among the
+// people who already share a trip with the importer; anyone else is left out with one
+// warning that doesn't tell registered emails apart from unknown ones.
+func (s *Service) fromDocument(ctx context.Context, doc *Document, location *time.Location) (*documentImport, []Issue, []Issue, error) {
+	_, ownerID, err := user.GetAuthenticatedUser(ctx, s.db)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	users, err := s.findCoMembersByEmail(ctx, ownerID, documentEmails(doc))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	d := doc.Trip
+	var issues, warnings []Issue
+
+	t := &trip.Trip{
+		ID:           uuid.New(), // Assigned up front so travelers shared by activities reference the trip
+		Title:        d.Title,
+		Destination:  d.Destination,
+		StartDate:    d.StartDate,
+		EndDate:      d.EndDate,
+		Travelers:    d.TravelerCount,
+		TimeZone:     location.String(),
+		HomeCurrency: strings.ToUpper(d.HomeCurrency),
+		Budget:       d.Budget,
+	}
+	if t.Travelers < 1 {
+		t.Travelers = 1
+	}
+	// Trips without a home currency get the importing user's preferred one when created
+	if t.HomeCurrency != "" && len(t.HomeCurrency) != 3 {
+		issues = append(issues, issue(0, "trip.homeCurrency", "Home currency must be an ISO 4217 code"))
+	}
+	if d.StartDate.IsZero() || d.EndDate.IsZero() || d.EndDate.Before(d.StartDate) {
+		issues = append(issues, issue(0, "trip.endDate", "Trip needs a start date and an end date on or after it"))
+	}
+
+	// Collaborators, including the original owner when someone else imports the trip
+	collaboratorEmails := d.CollaboratorEmails
+	if d.OwnerEmail != "" {
+		collaboratorEmails = append([]string{d.OwnerEmail}, collaboratorEmails...)
+	}
+	seen := map[uuid.UUID]bool{ownerID: true}
+	skipped := 0
+	for _, email := range collaboratorEmails {
+		userID, ok := users[strings.ToLower(email)]
+		if !ok {
+			skipped++
+			continue
+		}
+		if seen[userID] {
+			continue
+		}
+		seen[userID] = true
+		t.Collaborators = append(t.Collaborators, trip.TripCollaborator{UserID: userID})
+	}
+	if skipped > 0 {
+		warnings = append(warnings, issue(0, "trip.collaboratorEmails", fmt.Sprintf(
+			"%d collaborators skipped; only people you already share a trip with are added", skipped,
+		)))
+	}
+
+	places := make(map[string]PlaceDocument, len(d.Places))
+	for i, p := range d.Places {
+		if p.GooglePlaceID == "" || p.Name == "" {
+			issues = append(issues, issue(0, fmt.Sprintf("trip.places[%d]", i), "Places need a Google place ID and a name"))
+			continue
+		}
+		places[p.ID] = p
+	}
+
+	travelers := make(map[string]trip.Traveler, len(d.Travelers))
+	for i, traveler := range d.Travelers {
+		field := fmt.Sprintf("trip.travelers[%d]", i)
+		if strings.TrimSpace(traveler.Name) == "" || !ageGroups[traveler.AgeGroup] {
+			issues = append(issues, issue(0, field, "Travelers need a name and a valid age group"))
+			continue
+		}
+
+		imported := trip.Traveler{
+			ID:       uuid.New(),
+			TripID:   t.ID,
+			Name:     traveler.Name,
+			AgeGroup: trip.AgeGroup(traveler.AgeGroup),
+		}
+		if traveler.UserEmail != nil {
+			if userID, ok := users[strings.ToLower(*traveler.UserEmail)]; ok && (userID == ownerID || seen[userID]) {
+				imported.UserID = &userID
+			} else {
+				warnings = append(warnings, issue(0, field+".userEmail", fmt.Sprintf("%q is not a member of the imported trip, traveler left unlinked", *traveler.UserEmail)))
+			}
+		}
+
+		travelers[traveler.ID] = imported
+		t.TravelerList = append(t.TravelerList, imported)
+	}
+
+	t.Itinerary = make([]trip.ItineraryDay, len(d.Days))
+	for i, day := range d.Days {
+		field := fmt.Sprintf("trip.days[%d]", i)
+		if day.Date.IsZero() {
+			issues = append(issues, issue(0, field+".date", "Day needs a date"))
+		}
+
+		t.Itinerary[i] = trip.ItineraryDay{
+			Date:       day.Date,
+			DayNumber:  day.DayNumber,
+			Activities: make([]trip.Activity, 0, len(day.Activities)),
+		}
+		for j, activity := range day.Activities {
+			imported, activityIssues := fromActivityDocument(activity, fmt.Sprintf("%s.activities[%d]", field, j), places, travelers)
+			issues = append(issues, activityIssues...)
+			t.Itinerary[i].Activities = append(t.Itinerary[i].Activities, imported)
+		}
+	}
+
+	for i, lodging := range d.Lodgings {
+		imported, lodgingIssues := fromLodgingDocument(lodging, fmt.Sprintf("trip.lodgings[%d]", i), places)
+		issues = append(issues, lodgingIssues...)
+		if imported != nil {
+			t.Lodgings = append(t.Lodgings, *imported)
+		}
+	}
+
+	result := &documentImport{trip: t, places: places}
+
+	// Collect place references only after all slices are final, so the pointers stay valid
+	for i := range d.Days {
+		for j, activity := range d.Days[i].Activities {
+			if activity.PlaceID != nil && j < len(t.Itinerary[i].Activities) {
+				result.placeRefs = append(result.placeRefs, placeRef{*activity.PlaceID, &t.Itinerary[i].Activities[j].PlaceID})
+			}
+		}
+	}
+	for i, lodging := range d.Lodgings {
+		if lodging.PlaceID != nil && i < len(t.Lodgings) {
+			result.placeRefs = append(result.placeRefs, placeRef{*lodging.PlaceID, &t.Lodgings[i].PlaceID})
+		}
+	}
+
+	return result, issues, warnings, nil
+}
+
+// fromActivityDocument converts an activity, checking its enums, times and references
+func fromActivityDocument(doc ActivityDocument, field string, places map[string]PlaceDocument, travelers map[string]trip.Traveler) (trip.Activity, []Issue) {
+	var issues []Issue
+
+	activity := trip.Activity{
+		Type:        trip.ActivityType(doc.Type),
+		Time:        doc.Time,
+		EndTime:     doc.EndTime,
+		TimeZone:    doc.TimeZone,
+		Title:       doc.Title,
+		Location:    doc.Location,
+		Category:    trip.ActivityCategory(doc.Category),
+		Description: doc.Description,
+		Notes:       doc.Notes,
+	}
+
+	if strings.TrimSpace(doc.Title) == "" {
+		issues = append(issues, issue(0, field+".title", "Title is required"))
+	}
+	if !activityTypes[doc.Type] {
+		issues = append(issues, issue(0, field+".type", fmt.Sprintf("Unknown activity type %q", doc.Type)))
+	}
+	if !validCategories[activity.Category] {
+		issues = append(issues, issue(0, field+".category", fmt.Sprintf("Unknown category %q", doc.Category)))
+	}
+	if doc.Time.IsZero() {
+		issues = append(issues, issue(0, field+".time", "Activity needs a start time"))
+	} else if doc.EndTime != nil && doc.EndTime.Before(doc.Time) {
+		issues = append(issues, issue(0, field+".endTime", "End time must not be before start time"))
+	}
+	if doc.TimeZone != nil && !trip.IsValidTimeZone(*doc.TimeZone) {
+		issues = append(issues, issue(0, field+".timeZone", fmt.Sprintf("Unknown time zone %q", *doc.TimeZone)))
+	}
+	if doc.PlaceID != nil {
+		if _, ok := places[*doc.PlaceID]; !ok {
+			issues = append(issues, issue(0, field+".placeId", "Place is not included in the document"))
+		}
+	}
+
+	for _, travelerID := range doc.TravelerIDs {
+		traveler, ok := travelers[travelerID]
+		if !ok {
+			issues = append(issues, issue(0, field+".travelerIds", "Traveler is not included in the document"))
+			continue
+		}
+		activity.Travelers = append(activity.Travelers, traveler)
+	}
+
+	if leg := doc.TransportLeg; leg != nil {
+		if !transportModes[leg.Mode] || !trip.IsValidTimeZone(leg.DepartTimeZone) || !trip.IsValidTimeZone(leg.ArriveTimeZone) {
+			issues = append(issues, issue(0, field+".transportLeg", "Transport leg needs a valid mode and time zones"))
+		} else if !leg.ArriveTime.After(leg.DepartTime) {
+			issues = append(issues, issue(0, field+".transportLeg.arriveTime", "Arrival time must be after departure time"))
+		}
+
+		activity.TransportLeg = &trip.TransportLeg{
+			Mode:               trip.TransportMode(leg.Mode),
+			Origin:             leg.Origin,
+			Destination:        leg.Destination,
+			DepartTime:         leg.DepartTime,
+			DepartTimeZone:     leg.DepartTimeZone,
+			ArriveTime:         leg.ArriveTime,
+			ArriveTimeZone:     leg.ArriveTimeZone,
+			Carrier:            leg.Carrier,
+			ConfirmationNumber: leg.ConfirmationNumber,
+			Seat:               leg.Seat,
+		}
+	}
+
+	return activity, issues
+}
+
+// fromLodgingDocument converts a lodging stay, checking its dates and place reference
+func fromLodgingDocument(doc LodgingDocument, field string, places map[string]PlaceDocument) (*trip.Lodging, []Issue) {
+	checkIn, errIn := time.Parse(dateFormat, doc.CheckInDate)
+	checkOut, errOut := time.Parse(dateFormat, doc.CheckOutDate)
+	if errIn != nil || errOut != nil || !checkOut.After(checkIn) {
+		return nil, []Issue{issue(0, field, "Lodging needs YYYY-MM-DD dates with check-out after check-in")}
+	}
+	if strings.TrimSpace(doc.Name) == "" {
+		return nil, []Issue{issue(0, field+".name", "Lodging name is required")}
+	}
+	if doc.PlaceID != nil {
+		if _, ok := places[*doc.PlaceID]; !ok {
+			return nil, []Issue{issue(0, field+".placeId", "Place is not included in the document")}
+		}
+	}
+
+	return &trip.Lodging{
+		Name:               doc.Name,
+		Address:            doc.Address,
+		CheckInDate:        checkIn,
+		CheckOutDate:       checkOut,
+		ConfirmationNumber: doc.ConfirmationNumber,
+		CostAmount:         doc.CostAmount,
+		CostCurrency:       doc.CostCurrency,
+	}, nil
+}
+
+// resolvePlaces reuses places already cached by Google place ID and creates the rest,
+// then points the imported activities and lodgings at them. Places are shared by all
+// users, so new ones are stored by Google place ID only and left stale for the next
+// fetch to fill in, rather than taking their details from the document.
+func (imp *documentImport) resolvePlaces(tx *gorm.DB) error {
+	resolved := make(map[string]uuid.UUID)
+
+	for _, ref := range imp.placeRefs {
+		id, ok := resolved[ref.docID]
+		if !ok {
+			doc := imp.places[ref.docID]
+			p := place.Place{GooglePlaceID: doc.GooglePlaceID}
+			err := tx.Where("google_place_id = ?", doc.GooglePlaceID).
+				FirstOrCreate(&p).Error
+			if err != nil {
+				return err
+			}
+			id = p.ID
+			resolved[ref.docID] = id
+		}
+
+		placeID := id
+		*ref.target = &placeID
+	}
+
+	return nil
+}
+
+// findCoMembersByEmail maps lower-cased emails to user IDs, for the importer and the
+// people who already share a trip with them
+func (s *Service) findCoMembersByEmail(ctx context.Context, importerID uuid.UUID, emails []string) (map[string]uuid.UUID, error) {
+	result := make(map[string]uuid.UUID, len(emails))
+	if len(emails) == 0 {
+		return result, nil
+	}
+
+	memberTrips := s.db.Table("trips").Select("id").Where(
+		"deleted_at IS NULL AND (owner_id = ? OR id IN (?))", importerID,
+		s.db.Table("trip_collaborators").Select("trip_id").Where("user_id = ? AND deleted_at IS NULL", importerID),
+	)
+	owners := s.db.Table("trips").Select("owner_id").Where("id IN (?)", memberTrips)
+	collaborators := s.db.Table("trip_collaborators").Select("user_id").Where("trip_id IN (?) AND deleted_at IS NULL", memberTrips)
+
+	var users []user.User
+	err := s.db.WithContext(ctx).
+		Where("LOWER(email) IN ?", emails).
+		Where("id = ? OR id IN (?) OR id IN (?)", importerID, owners, collaborators).
+		Find(&users).Error
+	if err != nil {
+		logger.Log.WithField("error", err.Error()).Error("Failed to look up users for trip import")
+		return nil, appErrors.Internal("Failed to import trip")
+	}
+
+	for _, u := range users {
+		result[strings.ToLower(u.Email)] = u.ID
+	}
+	return result, nil
+}
+
+// documentEmails lists the lower-cased emails a document refers to
+func documentEmails(doc *Document) []string {
+	var emails []string
+	if doc.Trip.OwnerEmail != "" {
+		emails = append(emails, strings.ToLower(doc.Trip.OwnerEmail))
+	}
+	for _, email := range doc.Trip.CollaboratorEmails {
+		emails = append(emails, strings.ToLower(email))
+	}
+	for _, traveler := range doc.Trip.Travelers {
+		if traveler.UserEmail != nil {
+			emails = append(emails, strings.ToLower(*traveler.UserEmail))
+		}
+	}
+	return emails
+}
+
+// previewDays formats an already built itinerary for the preview
+func previewDays(t *trip.Trip, location *time.Location) []DayPreview {
+	days := make([]DayPreview, len(t.Itinerary))
+	for i, day := range t.Itinerary {
+		days[i] = DayPreview{
+			Date:       trip.FormatDate(day.Date, location),
+			DayNumber:  day.DayNumber,
+			Activities: make([]ActivityPreview, len(day.Activities)),
+		}
+		for j, activity := range day.Activities {
+			days[i].Activities[j] = previewActivity(0, activity, location)
+		}
+	}
+	return days
+}
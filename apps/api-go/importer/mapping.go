@@ -0,0 +1,249 @@
+package importer
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"eztrip/api-go/trip"
+)
+
+// categoryKeywords maps words found in categories and titles to activity categories.
+// Keywords are checked in order, so more specific ones come first.
+var categoryKeywords = []struct {
+	keyword  string
+	category trip.ActivityCategory
+}{
+	{"flight", trip.ActivityCategoryTransport},
+	{"airport", trip.ActivityCategoryTransport},
+	{"train", trip.ActivityCategoryTransport},
+	{"bus", trip.ActivityCategoryTransport},
+	{"ferry", trip.ActivityCategoryTransport},
+	{"drive", trip.ActivityCategoryTransport},
+	{"transfer", trip.ActivityCategoryTransport},
+	{"transport", trip.ActivityCategoryTransport},
+	{"hotel", trip.ActivityCategoryHotel},
+	{"check-in", trip.ActivityCategoryHotel},
+	{"check in", trip.ActivityCategoryHotel},
+	{"lodging", trip.ActivityCategoryHotel},
+	{"breakfast", trip.ActivityCategoryFood},
+	{"brunch", trip.ActivityCategoryFood},
+	{"lunch", trip.ActivityCategoryFood},
+	{"dinner", trip.ActivityCategoryFood},
+	{"restaurant", trip.ActivityCategoryFood},
+	{"dining", trip.ActivityCategoryFood},
+	{"food", trip.ActivityCategoryFood},
+	{"beach", trip.ActivityCategoryBeach},
+	{"hike", trip.ActivityCategoryHike},
+	{"hiking", trip.ActivityCategoryHike},
+	{"trail", trip.ActivityCategoryHike},
+	{"shopping", trip.ActivityCategoryShopping},
+	{"market", trip.ActivityCategoryShopping},
+	{"shop", trip.ActivityCategoryShopping},
+	{"show", trip.ActivityCategoryEntertainment},
+	{"concert", trip.ActivityCategoryEntertainment},
+	{"theater", trip.ActivityCategoryEntertainment},
+	{"theatre", trip.ActivityCategoryEntertainment},
+	{"museum", trip.ActivityCategoryEntertainment},
+	{"entertainment", trip.ActivityCategoryEntertainment},
+}
+
+var validCategories = map[trip.ActivityCategory]bool{
+	trip.ActivityCategoryBeach:         true,
+	trip.ActivityCategoryHike:          true,
+	trip.ActivityCategoryFood:          true,
+	trip.ActivityCategoryHotel:         true,
+	trip.ActivityCategoryActivity:      true,
+	trip.ActivityCategoryTransport:     true,
+	trip.ActivityCategoryShopping:      true,
+	trip.ActivityCategoryEntertainment: true,
+}
+
+// mapCategory resolves a category from the given value, or infers one from the title.
+// Unrecognized explicit values are reported rather than guessed.
+func mapCategory(raw, title string) (trip.ActivityCategory, bool) {
+	value := strings.ToLower(strings.TrimSpace(raw))
+	if value != "" {
+		if validCategories[trip.ActivityCategory(value)] {
+			return trip.ActivityCategory(value), true
+		}
+		if category, ok := matchKeyword(value); ok {
+			return category, true
+		}
+		return "", false
+	}
+
+	if category, ok := matchKeyword(strings.ToLower(title)); ok {
+		return category, true
+	}
+	return trip.ActivityCategoryActivity, true
+}
+
+func matchKeyword(value string) (trip.ActivityCategory, bool) {
+	words := strings.FieldsFunc(value, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r == '-')
+	})
+	joined := " " + strings.Join(words, " ") + " "
+
+	for _, entry := range categoryKeywords {
+		if strings.Contains(joined, " "+entry.keyword+" ") {
+			return entry.category, true
+		}
+	}
+	return "", false
+}
+
+// mapType resolves the activity type. Imported activities aren't linked to places, so
+// only custom and transport activities can be created.
+func mapType(raw string, category trip.ActivityCategory) (trip.ActivityType, bool) {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "":
+		if category == trip.ActivityCategoryTransport {
+			return trip.ActivityTypeTransport, true
+		}
+		return trip.ActivityTypeCustom, true
+	case string(trip.ActivityTypeCustom):
+		return trip.ActivityTypeCustom, true
+	case string(trip.ActivityTypeTransport):
+		return trip.ActivityTypeTransport, true
+	default:
+		return "", false
+	}
+}
+
+// buildTrip validates the parsed entries and groups them into itinerary days by their
+// local date. Days are created for every date in the span so the itinerary has no gaps.
+func buildTrip(title, destination string, location *time.Location, events []event) (*trip.Trip, []DayPreview, []Issue) {
+	type entry struct {
+		line     int
+		activity trip.Activity
+	}
+
+	var issues []Issue
+	entries := make([]entry, 0, len(events))
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].start.Before(events[j].start)
+	})
+
+	for _, e := range events {
+		entryIssues := validateEvent(e)
+		if len(entryIssues) > 0 {
+			issues = append(issues, entryIssues...)
+			continue
+		}
+
+		category, ok := mapCategory(e.category, e.title)
+		if !ok {
+			issues = append(issues, issue(e.line, "category", fmt.Sprintf("Unknown category %q", e.category)))
+			continue
+		}
+
+		activityType, ok := mapType(e.activityType, category)
+		if !ok {
+			issues = append(issues, issue(e.line, "type", fmt.Sprintf("Unknown activity type %q, expected custom or transport", e.activityType)))
+			continue
+		}
+
+		entries = append(entries, entry{
+			line: e.line,
+			activity: trip.Activity{
+				Type:        activityType,
+				Time:        e.start,
+				EndTime:     e.end,
+				Title:       e.title,
+				Location:    e.location,
+				Category:    category,
+				Description: e.description,
+				Notes:       e.notes,
+			},
+		})
+	}
+
+	if len(entries) == 0 {
+		return nil, nil, issues
+	}
+
+	first := startOfDay(entries[0].activity.Time, location)
+	last := startOfDay(entries[len(entries)-1].activity.Time, location)
+
+	dayCount := daysBetween(first, last) + 1
+	if dayCount > maxTripDays {
+		issues = append(issues, issue(0, "", fmt.Sprintf("Activities span %d days, the limit is %d", dayCount, maxTripDays)))
+		return nil, nil, issues
+	}
+
+	days := make([]trip.ItineraryDay, dayCount)
+	previews := make([]DayPreview, dayCount)
+	for i := range days {
+		date := first.AddDate(0, 0, i)
+		days[i] = trip.ItineraryDay{Date: date, DayNumber: i + 1}
+		previews[i] = DayPreview{
+			Date:       trip.FormatDate(date, location),
+			DayNumber:  i + 1,
+			Activities: []ActivityPreview{},
+		}
+	}
+
+	for _, entry := range entries {
+		index := daysBetween(first, startOfDay(entry.activity.Time, location))
+		days[index].Activities = append(days[index].Activities, entry.activity)
+		previews[index].Activities = append(previews[index].Activities, previewActivity(entry.line, entry.activity, location))
+	}
+
+	return &trip.Trip{
+		Title:       title,
+		Destination: destination,
+		StartDate:   first,
+		EndDate:     last,
+		Travelers:   1,
+		TimeZone:    location.String(),
+		Itinerary:   days,
+	}, previews, issues
+}
+
+// previewActivity formats an activity for the preview in the trip's time zone
+func previewActivity(line int, activity trip.Activity, location *time.Location) ActivityPreview {
+	preview := ActivityPreview{
+		Line:        line,
+		Title:       activity.Title,
+		Type:        activity.Type,
+		Category:    activity.Category,
+		Time:        trip.FormatDateTime(activity.Time, location),
+		Location:    activity.Location,
+		Description: activity.Description,
+		Notes:       activity.Notes,
+	}
+	if activity.EndTime != nil {
+		endTime := trip.FormatDateTime(*activity.EndTime, location)
+		preview.EndTime = &endTime
+	}
+	return preview
+}
+
+// validateEvent checks the fields every activity needs
+func validateEvent(e event) []Issue {
+	var issues []Issue
+	if strings.TrimSpace(e.title) == "" {
+		issues = append(issues, issue(e.line, "title", "Title is required"))
+	} else if len(e.title) > maxTitleLength {
+		issues = append(issues, issue(e.line, "title", fmt.Sprintf("Title must be at most %d characters", maxTitleLength)))
+	}
+	if e.end != nil && e.end.Before(e.start) {
+		issues = append(issues, issue(e.line, "endTime", "End time must not be before start time"))
+	}
+	return issues
+}
+
+// daysBetween counts calendar days between two local midnights, tolerating DST shifts
+func daysBetween(from, to time.Time) int {
+	return int(math.Round(to.Sub(from).Hours() / 24))
+}
+
+// startOfDay returns midnight of the instant's local date
+func startOfDay(t time.Time, location *time.Location) time.Time {
+	local := t.In(location)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, location)
+}
@@ -0,0 +1,60 @@
+package importer
+
+import (
+	"context"
+	"encoding/json"
+
+	appErrors "eztrip/api-go/errors"
+	"eztrip/api-go/validation"
+
+	"github.com/google/uuid"
+)
+
+// Resolver handles GraphQL resolver operations for trip imports
+type Resolver struct {
+	Service *Service
+}
+
+// NewResolver creates a new import resolver
+func NewResolver(service *Service) *Resolver {
+	return &Resolver{
+		Service: service,
+	}
+}
+
+// PreviewTripImport validates a file and returns the trip it would create
+func (r *Resolver) PreviewTripImport(ctx context.Context, input Input) (*Preview, error) {
+	if err := validation.ValidateStruct(input); err != nil {
+		return nil, err
+	}
+
+	return r.Service.Preview(ctx, input)
+}
+
+// ImportTrip validates a file and creates the trip when it has no errors
+func (r *Resolver) ImportTrip(ctx context.Context, input Input) (*Preview, error) {
+	if err := validation.ValidateStruct(input); err != nil {
+		return nil, err
+	}
+
+	return r.Service.Import(ctx, input)
+}
+
+// ExportTrip returns the trip as an indented JSON trip document
+func (r *Resolver) ExportTrip(ctx context.Context, tripID string) (string, error) {
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return "", err
+	}
+
+	doc, err := r.Service.Export(ctx, id)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return "", appErrors.Internal("Failed to export trip")
+	}
+	return string(data), nil
+}
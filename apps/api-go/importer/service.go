@@ -0,0 +1,203 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"eztrip/api-go/logger"
+	"eztrip/api-go/trip"
+	"eztrip/api-go/user"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// Service parses trip files and creates trips from them, and exports trips as JSON documents
+type Service struct {
+	db          *gorm.DB
+	tripService *trip.Service
+}
+
+// NewService creates a new import service
+func NewService(db *gorm.DB, tripService *trip.Service) *Service {
+	return &Service{
+		db:          db,
+		tripService: tripService,
+	}
+}
+
+// Preview parses the file and reports the trip it would create, without saving anything
+func (s *Service) Preview(ctx context.Context, input Input) (*Preview, error) {
+	preview, _, _, err := s.prepare(ctx, input)
+	return preview, err
+}
+
+// Import parses the file and, if it has no validation errors, creates the trip with all
+// of its days and activities in one transaction. Invalid files return the preview
+// with its errors and create nothing.
+func (s *Service) Import(ctx context.Context, input Input) (*Preview, error) {
+	preview, t, hooks, err := s.prepare(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	if !preview.Valid {
+		return preview, nil
+	}
+
+	created, err := s.tripService.Create(ctx, t, hooks...)
+	if err != nil {
+		return nil, err
+	}
+
+	preview.Committed = true
+	preview.TripID = &created.ID
+
+	logger.Log.WithFields(logrus.Fields{
+		"trip_id": created.ID,
+		"format":  input.Format,
+		"days":    len(preview.Days),
+	}).Info("Trip imported successfully")
+
+	return preview, nil
+}
+
+// prepare parses the file into a trip and its preview, along with any work that must run
+// in the trip's transaction before it is created
+func (s *Service) prepare(ctx context.Context, input Input) (*Preview, *trip.Trip, []func(tx *gorm.DB) error, error) {
+	preview := &Preview{
+		Days:     []DayPreview{},
+		Errors:   []Issue{},
+		Warnings: []Issue{},
+	}
+
+	title := ""
+	if input.Title != nil {
+		title = strings.TrimSpace(*input.Title)
+	}
+	destination := ""
+	if input.Destination != nil {
+		destination = strings.TrimSpace(*input.Destination)
+	}
+	timeZone := ""
+	if input.TimeZone != nil {
+		timeZone = *input.TimeZone
+	}
+
+	// Files without a time zone are read in the importing user's home time zone
+	current, _, err := user.GetAuthenticatedUser(ctx, s.db)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	defaultTimeZone, _ := trip.DefaultsFor(current)
+
+	if input.Format == FormatJSON {
+		return s.prepareDocument(ctx, preview, input.Content, title, destination, timeZone, defaultTimeZone)
+	}
+
+	var events []event
+	var issues []Issue
+
+	switch input.Format {
+	case FormatICS:
+		cal, parseIssues := parseICS(input.Content)
+		issues = append(issues, parseIssues...)
+		if title == "" {
+			title = cal.name
+		}
+		if timeZone == "" && trip.IsValidTimeZone(cal.timeZone) {
+			timeZone = cal.timeZone
+		}
+		if timeZone == "" {
+			timeZone = defaultTimeZone
+		}
+		var eventIssues []Issue
+		events, eventIssues = cal.toEvents(trip.LoadLocation(timeZone))
+		issues = append(issues, eventIssues...)
+	case FormatCSV:
+		if timeZone == "" {
+			timeZone = defaultTimeZone
+		}
+		var csvIssues []Issue
+		events, csvIssues = parseCSV(input.Content, input.ColumnMapping, trip.LoadLocation(timeZone))
+		issues = append(issues, csvIssues...)
+	}
+
+	if title == "" {
+		issues = append(issues, issue(0, "title", "A trip title is required"))
+	}
+	if destination == "" {
+		issues = append(issues, issue(0, "destination", "A destination is required"))
+	}
+
+	preview.Title = title
+	preview.TimeZone = timeZone
+
+	t, days, buildIssues := buildTrip(title, destination, trip.LoadLocation(timeZone), events)
+	issues = append(issues, buildIssues...)
+
+	if t != nil {
+		preview.StartDate = trip.FormatDate(t.StartDate, trip.LoadLocation(timeZone))
+		preview.EndDate = trip.FormatDate(t.EndDate, trip.LoadLocation(timeZone))
+		preview.Days = days
+	}
+
+	preview.Errors = append(preview.Errors, issues...)
+	preview.Valid = t != nil && len(issues) == 0
+
+	return preview, t, nil, nil
+}
+
+// prepareDocument builds the trip of a JSON trip document. Title, destination and time
+// zone given with the import override the document's values, and documents without a
+// time zone use the default one.
+func (s *Service) prepareDocument(ctx context.Context, preview *Preview, content, title, destination, timeZone, defaultTimeZone string) (*Preview, *trip.Trip, []func(tx *gorm.DB) error, error) {
+	doc, issues := parseDocument(content)
+	if doc == nil {
+		preview.Errors = append(preview.Errors, issues...)
+		return preview, nil, nil, nil
+	}
+
+	if title != "" {
+		doc.Trip.Title = title
+	}
+	if destination != "" {
+		doc.Trip.Destination = destination
+	}
+	if timeZone == "" {
+		timeZone = doc.Trip.TimeZone
+	}
+	if timeZone == "" {
+		timeZone = defaultTimeZone
+	}
+	if !trip.IsValidTimeZone(timeZone) {
+		issues = append(issues, issue(0, "trip.timeZone", fmt.Sprintf("Unknown time zone %q", timeZone)))
+		timeZone = trip.DefaultTimeZone
+	}
+	location := trip.LoadLocation(timeZone)
+
+	imp, docIssues, warnings, err := s.fromDocument(ctx, doc, location)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	issues = append(issues, docIssues...)
+
+	t := imp.trip
+	if strings.TrimSpace(t.Title) == "" || len(t.Title) > maxTitleLength {
+		issues = append(issues, issue(0, "trip.title", "A trip title of at most 255 characters is required"))
+	}
+	if strings.TrimSpace(t.Destination) == "" {
+		issues = append(issues, issue(0, "trip.destination", "A destination is required"))
+	}
+
+	preview.Title = t.Title
+	preview.TimeZone = timeZone
+	preview.StartDate = trip.FormatDate(t.StartDate, location)
+	preview.EndDate = trip.FormatDate(t.EndDate, location)
+	preview.Days = previewDays(t, location)
+	preview.Errors = append(preview.Errors, issues...)
+	preview.Warnings = append(preview.Warnings, warnings...)
+	preview.Valid = len(issues) == 0
+
+	return preview, t, []func(tx *gorm.DB) error{imp.resolvePlaces}, nil
+}
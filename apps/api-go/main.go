@@ -3,11 +3,19 @@ package main
 import (
 	"eztrip/api-go/app"
 	"eztrip/api-go/db"
+	"eztrip/api-go/identity"
 	"eztrip/api-go/logger"
 
+	// Register identity providers
+	_ "eztrip/api-go/auth0"
+	_ "eztrip/api-go/identity/local"
+
 	// Register LLM providers
 	_ "eztrip/api-go/llm/xai"
 
+	// Register currency rate providers
+	_ "eztrip/api-go/currency/file"
+
 	"github.com/gin-gonic/gin"
 )
 
@@ -24,13 +32,20 @@ func main() {
 		logger.Log.Fatalf("Failed to initialize RBAC: %v", err)
 	}
 
+	tokenValidator, err := identity.NewDefaultValidator()
+	if err != nil {
+		logger.Log.Fatalf("Failed to initialize identity provider: %v", err)
+	}
+
 	router := gin.New()
 
-	if err := app.SetupMiddleware(router, database, enforcer); err != nil {
+	if err := app.SetupMiddleware(router, database, enforcer, tokenValidator); err != nil {
 		logger.Log.Fatalf("Failed to configure middleware: %v", err)
 	}
 
-	app.SetupRoutes(router, database)
+	if err := app.SetupRoutes(router, database, tokenValidator); err != nil {
+		logger.Log.Fatalf("Failed to configure routes: %v", err)
+	}
 
 	logger.Log.WithFields(map[string]interface{}{
 		"component": "server",
@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"eztrip/api-go/accesstoken"
+	"eztrip/api-go/identity"
+	"eztrip/api-go/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+var ErrInvalidAccessToken = errors.New("invalid or expired access token")
+
+// AccessTokenMiddleware authenticates bearer tokens that are access tokens, acting as
+// the token's owner. Other requests are passed on to JWTMiddleware, which must come
+// after this middleware in the chain.
+func AccessTokenMiddleware(service *accesstoken.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tokenString := extractBearerToken(c.GetHeader("Authorization"))
+		if !strings.HasPrefix(tokenString, accesstoken.TokenPrefix) {
+			c.Next()
+			return
+		}
+
+		token, owner, err := service.Authenticate(c.Request.Context(), tokenString)
+		if err != nil {
+			logger.Log.WithFields(logrus.Fields{
+				"error":  err.Error(),
+				"path":   c.Request.URL.Path,
+				"method": c.Request.Method,
+			}).Warn("Access token authentication failed")
+			respondWithError(c, http.StatusUnauthorized, ErrInvalidAccessToken)
+			return
+		}
+
+		// The owner's email isn't known to be verified, and token requests skip provisioning
+		claims := &identity.Claims{
+			Subject: *owner.Auth0UserID,
+			Email:   owner.Email,
+		}
+		ctx := context.WithValue(c.Request.Context(), validatedClaimsContextKey{}, claims)
+		c.Request = c.Request.WithContext(accesstoken.WithToken(ctx, token))
+		c.Next()
+	}
+}
+
+// AccessTokenScopeMiddleware requires requests made with an access token to carry the
+// read scope for GET and HEAD requests and the write scope for anything else. GraphQL
+// checks the scope of each operation itself, so it must be skipped.
+func AccessTokenScopeMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		scope := accesstoken.ScopeWrite
+		if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+			scope = accesstoken.ScopeRead
+		}
+
+		if err := accesstoken.RequireScope(c.Request.Context(), scope); err != nil {
+			logger.Log.WithFields(logrus.Fields{
+				"scope":  scope,
+				"path":   c.Request.URL.Path,
+				"method": c.Request.Method,
+			}).Warn("Access token is missing a required scope")
+			c.JSON(http.StatusForbidden, gin.H{
+				"error":   "insufficient_scope",
+				"message": err.Error(),
+			})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,17 @@
+package middleware
+
+import (
+	"eztrip/api-go/dataloader"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DataLoaderMiddleware gives every request its own dataloader store, so GraphQL field
+// resolvers batch their lookups within the request without sharing cached results
+func DataLoaderMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := dataloader.WithStore(c.Request.Context())
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
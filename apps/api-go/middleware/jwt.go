@@ -0,0 +1,104 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"eztrip/api-go/identity"
+	"eztrip/api-go/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	ErrMissingToken    = errors.New("missing authorization token")
+	ErrInvalidToken    = errors.New("invalid token")
+	ErrMissingSubClaim = errors.New("token missing sub claim")
+	ErrUserNotFound    = errors.New("user not found in database")
+)
+
+type validatedClaimsContextKey struct{}
+
+// claimsFromContext returns the claims of the request's validated token, or nil for
+// unauthenticated requests.
+func claimsFromContext(ctx context.Context) *identity.Claims {
+	claims, _ := ctx.Value(validatedClaimsContextKey{}).(*identity.Claims)
+	return claims
+}
+
+// extractSubjectFromClaims extracts and validates the subject claim from validated token.
+func extractSubjectFromClaims(claims *identity.Claims) (string, error) {
+	if claims == nil {
+		return "", ErrInvalidToken
+	}
+
+	userID := strings.TrimSpace(claims.Subject)
+	if userID == "" {
+		return "", ErrMissingSubClaim
+	}
+
+	return userID, nil
+}
+
+// JWTMiddleware creates a Gin middleware that validates bearer tokens with the identity
+// provider. Requests already authenticated by AccessTokenMiddleware are passed through.
+func JWTMiddleware(tokenValidator identity.TokenValidator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method == http.MethodOptions || claimsFromContext(c.Request.Context()) != nil {
+			c.Next()
+			return
+		}
+
+		tokenString := extractBearerToken(c.GetHeader("Authorization"))
+		if tokenString == "" {
+			respondWithError(c, http.StatusUnauthorized, ErrMissingToken)
+			return
+		}
+
+		claims, err := tokenValidator.ValidateToken(c.Request.Context(), tokenString)
+		if err != nil {
+			logTokenValidationError(c, err)
+			respondWithError(c, http.StatusUnauthorized, ErrInvalidToken)
+			return
+		}
+
+		c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), validatedClaimsContextKey{}, claims))
+		c.Next()
+	}
+}
+
+// respondWithError sends a standardized error response.
+func respondWithError(c *gin.Context, statusCode int, err error) {
+	c.JSON(statusCode, gin.H{
+		"error":   "unauthorized",
+		"message": err.Error(),
+	})
+	c.Abort()
+}
+
+// logTokenValidationError logs token validation failures.
+func logTokenValidationError(c *gin.Context, err error) {
+	logger.Log.WithFields(logrus.Fields{
+		"error":  err.Error(),
+		"path":   c.Request.URL.Path,
+		"method": c.Request.Method,
+	}).Warn("JWT validation failed")
+}
+
+func extractBearerToken(authHeader string) string {
+	authHeader = strings.TrimSpace(authHeader)
+	if authHeader == "" {
+		return ""
+	}
+	parts := strings.SplitN(authHeader, " ", 2)
+	if len(parts) != 2 {
+		return ""
+	}
+	if !strings.EqualFold(parts[0], "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(parts[1])
+}
@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SkipForPathPrefixes runs the handler for every request except those under the given
+// path prefixes, so routes that carry their own credentials can bypass it
+func SkipForPathPrefixes(handler gin.HandlerFunc, prefixes ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(c.Request.URL.Path, prefix) {
+				c.Next()
+				return
+			}
+		}
+		handler(c)
+	}
+}
@@ -14,7 +14,7 @@ import (
 
 // RBACMiddleware creates a Gin middleware that injects the Casbin enforcer into the context.
 // The enforcer automatically loads policies and role assignments from the database.
-func RBACMiddleware(enforcer *casbin.Enforcer) gin.HandlerFunc {
+func RBACMiddleware(enforcer *casbin.SyncedEnforcer) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		ctx := rbac.SetEnforcerInContext(c.Request.Context(), enforcer)
 		c.Request = c.Request.WithContext(ctx)
@@ -1,24 +1,28 @@
 package middleware
 
 import (
+	"eztrip/api-go/accesstoken"
+	"eztrip/api-go/identity"
 	"eztrip/api-go/logger"
 	"eztrip/api-go/user"
 
+	"github.com/casbin/casbin/v2"
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
 )
 
 // UserLookupMiddleware creates middleware that extracts Auth0 ID from JWT and stores it in context.
-// This must be placed after Auth0JWTMiddleware in the middleware chain.
-func UserLookupMiddleware(userService *user.Service) gin.HandlerFunc {
+// Users are provisioned from the token claims on their first authenticated request.
+// This must be placed after JWTMiddleware in the middleware chain.
+func UserLookupMiddleware(userService *user.Service, enforcer *casbin.SyncedEnforcer) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		validated := c.Request.Context().Value(validatedClaimsContextKey{})
-		if validated == nil {
+		claims := claimsFromContext(c.Request.Context())
+		if claims == nil {
 			c.Next()
 			return
 		}
 
-		auth0ID, err := extractSubjectFromClaims(validated)
+		auth0ID, err := extractSubjectFromClaims(claims)
 		if err != nil {
 			logger.Log.WithFields(logrus.Fields{
 				"error": err.Error(),
@@ -27,7 +31,32 @@ func UserLookupMiddleware(userService *user.Service) gin.HandlerFunc {
 			return
 		}
 
+		// Access tokens belong to existing users, and their claims don't prove a verified email.
+		// Other requests look the user up by Auth0 ID every time rather than caching it, so
+		// every instance sees deleted users at once.
+		if accesstoken.FromContext(c.Request.Context()) == nil {
+			// Failures leave the request unprovisioned; resolvers report the user as not found
+			if _, err := userService.Provision(c.Request.Context(), enforcer, profileFromClaims(claims, auth0ID)); err != nil {
+				logger.Log.WithFields(logrus.Fields{
+					"auth0_id": auth0ID,
+					"error":    err.Error(),
+				}).Warn("Failed to provision user")
+			}
+		}
+
 		c.Request = c.Request.WithContext(user.SetUserAuth0ID(c.Request.Context(), auth0ID))
 		c.Next()
 	}
 }
+
+// profileFromClaims reads the profile claims of a validated token
+func profileFromClaims(claims *identity.Claims, auth0ID string) user.Profile {
+	return user.Profile{
+		Auth0ID:       auth0ID,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+		Name:          claims.Name,
+		FirstName:     claims.GivenName,
+		LastName:      claims.FamilyName,
+	}
+}
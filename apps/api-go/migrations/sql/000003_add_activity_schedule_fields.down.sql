@@ -0,0 +1,3 @@
+ALTER TABLE places DROP COLUMN IF EXISTS longitude;
+ALTER TABLE places DROP COLUMN IF EXISTS latitude;
+ALTER TABLE activities DROP COLUMN IF EXISTS end_time;
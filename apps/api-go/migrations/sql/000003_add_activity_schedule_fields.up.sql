@@ -0,0 +1,6 @@
+-- Add end time to activities so durations and overlaps can be computed
+ALTER TABLE activities ADD COLUMN IF NOT EXISTS end_time TIMESTAMP;
+
+-- Add coordinates to places for travel time estimates between activities
+ALTER TABLE places ADD COLUMN IF NOT EXISTS latitude DOUBLE PRECISION;
+ALTER TABLE places ADD COLUMN IF NOT EXISTS longitude DOUBLE PRECISION;
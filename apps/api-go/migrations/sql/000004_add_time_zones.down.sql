@@ -0,0 +1,13 @@
+ALTER TABLE activities
+    ALTER COLUMN end_time TYPE TIMESTAMP USING end_time AT TIME ZONE 'UTC',
+    ALTER COLUMN time TYPE TIMESTAMP USING time AT TIME ZONE 'UTC';
+
+ALTER TABLE itinerary_days
+    ALTER COLUMN date TYPE TIMESTAMP USING date AT TIME ZONE 'UTC';
+
+ALTER TABLE trips
+    ALTER COLUMN end_date TYPE TIMESTAMP USING end_date AT TIME ZONE 'UTC',
+    ALTER COLUMN start_date TYPE TIMESTAMP USING start_date AT TIME ZONE 'UTC';
+
+ALTER TABLE activities DROP COLUMN IF EXISTS time_zone;
+ALTER TABLE trips DROP COLUMN IF EXISTS time_zone;
@@ -0,0 +1,16 @@
+-- Store an IANA time zone per trip, with an optional override per activity for multi-city trips
+ALTER TABLE trips ADD COLUMN IF NOT EXISTS time_zone VARCHAR(64) NOT NULL DEFAULT 'UTC';
+ALTER TABLE activities ADD COLUMN IF NOT EXISTS time_zone VARCHAR(64);
+
+-- Convert zone-less timestamps to timestamptz.
+-- Existing values were written as UTC wall times, which matches the default trip time zone.
+ALTER TABLE trips
+    ALTER COLUMN start_date TYPE TIMESTAMPTZ USING start_date AT TIME ZONE 'UTC',
+    ALTER COLUMN end_date TYPE TIMESTAMPTZ USING end_date AT TIME ZONE 'UTC';
+
+ALTER TABLE itinerary_days
+    ALTER COLUMN date TYPE TIMESTAMPTZ USING date AT TIME ZONE 'UTC';
+
+ALTER TABLE activities
+    ALTER COLUMN time TYPE TIMESTAMPTZ USING time AT TIME ZONE 'UTC',
+    ALTER COLUMN end_time TYPE TIMESTAMPTZ USING end_time AT TIME ZONE 'UTC';
@@ -0,0 +1,26 @@
+-- Create transport_legs table holding booking details for transport activities
+CREATE TABLE IF NOT EXISTS transport_legs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    activity_id UUID NOT NULL,
+    mode VARCHAR(50) NOT NULL,
+    origin VARCHAR(255) NOT NULL,
+    destination VARCHAR(255) NOT NULL,
+    depart_time TIMESTAMPTZ NOT NULL,
+    depart_time_zone VARCHAR(64) NOT NULL,
+    arrive_time TIMESTAMPTZ NOT NULL,
+    arrive_time_zone VARCHAR(64) NOT NULL,
+    carrier VARCHAR(255),
+    confirmation_number VARCHAR(100),
+    seat VARCHAR(50),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP,
+    CONSTRAINT fk_transport_legs_activity FOREIGN KEY (activity_id) REFERENCES activities(id) ON DELETE CASCADE,
+    CONSTRAINT chk_transport_legs_arrival_after_departure CHECK (arrive_time > depart_time)
+);
+
+-- Create indexes
+CREATE INDEX idx_transport_legs_deleted_at ON transport_legs(deleted_at);
+
+-- Each activity has at most one active transport leg
+CREATE UNIQUE INDEX idx_transport_legs_activity_id ON transport_legs(activity_id) WHERE deleted_at IS NULL;
@@ -0,0 +1,24 @@
+-- Create lodgings table for stays spanning one or more nights of a trip
+CREATE TABLE IF NOT EXISTS lodgings (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    trip_id UUID NOT NULL,
+    place_id UUID,
+    name VARCHAR(255) NOT NULL,
+    address TEXT,
+    check_in_date DATE NOT NULL,
+    check_out_date DATE NOT NULL,
+    confirmation_number VARCHAR(100),
+    cost_amount DECIMAL(12, 2),
+    cost_currency CHAR(3),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP,
+    CONSTRAINT fk_lodgings_trip FOREIGN KEY (trip_id) REFERENCES trips(id) ON DELETE CASCADE,
+    CONSTRAINT fk_lodgings_place FOREIGN KEY (place_id) REFERENCES places(id) ON DELETE SET NULL,
+    CONSTRAINT chk_lodgings_check_out_after_check_in CHECK (check_out_date > check_in_date)
+);
+
+-- Create indexes
+CREATE INDEX idx_lodgings_trip_id ON lodgings(trip_id);
+CREATE INDEX idx_lodgings_place_id ON lodgings(place_id);
+CREATE INDEX idx_lodgings_deleted_at ON lodgings(deleted_at);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS expenses;
+
+ALTER TABLE trips DROP COLUMN IF EXISTS budget;
+ALTER TABLE trips DROP COLUMN IF EXISTS home_currency;
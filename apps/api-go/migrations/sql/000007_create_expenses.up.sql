@@ -0,0 +1,29 @@
+-- Add budget settings to trips
+ALTER TABLE trips ADD COLUMN IF NOT EXISTS home_currency CHAR(3) NOT NULL DEFAULT 'USD';
+ALTER TABLE trips ADD COLUMN IF NOT EXISTS budget DECIMAL(12, 2);
+
+-- Create expenses table
+CREATE TABLE IF NOT EXISTS expenses (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    trip_id UUID NOT NULL,
+    activity_id UUID,
+    payer_id UUID NOT NULL,
+    amount DECIMAL(12, 2) NOT NULL,
+    currency CHAR(3) NOT NULL,
+    category VARCHAR(50) NOT NULL,
+    date DATE NOT NULL,
+    description TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP,
+    CONSTRAINT fk_expenses_trip FOREIGN KEY (trip_id) REFERENCES trips(id) ON DELETE CASCADE,
+    CONSTRAINT fk_expenses_activity FOREIGN KEY (activity_id) REFERENCES activities(id) ON DELETE SET NULL,
+    CONSTRAINT fk_expenses_payer FOREIGN KEY (payer_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT chk_expenses_amount_positive CHECK (amount > 0)
+);
+
+-- Create indexes
+CREATE INDEX idx_expenses_trip_id ON expenses(trip_id);
+CREATE INDEX idx_expenses_activity_id ON expenses(activity_id);
+CREATE INDEX idx_expenses_payer_id ON expenses(payer_id);
+CREATE INDEX idx_expenses_deleted_at ON expenses(deleted_at);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS settlements;
+DROP TABLE IF EXISTS expense_shares;
+
+ALTER TABLE expenses DROP COLUMN IF EXISTS split_method;
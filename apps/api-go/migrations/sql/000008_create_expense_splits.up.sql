@@ -0,0 +1,50 @@
+-- Record how each expense is divided among trip members
+ALTER TABLE expenses ADD COLUMN IF NOT EXISTS split_method VARCHAR(20) NOT NULL DEFAULT 'equal';
+
+-- Create expense shares table for each participant's portion of an expense
+CREATE TABLE IF NOT EXISTS expense_shares (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    expense_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    shares DECIMAL(12, 4),
+    amount DECIMAL(12, 2) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP,
+    CONSTRAINT fk_expense_shares_expense FOREIGN KEY (expense_id) REFERENCES expenses(id) ON DELETE CASCADE,
+    CONSTRAINT fk_expense_shares_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT chk_expense_shares_amount_non_negative CHECK (amount >= 0)
+);
+
+-- Existing expenses are owed entirely by their payer, leaving balances unchanged
+INSERT INTO expense_shares (expense_id, user_id, amount)
+SELECT id, payer_id, amount FROM expenses WHERE deleted_at IS NULL;
+
+-- Create settlements table for payments between trip members
+CREATE TABLE IF NOT EXISTS settlements (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    trip_id UUID NOT NULL,
+    from_user_id UUID NOT NULL,
+    to_user_id UUID NOT NULL,
+    amount DECIMAL(12, 2) NOT NULL,
+    currency CHAR(3) NOT NULL,
+    date DATE NOT NULL,
+    note TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP,
+    CONSTRAINT fk_settlements_trip FOREIGN KEY (trip_id) REFERENCES trips(id) ON DELETE CASCADE,
+    CONSTRAINT fk_settlements_from_user FOREIGN KEY (from_user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_settlements_to_user FOREIGN KEY (to_user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT chk_settlements_amount_positive CHECK (amount > 0),
+    CONSTRAINT chk_settlements_different_users CHECK (from_user_id <> to_user_id)
+);
+
+-- Create indexes
+CREATE INDEX idx_expense_shares_expense_id ON expense_shares(expense_id);
+CREATE INDEX idx_expense_shares_user_id ON expense_shares(user_id);
+CREATE INDEX idx_expense_shares_deleted_at ON expense_shares(deleted_at);
+CREATE INDEX idx_settlements_trip_id ON settlements(trip_id);
+CREATE INDEX idx_settlements_from_user_id ON settlements(from_user_id);
+CREATE INDEX idx_settlements_to_user_id ON settlements(to_user_id);
+CREATE INDEX idx_settlements_deleted_at ON settlements(deleted_at);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS expense_travelers;
+DROP TABLE IF EXISTS activity_travelers;
+DROP TABLE IF EXISTS travelers;
@@ -0,0 +1,39 @@
+-- Create travelers table for named people on a trip, with or without an account
+CREATE TABLE IF NOT EXISTS travelers (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    trip_id UUID NOT NULL,
+    user_id UUID,
+    name VARCHAR(255) NOT NULL,
+    age_group VARCHAR(20) NOT NULL DEFAULT 'adult',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP,
+    CONSTRAINT fk_travelers_trip FOREIGN KEY (trip_id) REFERENCES trips(id) ON DELETE CASCADE,
+    CONSTRAINT fk_travelers_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE SET NULL
+);
+
+-- Assign travelers to the activities they take part in
+CREATE TABLE IF NOT EXISTS activity_travelers (
+    activity_id UUID NOT NULL,
+    traveler_id UUID NOT NULL,
+    PRIMARY KEY (activity_id, traveler_id),
+    CONSTRAINT fk_activity_travelers_activity FOREIGN KEY (activity_id) REFERENCES activities(id) ON DELETE CASCADE,
+    CONSTRAINT fk_activity_travelers_traveler FOREIGN KEY (traveler_id) REFERENCES travelers(id) ON DELETE CASCADE
+);
+
+-- Assign travelers to the expenses made for them
+CREATE TABLE IF NOT EXISTS expense_travelers (
+    expense_id UUID NOT NULL,
+    traveler_id UUID NOT NULL,
+    PRIMARY KEY (expense_id, traveler_id),
+    CONSTRAINT fk_expense_travelers_expense FOREIGN KEY (expense_id) REFERENCES expenses(id) ON DELETE CASCADE,
+    CONSTRAINT fk_expense_travelers_traveler FOREIGN KEY (traveler_id) REFERENCES travelers(id) ON DELETE CASCADE
+);
+
+-- Create indexes
+CREATE INDEX idx_travelers_trip_id ON travelers(trip_id);
+CREATE INDEX idx_travelers_user_id ON travelers(user_id);
+CREATE INDEX idx_travelers_deleted_at ON travelers(deleted_at);
+CREATE UNIQUE INDEX idx_travelers_trip_user ON travelers(trip_id, user_id) WHERE user_id IS NOT NULL AND deleted_at IS NULL;
+CREATE INDEX idx_activity_travelers_traveler_id ON activity_travelers(traveler_id);
+CREATE INDEX idx_expense_travelers_traveler_id ON expense_travelers(traveler_id);
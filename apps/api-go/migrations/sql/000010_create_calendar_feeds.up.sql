@@ -0,0 +1,19 @@
+-- Create calendar feeds table for secret-token calendar subscriptions
+CREATE TABLE IF NOT EXISTS calendar_feeds (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    trip_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    token_hash CHAR(64) NOT NULL,
+    last_accessed_at TIMESTAMPTZ,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP,
+    CONSTRAINT fk_calendar_feeds_trip FOREIGN KEY (trip_id) REFERENCES trips(id) ON DELETE CASCADE,
+    CONSTRAINT fk_calendar_feeds_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Create indexes
+CREATE UNIQUE INDEX idx_calendar_feeds_token_hash ON calendar_feeds(token_hash);
+CREATE UNIQUE INDEX idx_calendar_feeds_trip_user ON calendar_feeds(trip_id, user_id) WHERE deleted_at IS NULL;
+CREATE INDEX idx_calendar_feeds_user_id ON calendar_feeds(user_id);
+CREATE INDEX idx_calendar_feeds_deleted_at ON calendar_feeds(deleted_at);
@@ -0,0 +1,22 @@
+-- Create share links table for public read-only trip views
+CREATE TABLE IF NOT EXISTS share_links (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    trip_id UUID NOT NULL,
+    created_by UUID NOT NULL,
+    token_hash CHAR(64) NOT NULL,
+    label VARCHAR(255),
+    password_hash VARCHAR(255),
+    expires_at TIMESTAMPTZ,
+    last_accessed_at TIMESTAMPTZ,
+    access_count INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP,
+    CONSTRAINT fk_share_links_trip FOREIGN KEY (trip_id) REFERENCES trips(id) ON DELETE CASCADE,
+    CONSTRAINT fk_share_links_created_by FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Create indexes
+CREATE UNIQUE INDEX idx_share_links_token_hash ON share_links(token_hash);
+CREATE INDEX idx_share_links_trip_id ON share_links(trip_id);
+CREATE INDEX idx_share_links_deleted_at ON share_links(deleted_at);
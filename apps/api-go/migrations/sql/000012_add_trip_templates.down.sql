@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_trips_cloned_from_id;
+DROP INDEX IF EXISTS idx_trips_is_template;
+
+ALTER TABLE trips DROP CONSTRAINT IF EXISTS fk_trips_cloned_from;
+ALTER TABLE trips DROP COLUMN IF EXISTS cloned_from_id;
+ALTER TABLE trips DROP COLUMN IF EXISTS is_template;
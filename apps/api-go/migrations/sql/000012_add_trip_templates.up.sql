@@ -0,0 +1,9 @@
+-- Add template flag and clone origin to trips
+ALTER TABLE trips ADD COLUMN IF NOT EXISTS is_template BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE trips ADD COLUMN IF NOT EXISTS cloned_from_id UUID;
+
+ALTER TABLE trips ADD CONSTRAINT fk_trips_cloned_from FOREIGN KEY (cloned_from_id) REFERENCES trips(id) ON DELETE SET NULL;
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_trips_is_template ON trips(destination) WHERE is_template AND deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_trips_cloned_from_id ON trips(cloned_from_id);
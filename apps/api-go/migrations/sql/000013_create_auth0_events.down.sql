@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_auth0_events_source_occurred_at;
+DROP INDEX IF EXISTS idx_auth0_events_status;
+DROP TABLE IF EXISTS auth0_events;
+
+ALTER TABLE users DROP COLUMN IF EXISTS blocked_at;
@@ -0,0 +1,20 @@
+-- Track users blocked in Auth0
+ALTER TABLE users ADD COLUMN IF NOT EXISTS blocked_at TIMESTAMPTZ;
+
+-- Create table of received Auth0 user lifecycle events, keyed by event ID so deliveries are applied once
+CREATE TABLE IF NOT EXISTS auth0_events (
+    event_id VARCHAR(255) PRIMARY KEY,
+    source VARCHAR(20) NOT NULL,
+    type VARCHAR(100) NOT NULL,
+    auth0_user_id VARCHAR(255),
+    payload JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    error TEXT,
+    occurred_at TIMESTAMPTZ,
+    received_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    processed_at TIMESTAMPTZ
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_auth0_events_status ON auth0_events(status);
+CREATE INDEX IF NOT EXISTS idx_auth0_events_source_occurred_at ON auth0_events(source, occurred_at);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_access_tokens_deleted_at;
+DROP INDEX IF EXISTS idx_access_tokens_user_id;
+DROP INDEX IF EXISTS idx_access_tokens_token_hash;
+DROP TABLE IF EXISTS access_tokens;
+
+ALTER TABLE users DROP COLUMN IF EXISTS service_account;
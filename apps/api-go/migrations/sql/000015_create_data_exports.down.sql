@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_data_exports_expires_at;
+DROP INDEX IF EXISTS idx_data_exports_user_id;
+DROP INDEX IF EXISTS idx_data_exports_token_hash;
+DROP TABLE IF EXISTS data_exports;
+
+DROP INDEX IF EXISTS idx_users_erasure_due_at;
+ALTER TABLE users DROP COLUMN IF EXISTS erased_at;
+ALTER TABLE users DROP COLUMN IF EXISTS erasure_due_at;
+ALTER TABLE users DROP COLUMN IF EXISTS erasure_requested_at;
@@ -0,0 +1,25 @@
+-- Track account erasure requests; erased users are kept, anonymized, so other members'
+-- expenses and settlements still add up
+ALTER TABLE users ADD COLUMN IF NOT EXISTS erasure_requested_at TIMESTAMPTZ;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS erasure_due_at TIMESTAMPTZ;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS erased_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_users_erasure_due_at ON users(erasure_due_at) WHERE erasure_due_at IS NOT NULL AND erased_at IS NULL;
+
+-- Create data exports table for downloadable archives of a user's personal data
+CREATE TABLE IF NOT EXISTS data_exports (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    token_hash CHAR(64) NOT NULL,
+    archive BYTEA NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    downloaded_at TIMESTAMPTZ,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP,
+    CONSTRAINT fk_data_exports_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX idx_data_exports_token_hash ON data_exports(token_hash);
+CREATE INDEX idx_data_exports_user_id ON data_exports(user_id);
+CREATE INDEX idx_data_exports_expires_at ON data_exports(expires_at);
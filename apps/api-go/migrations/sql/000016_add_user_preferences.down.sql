@@ -0,0 +1,8 @@
+ALTER TABLE users DROP COLUMN IF EXISTS travel_interests;
+ALTER TABLE users DROP COLUMN IF EXISTS accessibility_needs;
+ALTER TABLE users DROP COLUMN IF EXISTS dietary_needs;
+ALTER TABLE users DROP COLUMN IF EXISTS language;
+ALTER TABLE users DROP COLUMN IF EXISTS units;
+ALTER TABLE users DROP COLUMN IF EXISTS preferred_currency;
+ALTER TABLE users DROP COLUMN IF EXISTS home_time_zone;
+ALTER TABLE users DROP COLUMN IF EXISTS home_city;
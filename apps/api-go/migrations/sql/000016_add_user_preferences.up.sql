@@ -0,0 +1,9 @@
+-- Optional profile details used to personalize AI suggestions and new trips
+ALTER TABLE users ADD COLUMN IF NOT EXISTS home_city VARCHAR(100);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS home_time_zone VARCHAR(64);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS preferred_currency CHAR(3);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS units VARCHAR(10);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS language VARCHAR(35);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS dietary_needs JSONB NOT NULL DEFAULT '[]';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS accessibility_needs JSONB NOT NULL DEFAULT '[]';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS travel_interests JSONB NOT NULL DEFAULT '[]';
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_rbac_audit_log_actor_id;
+DROP INDEX IF EXISTS idx_rbac_audit_log_created_at;
+DROP TABLE IF EXISTS rbac_audit_log;
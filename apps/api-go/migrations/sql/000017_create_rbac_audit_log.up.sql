@@ -0,0 +1,17 @@
+-- Create audit log of changes to RBAC policies and role assignments
+CREATE TABLE IF NOT EXISTS rbac_audit_log (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    actor_id UUID,
+    change VARCHAR(20) NOT NULL,
+    role VARCHAR(100) NOT NULL,
+    resource VARCHAR(100),
+    action VARCHAR(100),
+    user_id UUID,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_rbac_audit_log_actor FOREIGN KEY (actor_id) REFERENCES users(id) ON DELETE SET NULL,
+    CONSTRAINT fk_rbac_audit_log_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE SET NULL
+);
+
+-- Create indexes
+CREATE INDEX idx_rbac_audit_log_created_at ON rbac_audit_log(created_at);
+CREATE INDEX idx_rbac_audit_log_actor_id ON rbac_audit_log(actor_id);
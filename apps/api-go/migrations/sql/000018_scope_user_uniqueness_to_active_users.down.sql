@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_users_auth0_user_id_active;
+DROP INDEX IF EXISTS idx_users_email_active;
+
+ALTER TABLE users ADD CONSTRAINT users_auth0_user_id_key UNIQUE (auth0_user_id);
+ALTER TABLE users ADD CONSTRAINT users_email_key UNIQUE (email);
@@ -0,0 +1,7 @@
+-- Deleted users keep their rows, so only active users need a unique email and
+-- identity provider account; someone whose account was deleted can sign up again
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_email_key;
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_auth0_user_id_key;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email_active ON users(email) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_auth0_user_id_active ON users(auth0_user_id) WHERE deleted_at IS NULL;
@@ -0,0 +1,224 @@
+// Package pagination implements Relay-style cursor pagination over GORM queries.
+//
+// Pages are ordered by a sort column with the primary key as a tie-breaker, and cursors
+// encode both values so results stay stable while rows are added or removed.
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	appErrors "eztrip/api-go/errors"
+	"eztrip/api-go/logger"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// Direction is the sort direction of a page
+type Direction string
+
+const (
+	Asc  Direction = "asc"
+	Desc Direction = "desc"
+)
+
+// Kind tells how a sort value is restored from a cursor
+type Kind int
+
+const (
+	KindString Kind = iota
+	KindTime
+)
+
+// Order is a sort column. Column must come from a fixed list, never from user input.
+type Order struct {
+	Column    string
+	Kind      Kind
+	Direction Direction
+}
+
+// Params are the Relay forward pagination arguments
+type Params struct {
+	First *int32
+	After *string
+}
+
+// PageInfo describes where a page sits in the full result
+type PageInfo struct {
+	HasNextPage     bool
+	HasPreviousPage bool
+	StartCursor     *string
+	EndCursor       *string
+}
+
+// Page is a slice of results with a cursor for each item
+type Page[T any] struct {
+	Items      []T
+	Cursors    []string
+	PageInfo   PageInfo
+	TotalCount int64
+}
+
+// Key returns the value of the sort column and the ID of a row, used to build its cursor
+type Key[T any] func(item *T) (any, uuid.UUID)
+
+// cursor is the decoded form of an opaque cursor
+type cursor struct {
+	Order string    `json:"o"`
+	Value any       `json:"v"`
+	ID    uuid.UUID `json:"id"`
+}
+
+// PageSize returns the number of items a page holds for the first argument
+func PageSize(first *int32) int {
+	if first == nil {
+		return DefaultPageSize
+	}
+	return min(max(int(*first), 0), MaxPageSize)
+}
+
+// Paginate runs a filtered query one page at a time. The query must not have an order,
+// limit or preloads yet; preload, if given, is applied only when fetching the page so
+// the total count stays a plain COUNT. Invalid arguments return validation errors and
+// database failures are logged and returned as internal errors.
+func Paginate[T any](query *gorm.DB, params Params, order Order, key Key[T], preload func(*gorm.DB) *gorm.DB) (*Page[T], error) {
+	if params.First != nil && *params.First < 0 {
+		return nil, appErrors.ValidationError("first", "first must not be negative")
+	}
+	size := PageSize(params.First)
+
+	var after *cursor
+	if params.After != nil {
+		decoded, err := decodeCursor(*params.After, order)
+		if err != nil {
+			return nil, err
+		}
+		after = decoded
+	}
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Model(new(T)).Count(&total).Error; err != nil {
+		return nil, queryFailed(order, err)
+	}
+
+	idColumn := qualifiedID(query, new(T))
+
+	pageQuery := query.Session(&gorm.Session{})
+	if after != nil {
+		comparison := ">"
+		if order.Direction == Desc {
+			comparison = "<"
+		}
+		pageQuery = pageQuery.Where(
+			fmt.Sprintf("(%s, %s) %s (?, ?)", order.Column, idColumn, comparison),
+			after.Value, after.ID,
+		)
+	}
+
+	if preload != nil {
+		pageQuery = preload(pageQuery)
+	}
+
+	var items []T
+	err := pageQuery.
+		Order(fmt.Sprintf("%s %s, %s %s", order.Column, order.Direction, idColumn, order.Direction)).
+		Limit(size + 1).
+		Find(&items).Error
+	if err != nil {
+		return nil, queryFailed(order, err)
+	}
+
+	page := &Page[T]{
+		TotalCount: total,
+		PageInfo: PageInfo{
+			HasNextPage:     len(items) > size,
+			HasPreviousPage: params.After != nil,
+		},
+	}
+	if len(items) > size {
+		items = items[:size]
+	}
+
+	page.Items = items
+	page.Cursors = make([]string, len(items))
+	for i := range items {
+		value, id := key(&items[i])
+		page.Cursors[i] = encodeCursor(order, value, id)
+	}
+	if len(items) > 0 {
+		page.PageInfo.StartCursor = &page.Cursors[0]
+		page.PageInfo.EndCursor = &page.Cursors[len(items)-1]
+	}
+
+	return page, nil
+}
+
+func queryFailed(order Order, err error) error {
+	logger.Log.WithFields(logrus.Fields{
+		"order": order.signature(),
+		"error": err.Error(),
+	}).Error("Failed to fetch page")
+	return appErrors.Internal("Failed to fetch results")
+}
+
+// signature identifies an order so cursors can't be reused with a different sort
+func (o Order) signature() string {
+	return o.Column + " " + string(o.Direction)
+}
+
+func encodeCursor(order Order, value any, id uuid.UUID) string {
+	if t, ok := value.(time.Time); ok {
+		value = t.UTC().Format(time.RFC3339Nano)
+	}
+	data, _ := json.Marshal(cursor{Order: order.signature(), Value: value, ID: id})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(encoded string, order Order) (*cursor, error) {
+	invalid := appErrors.ValidationError("after", "Invalid cursor")
+
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, invalid
+	}
+
+	var c cursor
+	if err := json.Unmarshal(data, &c); err != nil || c.Order != order.signature() {
+		return nil, invalid
+	}
+
+	value, ok := c.Value.(string)
+	if !ok {
+		return nil, invalid
+	}
+	if order.Kind == KindTime {
+		parsed, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return nil, invalid
+		}
+		c.Value = parsed
+	} else {
+		c.Value = value
+	}
+
+	return &c, nil
+}
+
+// qualifiedID names the model's ID column with its table, so the tie-breaker stays
+// unambiguous when the query joins other tables
+func qualifiedID(db *gorm.DB, model any) string {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(model); err != nil {
+		return "id"
+	}
+	return stmt.Schema.Table + ".id"
+}
@@ -0,0 +1,48 @@
+package place
+
+import (
+	"context"
+	"fmt"
+
+	"eztrip/api-go/dataloader"
+	"eztrip/api-go/logger"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type byIDLoaderKey struct{}
+
+// LoadByIDs returns the places with the given IDs keyed by ID, batching lookups made
+// by other resolvers of the same request. Unknown IDs are left out.
+func (s *Service) LoadByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*Place, error) {
+	found, err := dataloader.For(ctx, byIDLoaderKey{}, s.fetchByIDs).LoadAll(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	places := make(map[uuid.UUID]*Place, len(ids))
+	for _, place := range found {
+		if place != nil {
+			places[place.ID] = place
+		}
+	}
+	return places, nil
+}
+
+func (s *Service) fetchByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*Place, error) {
+	var found []Place
+	if err := s.db.WithContext(ctx).Where("id IN ?", ids).Find(&found).Error; err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"place_count": len(ids),
+			"error":       err.Error(),
+		}).Error("Failed to fetch places by ID")
+		return nil, fmt.Errorf("failed to fetch places: %w", err)
+	}
+
+	places := make(map[uuid.UUID]*Place, len(found))
+	for i := range found {
+		places[found[i].ID] = &found[i]
+	}
+	return places, nil
+}
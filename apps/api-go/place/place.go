@@ -20,6 +20,8 @@ type Place struct {
 	Website          string         `gorm:"column:website;type:text"`
 	PhoneNumber      string         `gorm:"column:phone_number"`
 	PriceLevel       int            `gorm:"column:price_level"` // 0-4 scale from Google
+	Latitude         *float64       `gorm:"column:latitude"`
+	Longitude        *float64       `gorm:"column:longitude"`
 	LastFetchedAt    time.Time      `gorm:"column:last_fetched_at;not null"`
 	CreatedAt        time.Time      `gorm:"column:created_at"`
 	UpdatedAt        time.Time      `gorm:"column:updated_at"`
@@ -35,3 +37,8 @@ func (Place) TableName() string {
 func (p *Place) IsStale() bool {
 	return time.Since(p.LastFetchedAt) > 30*24*time.Hour
 }
+
+// HasCoordinates checks if the place has a known latitude and longitude
+func (p *Place) HasCoordinates() bool {
+	return p.Latitude != nil && p.Longitude != nil
+}
@@ -0,0 +1,61 @@
+package printout
+
+import (
+	"fmt"
+	"net/http"
+
+	appErrors "eztrip/api-go/errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+var contentTypes = map[Format]string{
+	FormatHTML: "text/html; charset=utf-8",
+	FormatPDF:  "application/pdf",
+}
+
+// Handler serves printable itineraries over plain HTTP so browsers can download them
+type Handler struct {
+	Service *Service
+}
+
+// NewHandler creates a new printout HTTP handler
+func NewHandler(service *Service) *Handler {
+	return &Handler{
+		Service: service,
+	}
+}
+
+// HTML serves the itinerary as a printable page for the authenticated user
+func (h *Handler) HTML(c *gin.Context) {
+	h.serve(c, FormatHTML)
+}
+
+// PDF downloads the itinerary as a PDF for the authenticated user
+func (h *Handler) PDF(c *gin.Context) {
+	h.serve(c, FormatPDF)
+}
+
+func (h *Handler) serve(c *gin.Context, format Format) {
+	tripID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "bad_request", "message": "Invalid trip ID"})
+		return
+	}
+
+	t, body, err := h.Service.Render(c.Request.Context(), tripID, format)
+	if err != nil {
+		c.JSON(appErrors.HTTPStatus(err), gin.H{"error": "itinerary_render_failed", "message": appErrors.Message(err)})
+		return
+	}
+
+	disposition := "inline"
+	if format == FormatPDF || c.Query("download") == "true" {
+		disposition = "attachment"
+	}
+
+	c.Header("Cache-Control", "private, no-store")
+	c.Header("Content-Disposition", fmt.Sprintf(`%s; filename="%s-itinerary.%s"`, disposition, t.FileName(), format))
+	c.Data(http.StatusOK, contentTypes[format], body)
+}
@@ -0,0 +1,26 @@
+package printout
+
+import (
+	"bytes"
+	"embed"
+	"html/template"
+	"strings"
+)
+
+//go:embed templates/itinerary.html
+var templates embed.FS
+
+var htmlTemplate = template.Must(
+	template.New("itinerary.html").
+		Funcs(template.FuncMap{"join": strings.Join}).
+		ParseFS(templates, "templates/itinerary.html"),
+)
+
+// RenderHTML renders the itinerary as a standalone, print-styled HTML page
+func RenderHTML(it *Itinerary) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := htmlTemplate.Execute(&buf, it); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
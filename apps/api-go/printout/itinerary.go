@@ -0,0 +1,258 @@
+package printout
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"eztrip/api-go/place"
+	"eztrip/api-go/trip"
+
+	"github.com/google/uuid"
+)
+
+const (
+	longDateFormat  = "Monday, January 2, 2006"
+	shortDateFormat = "Jan 2, 2006"
+	clockFormat     = "15:04"
+)
+
+// Itinerary is a trip laid out for printing, with every value already formatted
+type Itinerary struct {
+	Title       string
+	Destination string
+	Dates       string
+	TimeZone    string
+	Travelers   []string
+	Days        []Day
+	Stays       []Stay
+	GeneratedAt string
+}
+
+// Day is an itinerary day with its activities in time order and where travelers sleep
+type Day struct {
+	Number     int
+	Date       string
+	Activities []Entry
+	Lodging    []string
+}
+
+// Entry is a single activity
+type Entry struct {
+	Time        string
+	Title       string
+	Category    string
+	Location    string
+	Address     string
+	Phone       string
+	Transport   string
+	Booking     string // Seat and confirmation number of the transport booking
+	Travelers   string
+	Description string
+	Notes       string
+}
+
+// Stay is a lodging stay with its booking details
+type Stay struct {
+	Name         string
+	Address      string
+	Phone        string
+	CheckIn      string
+	CheckOut     string
+	Nights       int
+	Confirmation string
+}
+
+// Build lays out a trip loaded through the trip service. Places are looked up by ID to
+// add addresses and phone numbers to activities and lodging.
+func Build(t *trip.Trip, places map[uuid.UUID]*place.Place, now time.Time) *Itinerary {
+	location := t.TimeLocation()
+
+	it := &Itinerary{
+		Title:       t.Title,
+		Destination: t.Destination,
+		Dates:       fmt.Sprintf("%s – %s", t.StartDate.In(location).Format(shortDateFormat), t.EndDate.In(location).Format(shortDateFormat)),
+		TimeZone:    t.TimeZone,
+		Travelers:   make([]string, 0, len(t.TravelerList)),
+		Days:        make([]Day, 0, len(t.Itinerary)),
+		Stays:       make([]Stay, 0, len(t.Lodgings)),
+		GeneratedAt: now.In(location).Format(shortDateFormat + " " + clockFormat),
+	}
+
+	travelerNames := make(map[uuid.UUID]string, len(t.TravelerList))
+	for _, traveler := range t.TravelerList {
+		it.Travelers = append(it.Travelers, traveler.Name)
+		travelerNames[traveler.ID] = traveler.Name
+	}
+
+	for i := range t.Itinerary {
+		day := &t.Itinerary[i]
+		date := day.LocalDate()
+
+		printed := Day{
+			Number:     day.DayNumber,
+			Date:       day.Date.In(location).Format(longDateFormat),
+			Activities: make([]Entry, 0, len(day.Activities)),
+		}
+
+		activities := append([]trip.Activity(nil), day.Activities...)
+		sort.SliceStable(activities, func(a, b int) bool {
+			return activities[a].Time.Before(activities[b].Time)
+		})
+		for j := range activities {
+			printed.Activities = append(printed.Activities, buildEntry(&activities[j], location, places))
+		}
+
+		for _, lodging := range t.Lodgings {
+			if lodging.CoversNight(date) {
+				printed.Lodging = append(printed.Lodging, lodging.Name)
+			}
+		}
+
+		it.Days = append(it.Days, printed)
+	}
+
+	for _, lodging := range t.Lodgings {
+		stay := Stay{
+			Name:     lodging.Name,
+			Address:  lodging.Address,
+			CheckIn:  lodging.CheckInDate.Format(shortDateFormat),
+			CheckOut: lodging.CheckOutDate.Format(shortDateFormat),
+			Nights:   lodging.Nights(),
+		}
+		if lodging.ConfirmationNumber != nil {
+			stay.Confirmation = *lodging.ConfirmationNumber
+		}
+		if lodging.PlaceID != nil {
+			if p, ok := places[*lodging.PlaceID]; ok {
+				stay.Address = firstNonEmpty(stay.Address, p.FormattedAddress, p.Address)
+				stay.Phone = p.PhoneNumber
+			}
+		}
+		it.Stays = append(it.Stays, stay)
+	}
+
+	return it
+}
+
+// hideNotes removes the private notes of every activity
+func (it *Itinerary) hideNotes() {
+	for i := range it.Days {
+		for j := range it.Days[i].Activities {
+			it.Days[i].Activities[j].Notes = ""
+		}
+	}
+}
+
+// hideBookingDetails removes confirmation numbers and seats, which are enough to
+// manage most bookings
+func (it *Itinerary) hideBookingDetails() {
+	for i := range it.Days {
+		for j := range it.Days[i].Activities {
+			it.Days[i].Activities[j].Booking = ""
+		}
+	}
+	for i := range it.Stays {
+		it.Stays[i].Confirmation = ""
+	}
+}
+
+// hideTravelers removes the names of the people on the trip and who joins each activity
+func (it *Itinerary) hideTravelers() {
+	it.Travelers = nil
+	for i := range it.Days {
+		for j := range it.Days[i].Activities {
+			it.Days[i].Activities[j].Travelers = ""
+		}
+	}
+}
+
+// buildEntry formats an activity. Times are shown in the activity's own time zone, which
+// is named when it differs from the trip's.
+func buildEntry(activity *trip.Activity, tripLocation *time.Location, places map[uuid.UUID]*place.Place) Entry {
+	location := activity.TimeLocation()
+
+	entry := Entry{
+		Time:        activity.Time.In(location).Format(clockFormat),
+		Title:       activity.Title,
+		Category:    capitalize(string(activity.Category)),
+		Location:    activity.Location,
+		Description: activity.Description,
+		Notes:       activity.Notes,
+	}
+	if activity.EndTime != nil && activity.EndTime.After(activity.Time) {
+		entry.Time += "–" + activity.EndTime.In(location).Format(clockFormat)
+	}
+	if location.String() != tripLocation.String() {
+		entry.Time += " " + activity.Time.In(location).Format("MST")
+	}
+
+	if activity.PlaceID != nil {
+		if p, ok := places[*activity.PlaceID]; ok {
+			entry.Location = firstNonEmpty(entry.Location, p.Name)
+			entry.Address = firstNonEmpty(p.FormattedAddress, p.Address)
+			entry.Phone = p.PhoneNumber
+		}
+	}
+
+	if leg := activity.TransportLeg; leg != nil {
+		entry.Transport = describeTransport(leg)
+		entry.Booking = describeBooking(leg)
+	}
+
+	if len(activity.Travelers) > 0 {
+		names := make([]string, len(activity.Travelers))
+		for i, traveler := range activity.Travelers {
+			names[i] = traveler.Name
+		}
+		entry.Travelers = strings.Join(names, ", ")
+	}
+
+	return entry
+}
+
+// describeTransport summarizes a transport leg's route on one line
+func describeTransport(leg *trip.TransportLeg) string {
+	parts := []string{capitalize(string(leg.Mode))}
+	if leg.Carrier != nil && *leg.Carrier != "" {
+		parts[0] += " " + *leg.Carrier
+	}
+
+	parts = append(parts, fmt.Sprintf("%s %s → %s %s",
+		leg.Origin,
+		leg.DepartTime.In(trip.LoadLocation(leg.DepartTimeZone)).Format(clockFormat+" MST"),
+		leg.Destination,
+		leg.ArriveTime.In(trip.LoadLocation(leg.ArriveTimeZone)).Format(clockFormat+" MST"),
+	))
+
+	return strings.Join(parts, " · ")
+}
+
+// describeBooking summarizes the seat and confirmation number of a transport leg
+func describeBooking(leg *trip.TransportLeg) string {
+	var parts []string
+	if leg.Seat != nil && *leg.Seat != "" {
+		parts = append(parts, "Seat "+*leg.Seat)
+	}
+	if leg.ConfirmationNumber != nil && *leg.ConfirmationNumber != "" {
+		parts = append(parts, "Confirmation "+*leg.ConfirmationNumber)
+	}
+	return strings.Join(parts, " · ")
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func capitalize(value string) string {
+	if value == "" {
+		return value
+	}
+	return strings.ToUpper(value[:1]) + value[1:]
+}
@@ -0,0 +1,179 @@
+package printout
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+const (
+	pageMargin   = 16.0
+	timeColumn   = 30.0
+	lineHeight   = 5.0
+	minRowHeight = 20.0
+	accentRed    = 31
+	accentGreen  = 111
+	accentBlue   = 235
+)
+
+// The core PDF fonts only cover Windows-1252, so characters outside it are spelled out
+var pdfReplacer = strings.NewReplacer("→", "->")
+
+// RenderPDF renders the itinerary as an A4 PDF using the built-in Helvetica font
+func RenderPDF(it *Itinerary) ([]byte, error) {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(pageMargin, pageMargin, pageMargin)
+	pdf.SetAutoPageBreak(true, pageMargin)
+	pdf.SetTitle(it.Title, true)
+	pdf.AliasNbPages("")
+
+	translate := pdf.UnicodeTranslatorFromDescriptor("")
+	text := func(value string) string {
+		return translate(pdfReplacer.Replace(value))
+	}
+
+	pageWidth, _ := pdf.GetPageSize()
+	contentWidth := pageWidth - 2*pageMargin
+
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-12)
+		pdf.SetFont("Helvetica", "I", 8)
+		pdf.SetTextColor(123, 135, 148)
+		pdf.CellFormat(contentWidth/2, 6, text("Generated "+it.GeneratedAt), "", 0, "L", false, 0, "")
+		pdf.CellFormat(contentWidth/2, 6, fmt.Sprintf("Page %d of {nb}", pdf.PageNo()), "", 0, "R", false, 0, "")
+	})
+
+	pdf.AddPage()
+
+	// Title block
+	pdf.SetFont("Helvetica", "B", 20)
+	pdf.SetTextColor(31, 41, 51)
+	pdf.MultiCell(contentWidth, 9, text(it.Title), "", "L", false)
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.SetTextColor(82, 96, 109)
+	pdf.MultiCell(contentWidth, lineHeight, text(fmt.Sprintf("%s · %s · Times in %s", it.Destination, it.Dates, it.TimeZone)), "", "L", false)
+	if len(it.Travelers) > 0 {
+		pdf.MultiCell(contentWidth, lineHeight, text("Travelers: "+strings.Join(it.Travelers, ", ")), "", "L", false)
+	}
+	pdf.SetDrawColor(accentRed, accentGreen, accentBlue)
+	pdf.SetLineWidth(0.6)
+	pdf.Line(pageMargin, pdf.GetY()+2, pageWidth-pageMargin, pdf.GetY()+2)
+	pdf.Ln(6)
+
+	for _, day := range it.Days {
+		heading(pdf, contentWidth, text(fmt.Sprintf("Day %d – %s", day.Number, day.Date)))
+
+		if len(day.Activities) == 0 {
+			pdf.SetFont("Helvetica", "I", 10)
+			pdf.SetTextColor(123, 135, 148)
+			pdf.MultiCell(contentWidth, lineHeight, "Nothing planned yet.", "", "L", false)
+		}
+
+		for _, entry := range day.Activities {
+			details := []string{entry.Location, entry.Address}
+			if entry.Phone != "" {
+				details = append(details, "Phone: "+entry.Phone)
+			}
+			if entry.Booking != "" {
+				details = append(details, entry.Transport+" · "+entry.Booking)
+			} else {
+				details = append(details, entry.Transport)
+			}
+			if entry.Travelers != "" {
+				details = append(details, "With: "+entry.Travelers)
+			}
+			details = append(details, entry.Description)
+
+			row(pdf, contentWidth, text(entry.Time), text(entry.Title), text(entry.Category), text, details, entry.Notes)
+		}
+
+		if len(day.Lodging) > 0 {
+			pdf.SetFont("Helvetica", "", 9)
+			pdf.SetTextColor(62, 76, 89)
+			pdf.MultiCell(contentWidth, lineHeight, text("Overnight: "+strings.Join(day.Lodging, ", ")), "", "L", false)
+		}
+		pdf.Ln(3)
+	}
+
+	if len(it.Stays) > 0 {
+		heading(pdf, contentWidth, "Lodging")
+		for _, stay := range it.Stays {
+			nights := fmt.Sprintf("%d nights, until %s", stay.Nights, stay.CheckOut)
+			if stay.Nights == 1 {
+				nights = "1 night, until " + stay.CheckOut
+			}
+			details := []string{stay.Address}
+			if stay.Phone != "" {
+				details = append(details, "Phone: "+stay.Phone)
+			}
+			if stay.Confirmation != "" {
+				details = append(details, "Confirmation: "+stay.Confirmation)
+			}
+			row(pdf, contentWidth, text(stay.CheckIn), text(stay.Name), text(nights), text, details, "")
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// heading writes a section title with a rule underneath
+func heading(pdf *gofpdf.Fpdf, width float64, title string) {
+	pdf.SetFont("Helvetica", "B", 13)
+	pdf.SetTextColor(31, 41, 51)
+	pdf.CellFormat(width, 8, title, "B", 1, "L", false, 0, "")
+	pdf.Ln(1)
+}
+
+// row writes a time cell and a wrapped description column, keeping the two aligned
+func row(pdf *gofpdf.Fpdf, width float64, when, title, label string, text func(string) string, details []string, notes string) {
+	left, _, _, _ := pdf.GetMargins()
+
+	// Start rows near the bottom of a page on the next one, so the time stays next to its title
+	if _, pageHeight := pdf.GetPageSize(); pdf.GetY()+minRowHeight > pageHeight-pageMargin {
+		pdf.AddPage()
+	}
+	top := pdf.GetY()
+
+	pdf.SetFont("Helvetica", "B", 10)
+	pdf.SetTextColor(31, 41, 51)
+	pdf.MultiCell(timeColumn, lineHeight, when, "", "L", false)
+	timeBottom := pdf.GetY()
+
+	pdf.SetXY(left+timeColumn, top)
+	pdf.MultiCell(width-timeColumn, lineHeight, title, "", "L", false)
+
+	pdf.SetFont("Helvetica", "", 8)
+	pdf.SetTextColor(82, 96, 109)
+	pdf.SetX(left + timeColumn)
+	pdf.MultiCell(width-timeColumn, 4, strings.ToUpper(label), "", "L", false)
+
+	pdf.SetFont("Helvetica", "", 9)
+	pdf.SetTextColor(62, 76, 89)
+	for _, detail := range details {
+		if detail == "" {
+			continue
+		}
+		pdf.SetX(left + timeColumn)
+		pdf.MultiCell(width-timeColumn, 4.5, text(detail), "", "L", false)
+	}
+
+	if notes != "" {
+		pdf.SetFillColor(255, 248, 225)
+		pdf.SetX(left + timeColumn)
+		pdf.MultiCell(width-timeColumn, 4.5, text("Notes: "+notes), "", "L", true)
+	}
+
+	if pdf.GetY() < timeBottom {
+		pdf.SetY(timeBottom)
+	}
+	pdf.SetDrawColor(238, 241, 244)
+	pdf.SetLineWidth(0.2)
+	pdf.Line(left, pdf.GetY()+1, left+width, pdf.GetY()+1)
+	pdf.Ln(2.5)
+}
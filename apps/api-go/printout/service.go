@@ -0,0 +1,124 @@
+package printout
+
+import (
+	"context"
+	"time"
+
+	appErrors "eztrip/api-go/errors"
+	"eztrip/api-go/logger"
+	"eztrip/api-go/place"
+	"eztrip/api-go/trip"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// Format is a printable document format
+type Format string
+
+const (
+	FormatHTML Format = "html"
+	FormatPDF  Format = "pdf"
+)
+
+// Service renders printable itineraries
+type Service struct {
+	db           *gorm.DB
+	tripService  *trip.Service
+	placeService *place.Service
+}
+
+// NewService creates a new printout service
+func NewService(db *gorm.DB, tripService *trip.Service) *Service {
+	return &Service{
+		db:           db,
+		tripService:  tripService,
+		placeService: place.NewService(db),
+	}
+}
+
+// Options adjust what a rendered itinerary includes
+type Options struct {
+	HideNotes          bool // Leave out private activity notes, e.g. for public share links
+	HideBookingDetails bool // Leave out confirmation numbers and seats, e.g. for public share links
+	HideTravelers      bool // Leave out the names of the people on the trip, e.g. for public share links
+}
+
+// Render renders a trip the user has access to in the requested format
+func (s *Service) Render(ctx context.Context, tripID uuid.UUID, format Format) (*trip.Trip, []byte, error) {
+	t, err := s.tripService.GetByID(ctx, tripID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	body, err := s.RenderTrip(ctx, t, format, Options{})
+	if err != nil {
+		return nil, nil, err
+	}
+	return t, body, nil
+}
+
+// RenderTrip renders an already authorized trip in the requested format
+func (s *Service) RenderTrip(ctx context.Context, t *trip.Trip, format Format, opts Options) ([]byte, error) {
+	places, err := s.loadPlaces(ctx, t)
+	if err != nil {
+		return nil, err
+	}
+
+	it := Build(t, places, time.Now())
+	if opts.HideNotes {
+		it.hideNotes()
+	}
+	if opts.HideBookingDetails {
+		it.hideBookingDetails()
+	}
+	if opts.HideTravelers {
+		it.hideTravelers()
+	}
+
+	var body []byte
+	switch format {
+	case FormatPDF:
+		body, err = RenderPDF(it)
+	default:
+		body, err = RenderHTML(it)
+	}
+	if err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"trip_id": t.ID,
+			"format":  format,
+			"error":   err.Error(),
+		}).Error("Failed to render itinerary")
+		return nil, appErrors.Internal("Failed to render itinerary")
+	}
+
+	return body, nil
+}
+
+// loadPlaces fetches the places referenced by the trip's activities and lodgings
+func (s *Service) loadPlaces(ctx context.Context, t *trip.Trip) (map[uuid.UUID]*place.Place, error) {
+	var placeIDs []uuid.UUID
+	for _, day := range t.Itinerary {
+		for _, activity := range day.Activities {
+			if activity.PlaceID != nil {
+				placeIDs = append(placeIDs, *activity.PlaceID)
+			}
+		}
+	}
+	for _, lodging := range t.Lodgings {
+		if lodging.PlaceID != nil {
+			placeIDs = append(placeIDs, *lodging.PlaceID)
+		}
+	}
+
+	places, err := s.placeService.LoadByIDs(ctx, placeIDs)
+	if err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"trip_id": t.ID,
+			"error":   err.Error(),
+		}).Error("Failed to fetch places for itinerary")
+		return nil, appErrors.Internal("Failed to render itinerary")
+	}
+	return places, nil
+}
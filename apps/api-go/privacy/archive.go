@@ -0,0 +1,367 @@
+package privacy
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"maps"
+	"slices"
+	"time"
+
+	"eztrip/api-go/accesstoken"
+	"eztrip/api-go/calendar"
+	appErrors "eztrip/api-go/errors"
+	"eztrip/api-go/expense"
+	"eztrip/api-go/logger"
+	"eztrip/api-go/rbac"
+	"eztrip/api-go/share"
+	"eztrip/api-go/trip"
+	"eztrip/api-go/user"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// archiveReadme explains the archive's files to the person who downloads it
+const archiveReadme = `This archive holds the personal data eztrip keeps about you, as JSON files:
+
+profile.json          your account, roles and preferences
+collaborations.json   the trips you own or collaborate on, and since when
+trips/<id>.json       each of those trips with its itinerary, activities, lodgings and
+                      travelers, in the format accepted by trip import
+expenses.json         expenses you paid, your shares of expenses, and settlements
+access.json           your access tokens, share links and calendar subscriptions,
+                      without their secrets
+
+AI travel suggestions are generated on request and neither prompts nor answers are
+stored, so there is no AI chat history to include.
+`
+
+type profileDocument struct {
+	ID                 string           `json:"id"`
+	FirstName          string           `json:"firstName"`
+	LastName           string           `json:"lastName"`
+	Email              string           `json:"email"`
+	Roles              []string         `json:"roles"`
+	Preferences        user.Preferences `json:"preferences"`
+	CreatedAt          time.Time        `json:"createdAt"`
+	UpdatedAt          time.Time        `json:"updatedAt"`
+	ErasureRequestedAt *time.Time       `json:"erasureRequestedAt,omitempty"`
+	ErasureDueAt       *time.Time       `json:"erasureDueAt,omitempty"`
+}
+
+type collaborationDocument struct {
+	TripID string          `json:"tripId"`
+	Title  string          `json:"title"`
+	Role   trip.MemberRole `json:"role"`
+	Since  time.Time       `json:"since"`
+}
+
+type expensesDocument struct {
+	Paid        []expenseDocument    `json:"paid"`
+	Shares      []shareDocument      `json:"shares"`
+	Settlements []settlementDocument `json:"settlements"`
+}
+
+type expenseDocument struct {
+	ID          string           `json:"id"`
+	TripID      string           `json:"tripId"`
+	Amount      float64          `json:"amount"`
+	Currency    string           `json:"currency"`
+	Category    expense.Category `json:"category"`
+	Date        string           `json:"date"`
+	Description string           `json:"description,omitempty"`
+}
+
+type shareDocument struct {
+	ExpenseID string  `json:"expenseId"`
+	Amount    float64 `json:"amount"`
+}
+
+type settlementDocument struct {
+	ID         string  `json:"id"`
+	TripID     string  `json:"tripId"`
+	FromUserID string  `json:"fromUserId"`
+	ToUserID   string  `json:"toUserId"`
+	Amount     float64 `json:"amount"`
+	Currency   string  `json:"currency"`
+	Date       string  `json:"date"`
+	Note       string  `json:"note,omitempty"`
+}
+
+type accessDocument struct {
+	AccessTokens          []accessTokenDocument  `json:"accessTokens"`
+	ShareLinks            []shareLinkDocument    `json:"shareLinks"`
+	CalendarSubscriptions []calendarFeedDocument `json:"calendarSubscriptions"`
+}
+
+type accessTokenDocument struct {
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     string     `json:"scopes"`
+	ExpiresAt  time.Time  `json:"expiresAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
+
+type shareLinkDocument struct {
+	TripID         string     `json:"tripId"`
+	Label          *string    `json:"label,omitempty"`
+	ExpiresAt      *time.Time `json:"expiresAt,omitempty"`
+	LastAccessedAt *time.Time `json:"lastAccessedAt,omitempty"`
+	AccessCount    int        `json:"accessCount"`
+	CreatedAt      time.Time  `json:"createdAt"`
+}
+
+type calendarFeedDocument struct {
+	TripID         string     `json:"tripId"`
+	LastAccessedAt *time.Time `json:"lastAccessedAt,omitempty"`
+	CreatedAt      time.Time  `json:"createdAt"`
+}
+
+// buildArchive collects the user's personal data into a ZIP archive of JSON files
+func (s *Service) buildArchive(ctx context.Context, u *user.User) ([]byte, error) {
+	files := map[string]any{}
+
+	files["profile.json"] = profileDocumentFor(ctx, u)
+
+	trips, err := s.memberTrips(ctx, u.ID)
+	if err != nil {
+		return nil, err
+	}
+	collaborations := make([]collaborationDocument, 0, len(trips))
+	for _, t := range trips {
+		collaborations = append(collaborations, toCollaborationDocument(t, u.ID))
+
+		doc, err := s.importService.Export(ctx, t.ID)
+		if err != nil {
+			return nil, err
+		}
+		files["trips/"+t.ID.String()+".json"] = doc
+	}
+	files["collaborations.json"] = collaborations
+
+	expenses, err := s.expensesDocument(ctx, u.ID)
+	if err != nil {
+		return nil, err
+	}
+	files["expenses.json"] = expenses
+
+	access, err := s.accessDocument(ctx, u.ID)
+	if err != nil {
+		return nil, err
+	}
+	files["access.json"] = access
+
+	return writeArchive(files)
+}
+
+// profileDocumentFor describes the user's account. Roles are left out if they can't be read.
+func profileDocumentFor(ctx context.Context, u *user.User) *profileDocument {
+	roles := []string{}
+	if enforcer, err := rbac.GetEnforcerFromContext(ctx); err == nil {
+		if assigned, err := rbac.GetRolesForUser(enforcer, u.ID.String()); err == nil {
+			roles = append(roles, assigned...)
+		}
+	}
+
+	return &profileDocument{
+		ID:                 u.ID.String(),
+		FirstName:          u.FirstName,
+		LastName:           u.LastName,
+		Email:              u.Email,
+		Roles:              roles,
+		Preferences:        u.Preferences,
+		CreatedAt:          u.CreatedAt,
+		UpdatedAt:          u.UpdatedAt,
+		ErasureRequestedAt: u.ErasureRequestedAt,
+		ErasureDueAt:       u.ErasureDueAt,
+	}
+}
+
+// memberTrips returns the trips the user owns or collaborates on, with their collaborators
+func (s *Service) memberTrips(ctx context.Context, userID uuid.UUID) ([]*trip.Trip, error) {
+	collaborating := s.db.Table("trip_collaborators").
+		Select("trip_id").
+		Where("user_id = ? AND deleted_at IS NULL", userID)
+
+	var trips []*trip.Trip
+	err := s.db.WithContext(ctx).
+		Preload("Collaborators", "user_id = ?", userID).
+		Where(s.db.Where("owner_id = ?", userID).Or("id IN (?)", collaborating)).
+		Order("start_date ASC").
+		Find(&trips).Error
+
+	if err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"user_id": userID,
+			"error":   err.Error(),
+		}).Error("Failed to fetch trips for data export")
+		return nil, appErrors.Internal("Failed to export data")
+	}
+
+	return trips, nil
+}
+
+func toCollaborationDocument(t *trip.Trip, userID uuid.UUID) collaborationDocument {
+	doc := collaborationDocument{
+		TripID: t.ID.String(),
+		Title:  t.Title,
+		Role:   trip.MemberRoleOwner,
+		Since:  t.CreatedAt,
+	}
+	if t.OwnerID != userID && len(t.Collaborators) > 0 {
+		doc.Role = trip.MemberRoleCollaborator
+		doc.Since = t.Collaborators[0].CreatedAt
+	}
+	return doc
+}
+
+func (s *Service) expensesDocument(ctx context.Context, userID uuid.UUID) (*expensesDocument, error) {
+	var paid []expense.Expense
+	var shares []expense.ExpenseShare
+	var settlements []expense.Settlement
+
+	db := s.db.WithContext(ctx)
+	err := db.Where("payer_id = ?", userID).Order("date ASC").Find(&paid).Error
+	if err == nil {
+		err = db.Where("user_id = ?", userID).Order("created_at ASC").Find(&shares).Error
+	}
+	if err == nil {
+		err = db.Where("from_user_id = ? OR to_user_id = ?", userID, userID).Order("date ASC").Find(&settlements).Error
+	}
+	if err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"user_id": userID,
+			"error":   err.Error(),
+		}).Error("Failed to fetch expenses for data export")
+		return nil, appErrors.Internal("Failed to export data")
+	}
+
+	doc := &expensesDocument{
+		Paid:        make([]expenseDocument, len(paid)),
+		Shares:      make([]shareDocument, len(shares)),
+		Settlements: make([]settlementDocument, len(settlements)),
+	}
+	for i, e := range paid {
+		doc.Paid[i] = expenseDocument{
+			ID:          e.ID.String(),
+			TripID:      e.TripID.String(),
+			Amount:      e.Amount,
+			Currency:    e.Currency,
+			Category:    e.Category,
+			Date:        e.Date.Format(dateFormat),
+			Description: e.Description,
+		}
+	}
+	for i, share := range shares {
+		doc.Shares[i] = shareDocument{ExpenseID: share.ExpenseID.String(), Amount: share.Amount}
+	}
+	for i, settlement := range settlements {
+		doc.Settlements[i] = settlementDocument{
+			ID:         settlement.ID.String(),
+			TripID:     settlement.TripID.String(),
+			FromUserID: settlement.FromUserID.String(),
+			ToUserID:   settlement.ToUserID.String(),
+			Amount:     settlement.Amount,
+			Currency:   settlement.Currency,
+			Date:       settlement.Date.Format(dateFormat),
+			Note:       settlement.Note,
+		}
+	}
+
+	return doc, nil
+}
+
+func (s *Service) accessDocument(ctx context.Context, userID uuid.UUID) (*accessDocument, error) {
+	var tokens []accesstoken.Token
+	var links []share.Link
+	var feeds []calendar.Feed
+
+	db := s.db.WithContext(ctx)
+	err := db.Where("user_id = ?", userID).Order("created_at ASC").Find(&tokens).Error
+	if err == nil {
+		err = db.Where("created_by = ?", userID).Order("created_at ASC").Find(&links).Error
+	}
+	if err == nil {
+		err = db.Where("user_id = ?", userID).Order("created_at ASC").Find(&feeds).Error
+	}
+	if err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"user_id": userID,
+			"error":   err.Error(),
+		}).Error("Failed to fetch access grants for data export")
+		return nil, appErrors.Internal("Failed to export data")
+	}
+
+	doc := &accessDocument{
+		AccessTokens:          make([]accessTokenDocument, len(tokens)),
+		ShareLinks:            make([]shareLinkDocument, len(links)),
+		CalendarSubscriptions: make([]calendarFeedDocument, len(feeds)),
+	}
+	for i, token := range tokens {
+		doc.AccessTokens[i] = accessTokenDocument{
+			Name:       token.Name,
+			Prefix:     token.Prefix,
+			Scopes:     token.ScopeList,
+			ExpiresAt:  token.ExpiresAt,
+			LastUsedAt: token.LastUsedAt,
+			CreatedAt:  token.CreatedAt,
+		}
+	}
+	for i, link := range links {
+		doc.ShareLinks[i] = shareLinkDocument{
+			TripID:         link.TripID.String(),
+			Label:          link.Label,
+			ExpiresAt:      link.ExpiresAt,
+			LastAccessedAt: link.LastAccessedAt,
+			AccessCount:    link.AccessCount,
+			CreatedAt:      link.CreatedAt,
+		}
+	}
+	for i, feed := range feeds {
+		doc.CalendarSubscriptions[i] = calendarFeedDocument{
+			TripID:         feed.TripID.String(),
+			LastAccessedAt: feed.LastAccessedAt,
+			CreatedAt:      feed.CreatedAt,
+		}
+	}
+
+	return doc, nil
+}
+
+// writeArchive zips the README and the files, each encoded as indented JSON
+func writeArchive(files map[string]any) ([]byte, error) {
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+
+	err := addFile(archive, "README.txt", []byte(archiveReadme))
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		if err != nil {
+			break
+		}
+		var data []byte
+		if data, err = json.MarshalIndent(files[name], "", "  "); err == nil {
+			err = addFile(archive, name, data)
+		}
+	}
+	if err == nil {
+		err = archive.Close()
+	}
+	if err != nil {
+		logger.Log.WithField("error", err.Error()).Error("Failed to write data export archive")
+		return nil, appErrors.Internal("Failed to export data")
+	}
+
+	return buf.Bytes(), nil
+}
+
+func addFile(archive *zip.Writer, name string, data []byte) error {
+	file, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(data)
+	return err
+}
@@ -0,0 +1,263 @@
+package privacy
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"eztrip/api-go/accesstoken"
+	"eztrip/api-go/auth0sync"
+	"eztrip/api-go/calendar"
+	appErrors "eztrip/api-go/errors"
+	"eztrip/api-go/identity"
+	"eztrip/api-go/logger"
+	"eztrip/api-go/share"
+	"eztrip/api-go/trip"
+	"eztrip/api-go/user"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	erasedFirstName   = "Deleted"
+	erasedLastName    = "user"
+	erasedEmailDomain = "erased.invalid"
+	erasedTraveler    = "Former traveler"
+)
+
+// ErasureResult counts the outcome of a run of due erasures
+type ErasureResult struct {
+	Erased int
+	Failed int
+}
+
+// GetErasure returns the current user's pending erasure request, or nil if there is none
+func (s *Service) GetErasure(ctx context.Context) (*Erasure, error) {
+	current, _, err := user.GetAuthenticatedUser(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+	return pendingErasure(current), nil
+}
+
+// RequestErasure schedules the erasure of the current user's personal data after the
+// grace period. Repeated requests keep the original schedule.
+func (s *Service) RequestErasure(ctx context.Context) (*Erasure, error) {
+	if err := requireInteractive(ctx, "Account erasure can't be requested with an access token"); err != nil {
+		return nil, err
+	}
+
+	current, userID, err := user.GetAuthenticatedUser(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+	if erasure := pendingErasure(current); erasure != nil {
+		return erasure, nil
+	}
+
+	now := time.Now()
+	dueAt := now.Add(s.gracePeriod)
+	err = s.db.WithContext(ctx).Model(current).UpdateColumns(map[string]interface{}{
+		"erasure_requested_at": now,
+		"erasure_due_at":       dueAt,
+	}).Error
+	if err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"user_id": userID,
+			"error":   err.Error(),
+		}).Error("Failed to request account erasure")
+		return nil, appErrors.Internal("Failed to request account erasure")
+	}
+
+	logger.Log.WithFields(logrus.Fields{
+		"user_id": userID,
+		"due_at":  dueAt,
+	}).Info("Account erasure requested")
+
+	return &Erasure{RequestedAt: now, DueAt: dueAt}, nil
+}
+
+// CancelErasure withdraws the current user's pending erasure request
+func (s *Service) CancelErasure(ctx context.Context) error {
+	if err := requireInteractive(ctx, "Account erasure can't be cancelled with an access token"); err != nil {
+		return err
+	}
+
+	current, userID, err := user.GetAuthenticatedUser(ctx, s.db)
+	if err != nil {
+		return err
+	}
+	if pendingErasure(current) == nil {
+		return appErrors.NotFound("Account erasure request")
+	}
+
+	err = s.db.WithContext(ctx).Model(current).UpdateColumns(map[string]interface{}{
+		"erasure_requested_at": nil,
+		"erasure_due_at":       nil,
+	}).Error
+	if err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"user_id": userID,
+			"error":   err.Error(),
+		}).Error("Failed to cancel account erasure")
+		return appErrors.Internal("Failed to cancel account erasure")
+	}
+
+	logger.Log.WithField("user_id", userID).Info("Account erasure cancelled")
+	return nil
+}
+
+// ProcessDue erases every user whose grace period has ended. Failures are logged and
+// retried on the next run.
+func (s *Service) ProcessDue(ctx context.Context, enforcer *casbin.SyncedEnforcer, now time.Time) (ErasureResult, error) {
+	var result ErasureResult
+
+	var due []user.User
+	err := s.db.WithContext(ctx).Unscoped().
+		Where("erasure_due_at <= ? AND erased_at IS NULL", now).
+		Order("erasure_due_at ASC").
+		Find(&due).Error
+	if err != nil {
+		return result, err
+	}
+
+	for i := range due {
+		if err := s.Erase(ctx, enforcer, &due[i]); err != nil {
+			logger.Log.WithFields(logrus.Fields{
+				"user_id": due[i].ID,
+				"error":   err.Error(),
+			}).Error("Failed to erase user")
+			result.Failed++
+			continue
+		}
+		result.Erased++
+	}
+
+	return result, nil
+}
+
+// Erase removes a user's personal data. The identity provider account is deleted first,
+// so a failure there leaves everything to retry. Trips the user owns pass to their
+// longest-standing collaborator, or are deleted with everything in them if there is
+// none. The user row is kept, anonymized, so other members' expenses and settlements
+// still add up.
+func (s *Service) Erase(ctx context.Context, enforcer *casbin.SyncedEnforcer, u *user.User) error {
+	if err := s.deleteFromDirectory(u); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := handOverOwnedTrips(tx, u.ID); err != nil {
+			return err
+		}
+
+		deletions := []struct {
+			model any
+			query string
+		}{
+			{&trip.TripCollaborator{}, "user_id = ?"},
+			{&accesstoken.Token{}, "user_id = ?"},
+			{&share.Link{}, "created_by = ?"},
+			{&calendar.Feed{}, "user_id = ?"},
+			{&Export{}, "user_id = ?"},
+		}
+		for _, deletion := range deletions {
+			if err := tx.Unscoped().Where(deletion.query, u.ID).Delete(deletion.model).Error; err != nil {
+				return err
+			}
+		}
+
+		err := tx.Unscoped().Model(&trip.Traveler{}).Where("user_id = ?", u.ID).Updates(map[string]interface{}{
+			"user_id": nil,
+			"name":    erasedTraveler,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		if u.Auth0UserID != nil {
+			if err := tx.Where("auth0_user_id = ?", *u.Auth0UserID).Delete(&auth0sync.Record{}).Error; err != nil {
+				return err
+			}
+		}
+
+		anonymized := user.ClearedPreferenceColumns()
+		anonymized["first_name"] = erasedFirstName
+		anonymized["last_name"] = erasedLastName
+		anonymized["email"] = u.ID.String() + "@" + erasedEmailDomain
+		anonymized["auth0_user_id"] = nil
+		anonymized["erased_at"] = now
+		anonymized["deleted_at"] = gorm.Expr("COALESCE(deleted_at, ?)", now)
+		return tx.Unscoped().Model(&user.User{}).Where("id = ?", u.ID).Updates(anonymized).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, err := enforcer.DeleteRolesForUser(u.ID.String()); err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"user_id": u.ID,
+			"error":   err.Error(),
+		}).Warn("Failed to remove roles of erased user")
+	}
+
+	logger.Log.WithField("user_id", u.ID).Info("User erased successfully")
+	return nil
+}
+
+// handOverOwnedTrips gives each trip the user owns to its longest-standing collaborator,
+// and deletes trips without one. Deleting a trip cascades to everything in it.
+func handOverOwnedTrips(tx *gorm.DB, userID uuid.UUID) error {
+	var owned []trip.Trip
+	if err := tx.Unscoped().Select("id", "deleted_at").Where("owner_id = ?", userID).Find(&owned).Error; err != nil {
+		return err
+	}
+
+	for _, t := range owned {
+		var successor trip.TripCollaborator
+		err := tx.Where("trip_id = ? AND user_id <> ?", t.ID, userID).Order("created_at ASC").First(&successor).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound) || t.DeletedAt.Valid:
+			if err := tx.Unscoped().Delete(&trip.Trip{}, "id = ?", t.ID).Error; err != nil {
+				return err
+			}
+		case err != nil:
+			return err
+		default:
+			if err := tx.Model(&trip.Trip{}).Where("id = ?", t.ID).Update("owner_id", successor.UserID).Error; err != nil {
+				return err
+			}
+			if err := tx.Unscoped().Delete(&successor).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// deleteFromDirectory deletes the user's identity provider account. Accounts that are
+// already gone count as deleted, so erasures can be retried.
+func (s *Service) deleteFromDirectory(u *user.User) error {
+	if u.Auth0UserID == nil || u.ServiceAccount {
+		return nil
+	}
+	if s.directory == nil {
+		return errors.New("identity provider not initialized")
+	}
+	if err := s.directory.DeleteUser(*u.Auth0UserID); err != nil && !errors.Is(err, identity.ErrUserNotFound) {
+		return err
+	}
+	return nil
+}
+
+func pendingErasure(u *user.User) *Erasure {
+	if u.ErasureRequestedAt == nil || u.ErasureDueAt == nil {
+		return nil
+	}
+	return &Erasure{RequestedAt: *u.ErasureRequestedAt, DueAt: *u.ErasureDueAt}
+}
@@ -0,0 +1,40 @@
+package privacy
+
+import (
+	"fmt"
+	"net/http"
+
+	appErrors "eztrip/api-go/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+const archiveContentType = "application/zip"
+
+// Handler serves data export archives
+type Handler struct {
+	Service *Service
+}
+
+// NewHandler creates a new privacy HTTP handler
+func NewHandler(service *Service) *Handler {
+	return &Handler{
+		Service: service,
+	}
+}
+
+// Download serves the archive behind an export's secret token. Browsers follow the link
+// without a bearer token, so this route is public and the token in the path is the
+// credential.
+func (h *Handler) Download(c *gin.Context) {
+	export, err := h.Service.ResolveExport(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		c.JSON(appErrors.HTTPStatus(err), gin.H{"error": "data_export_unavailable", "message": appErrors.Message(err)})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="eztrip-data-%s.zip"`, export.CreatedAt.Format(dateFormat)))
+	c.Header("Cache-Control", "private, no-store")
+	c.Header("Referrer-Policy", "no-referrer")
+	c.Data(http.StatusOK, archiveContentType, export.Archive)
+}
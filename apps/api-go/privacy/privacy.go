@@ -0,0 +1,62 @@
+package privacy
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const tokenBytes = 32
+
+// Export is a downloadable archive of a user's personal data. Only a hash of the
+// download token is stored, so the URL is shown once when the export is requested.
+type Export struct {
+	ID           uuid.UUID      `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	UserID       uuid.UUID      `gorm:"type:uuid;not null;index"`
+	TokenHash    string         `gorm:"column:token_hash;not null;uniqueIndex"`
+	Archive      []byte         `gorm:"column:archive;type:bytea;not null"` // ZIP archive of JSON files
+	ExpiresAt    time.Time      `gorm:"column:expires_at;not null"`
+	DownloadedAt *time.Time     `gorm:"column:downloaded_at"`
+	CreatedAt    time.Time      `gorm:"column:created_at"`
+	UpdatedAt    time.Time      `gorm:"column:updated_at"`
+	DeletedAt    gorm.DeletedAt `gorm:"column:deleted_at;index"`
+
+	URL *string `gorm:"-"` // Only populated right after the export is created
+}
+
+// TableName specifies the table name for the Export model
+func (Export) TableName() string {
+	return "data_exports"
+}
+
+// IsExpired checks if the export's download link has expired
+func (e *Export) IsExpired(now time.Time) bool {
+	return !now.Before(e.ExpiresAt)
+}
+
+// Erasure is a pending request to erase a user's personal data. It can be cancelled
+// until it is due.
+type Erasure struct {
+	RequestedAt time.Time
+	DueAt       time.Time
+}
+
+// generateToken returns a random URL-safe token and its hash
+func generateToken() (string, string, error) {
+	raw := make([]byte, tokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+	return token, hashToken(token), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,40 @@
+package privacy
+
+import (
+	"context"
+)
+
+// Resolver handles GraphQL resolver operations for data exports and account erasure
+type Resolver struct {
+	Service *Service
+}
+
+// NewResolver creates a new privacy resolver
+func NewResolver(service *Service) *Resolver {
+	return &Resolver{
+		Service: service,
+	}
+}
+
+// MyAccountErasure returns the current user's pending erasure request, if any
+func (r *Resolver) MyAccountErasure(ctx context.Context) (*Erasure, error) {
+	return r.Service.GetErasure(ctx)
+}
+
+// RequestMyDataExport assembles the current user's personal data into a downloadable archive
+func (r *Resolver) RequestMyDataExport(ctx context.Context) (*Export, error) {
+	return r.Service.RequestExport(ctx)
+}
+
+// RequestAccountErasure schedules the erasure of the current user's personal data
+func (r *Resolver) RequestAccountErasure(ctx context.Context) (*Erasure, error) {
+	return r.Service.RequestErasure(ctx)
+}
+
+// CancelAccountErasure withdraws the current user's pending erasure request
+func (r *Resolver) CancelAccountErasure(ctx context.Context) (bool, error) {
+	if err := r.Service.CancelErasure(ctx); err != nil {
+		return false, err
+	}
+	return true, nil
+}
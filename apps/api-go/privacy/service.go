@@ -0,0 +1,168 @@
+package privacy
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"eztrip/api-go/accesstoken"
+	appErrors "eztrip/api-go/errors"
+	"eztrip/api-go/identity"
+	"eztrip/api-go/importer"
+	"eztrip/api-go/logger"
+	"eztrip/api-go/user"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	envPublicURL     = "PUBLIC_API_URL"
+	defaultPublicURL = "http://localhost:8080"
+
+	envErasureGraceDays     = "ACCOUNT_ERASURE_GRACE_DAYS"
+	defaultErasureGraceDays = 30
+
+	// ExportPathPrefix is where export archives are downloaded, authenticated by their token alone
+	ExportPathPrefix = "/exports/"
+
+	// exportLifetime is how long an export's download link works
+	exportLifetime = 24 * time.Hour
+
+	dateFormat = "2006-01-02"
+)
+
+// Service exports a user's personal data and erases it on request
+type Service struct {
+	db            *gorm.DB
+	importService *importer.Service
+	directory     identity.Directory
+	publicURL     string
+	gracePeriod   time.Duration
+}
+
+// NewService creates a new privacy service
+func NewService(db *gorm.DB, importService *importer.Service) *Service {
+	publicURL := strings.TrimRight(strings.TrimSpace(os.Getenv(envPublicURL)), "/")
+	if publicURL == "" {
+		publicURL = defaultPublicURL
+	}
+
+	graceDays := defaultErasureGraceDays
+	if value := strings.TrimSpace(os.Getenv(envErasureGraceDays)); value != "" {
+		if days, err := strconv.Atoi(value); err == nil && days >= 0 {
+			graceDays = days
+		} else {
+			logger.Log.WithField("value", value).Warn("Invalid ACCOUNT_ERASURE_GRACE_DAYS, using the default")
+		}
+	}
+
+	directory, err := identity.NewDefaultDirectory()
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to initialize identity provider user directory")
+		// Continue without a directory - erasing users with a provider account will fail
+	}
+
+	return &Service{
+		db:            db,
+		importService: importService,
+		directory:     directory,
+		publicURL:     publicURL,
+		gracePeriod:   time.Duration(graceDays) * 24 * time.Hour,
+	}
+}
+
+// RequestExport assembles the current user's personal data into an archive that can be
+// downloaded from the returned URL for a day
+func (s *Service) RequestExport(ctx context.Context) (*Export, error) {
+	current, userID, err := user.GetAuthenticatedUser(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+
+	archive, err := s.buildArchive(ctx, current)
+	if err != nil {
+		return nil, err
+	}
+
+	token, tokenHash, err := generateToken()
+	if err != nil {
+		logger.Log.WithField("error", err.Error()).Error("Failed to generate data export token")
+		return nil, appErrors.Internal("Failed to export data")
+	}
+
+	export := &Export{
+		UserID:    userID,
+		TokenHash: tokenHash,
+		Archive:   archive,
+		ExpiresAt: time.Now().Add(exportLifetime),
+	}
+
+	if err := s.db.WithContext(ctx).Create(export).Error; err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"user_id": userID,
+			"error":   err.Error(),
+		}).Error("Failed to save data export")
+		return nil, appErrors.Internal("Failed to export data")
+	}
+
+	url := s.publicURL + ExportPathPrefix + token
+	export.URL = &url
+
+	logger.Log.WithFields(logrus.Fields{
+		"user_id":        userID,
+		"data_export_id": export.ID,
+		"size":           len(archive),
+	}).Info("Data export created successfully")
+
+	return export, nil
+}
+
+// ResolveExport finds the unexpired export behind a download token. Expired exports
+// are reported as not found so they can't be told apart from made-up tokens.
+func (s *Service) ResolveExport(ctx context.Context, token string) (*Export, error) {
+	var export Export
+	if err := s.db.WithContext(ctx).First(&export, "token_hash = ?", hashToken(token)).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, appErrors.NotFound("Data export")
+		}
+		logger.Log.WithField("error", err.Error()).Error("Failed to fetch data export")
+		return nil, appErrors.Internal("Failed to download data export")
+	}
+
+	if export.IsExpired(time.Now()) {
+		return nil, appErrors.NotFound("Data export")
+	}
+
+	// Download tracking is best effort and shouldn't fail the download
+	err := s.db.WithContext(ctx).Model(&export).UpdateColumn("downloaded_at", time.Now()).Error
+	if err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"data_export_id": export.ID,
+			"error":          err.Error(),
+		}).Warn("Failed to record data export download")
+	}
+
+	return &export, nil
+}
+
+// DeleteExpiredExports removes the archives of exports whose download link has expired
+func (s *Service) DeleteExpiredExports(ctx context.Context, now time.Time) (int64, error) {
+	result := s.db.WithContext(ctx).Unscoped().Where("expires_at <= ?", now).Delete(&Export{})
+	if result.Error != nil {
+		logger.Log.WithField("error", result.Error.Error()).Error("Failed to delete expired data exports")
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
+// requireInteractive refuses requests made with an access token, for actions only the
+// account holder should take
+func requireInteractive(ctx context.Context, message string) error {
+	if accesstoken.FromContext(ctx) != nil {
+		return appErrors.Forbidden(message)
+	}
+	return nil
+}
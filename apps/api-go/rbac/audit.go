@@ -0,0 +1,68 @@
+package rbac
+
+import (
+	"fmt"
+	"time"
+
+	"eztrip/api-go/logger"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Change is the kind of change an audit entry records
+type Change string
+
+const (
+	ChangePolicyAdded    Change = "policy_added"
+	ChangePolicyRemoved  Change = "policy_removed"
+	ChangeRoleAssigned   Change = "role_assigned"
+	ChangeRoleUnassigned Change = "role_unassigned"
+)
+
+// AuditEntry records a change to policies or role assignments and who made it.
+// Policy changes set Resource and Action; role assignment changes set UserID.
+type AuditEntry struct {
+	ID        uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	ActorID   *uuid.UUID `gorm:"type:uuid;index"` // Nullable - cleared if the actor is deleted
+	Change    Change     `gorm:"column:change;not null"`
+	Role      string     `gorm:"column:role;not null"`
+	Resource  *string    `gorm:"column:resource"`
+	Action    *string    `gorm:"column:action"`
+	UserID    *uuid.UUID `gorm:"type:uuid"` // Nullable - the user a role was assigned to or removed from
+	CreatedAt time.Time  `gorm:"column:created_at"`
+}
+
+// TableName specifies the table name for the AuditEntry model
+func (AuditEntry) TableName() string {
+	return "rbac_audit_log"
+}
+
+// ApplyAudited records audit entries and applies the change they describe to the
+// enforcer in one database transaction. The enforcer saves to its own connection, so
+// undo reverts the change if the entries can't be committed.
+func ApplyAudited(db *gorm.DB, entries []AuditEntry, apply func() error, undo func() error) error {
+	applied := false
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if len(entries) > 0 {
+			if err := tx.Create(&entries).Error; err != nil {
+				return fmt.Errorf("failed to record audit entries: %w", err)
+			}
+		}
+		if err := apply(); err != nil {
+			return err
+		}
+		applied = true
+		return nil
+	})
+
+	if err != nil && applied {
+		if undoErr := undo(); undoErr != nil {
+			logger.Log.WithFields(map[string]interface{}{
+				"component": "rbac",
+				"error":     undoErr.Error(),
+			}).Error("Failed to undo unaudited policy change")
+		}
+	}
+	return err
+}
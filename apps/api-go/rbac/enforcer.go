@@ -22,6 +22,18 @@ var (
 	ErrEnforcerNotFound = errors.New("enforcer not found in context")
 )
 
+// DefaultRole is the role given to users provisioned on their first login
+const DefaultRole = "user"
+
+// Wildcard matches any resource or action in a policy
+const Wildcard = "*"
+
+// Resources are the objects policies can grant access to
+var Resources = []string{"users", "user", "currentUser"}
+
+// Actions are what policies can allow on a resource
+var Actions = []string{"read", "write", "delete"}
+
 var DefaultPolicies = [][]string{
 	{"admin", "*", "*"},
 	{"user", "users", "read"},
@@ -32,21 +44,21 @@ var DefaultPolicies = [][]string{
 type enforcerContextKey struct{}
 
 // GetEnforcerFromContext retrieves the Casbin enforcer from the request context.
-func GetEnforcerFromContext(ctx context.Context) (*casbin.Enforcer, error) {
-	if enforcer, ok := ctx.Value(enforcerContextKey{}).(*casbin.Enforcer); ok {
+func GetEnforcerFromContext(ctx context.Context) (*casbin.SyncedEnforcer, error) {
+	if enforcer, ok := ctx.Value(enforcerContextKey{}).(*casbin.SyncedEnforcer); ok {
 		return enforcer, nil
 	}
 	return nil, ErrEnforcerNotFound
 }
 
 // SetEnforcerInContext stores the Casbin enforcer in the request context.
-func SetEnforcerInContext(ctx context.Context, enforcer *casbin.Enforcer) context.Context {
+func SetEnforcerInContext(ctx context.Context, enforcer *casbin.SyncedEnforcer) context.Context {
 	return context.WithValue(ctx, enforcerContextKey{}, enforcer)
 }
 
 // NewEnforcer creates a new Casbin enforcer using the official GORM adapter.
 // This stores both policies and role assignments in the database.
-func NewEnforcer(db *gorm.DB) (*casbin.Enforcer, error) {
+func NewEnforcer(db *gorm.DB) (*casbin.SyncedEnforcer, error) {
 	modelPath, err := getModelPath()
 	if err != nil {
 		logger.Log.WithFields(map[string]interface{}{
@@ -65,7 +77,7 @@ func NewEnforcer(db *gorm.DB) (*casbin.Enforcer, error) {
 		return nil, fmt.Errorf("failed to create GORM adapter: %w", err)
 	}
 
-	enforcer, err := casbin.NewEnforcer(modelPath, adapter)
+	enforcer, err := casbin.NewSyncedEnforcer(modelPath, adapter)
 	if err != nil {
 		logger.Log.WithFields(map[string]interface{}{
 			"component":  "rbac",
@@ -77,6 +89,15 @@ func NewEnforcer(db *gorm.DB) (*casbin.Enforcer, error) {
 
 	enforcer.EnableAutoSave(true)
 
+	// Announce changes to other instances and reload the ones they make
+	if err := enforcer.SetWatcher(NewWatcher(db)); err != nil {
+		logger.Log.WithFields(map[string]interface{}{
+			"component": "rbac",
+			"error":     err.Error(),
+		}).Error("Failed to set Casbin policy watcher")
+		return nil, fmt.Errorf("failed to set watcher: %w", err)
+	}
+
 	return enforcer, nil
 }
 
@@ -129,7 +150,7 @@ func CheckPermission(ctx context.Context, userID, resource, action string) error
 // InitializePolicies sets up the initial policy rules in the database.
 // This should be called once during application setup if the casbin_rule table is empty.
 // Supports wildcards (*) for resources and actions.
-func InitializePolicies(enforcer *casbin.Enforcer) error {
+func InitializePolicies(enforcer *casbin.SyncedEnforcer) error {
 	existingPolicies, _ := enforcer.GetPolicy()
 	if len(existingPolicies) > 0 {
 		logger.Log.WithFields(map[string]interface{}{
@@ -158,7 +179,7 @@ func InitializePolicies(enforcer *casbin.Enforcer) error {
 }
 
 // AddRoleForUser adds a role assignment for a user (auto-saved to DB).
-func AddRoleForUser(enforcer *casbin.Enforcer, userID, role string) error {
+func AddRoleForUser(enforcer *casbin.SyncedEnforcer, userID, role string) error {
 	if _, err := enforcer.AddRoleForUser(userID, role); err != nil {
 		logger.Log.WithFields(map[string]interface{}{
 			"component": "rbac",
@@ -177,7 +198,7 @@ func AddRoleForUser(enforcer *casbin.Enforcer, userID, role string) error {
 }
 
 // RemoveRoleForUser removes a role assignment from a user (auto-saved to DB).
-func RemoveRoleForUser(enforcer *casbin.Enforcer, userID, role string) error {
+func RemoveRoleForUser(enforcer *casbin.SyncedEnforcer, userID, role string) error {
 	if _, err := enforcer.DeleteRoleForUser(userID, role); err != nil {
 		logger.Log.WithFields(map[string]interface{}{
 			"component": "rbac",
@@ -196,7 +217,7 @@ func RemoveRoleForUser(enforcer *casbin.Enforcer, userID, role string) error {
 }
 
 // UpdateUserRole updates a user's role by removing all existing roles and adding the new one.
-func UpdateUserRole(enforcer *casbin.Enforcer, userID, newRole string) error {
+func UpdateUserRole(enforcer *casbin.SyncedEnforcer, userID, newRole string) error {
 	if _, err := enforcer.DeleteRolesForUser(userID); err != nil {
 		logger.Log.WithFields(map[string]interface{}{
 			"component": "rbac",
@@ -225,8 +246,22 @@ func UpdateUserRole(enforcer *casbin.Enforcer, userID, newRole string) error {
 	return nil
 }
 
+// SetRolesForUser replaces a user's roles with the given ones, which may be none.
+func SetRolesForUser(enforcer *casbin.SyncedEnforcer, userID string, roles []string) error {
+	if _, err := enforcer.DeleteRolesForUser(userID); err != nil {
+		return fmt.Errorf("failed to remove existing roles: %w", err)
+	}
+	if len(roles) == 0 {
+		return nil
+	}
+	if _, err := enforcer.AddRolesForUser(userID, roles); err != nil {
+		return fmt.Errorf("failed to add roles: %w", err)
+	}
+	return nil
+}
+
 // GetRolesForUser returns all roles assigned to a user.
-func GetRolesForUser(enforcer *casbin.Enforcer, userID string) ([]string, error) {
+func GetRolesForUser(enforcer *casbin.SyncedEnforcer, userID string) ([]string, error) {
 	roles, err := enforcer.GetRolesForUser(userID)
 	if err != nil {
 		logger.Log.WithFields(map[string]interface{}{
@@ -240,12 +275,27 @@ func GetRolesForUser(enforcer *casbin.Enforcer, userID string) ([]string, error)
 }
 
 // HasRole checks if a user has a specific role.
-func HasRole(enforcer *casbin.Enforcer, userID, role string) (bool, error) {
+func HasRole(enforcer *casbin.SyncedEnforcer, userID, role string) (bool, error) {
 	return enforcer.HasRoleForUser(userID, role)
 }
 
+// RoleExists checks if a role is granted permissions by any policy, so users are only
+// assigned roles that mean something.
+func RoleExists(enforcer *casbin.SyncedEnforcer, role string) (bool, error) {
+	subjects, err := enforcer.GetAllSubjects()
+	if err != nil {
+		return false, fmt.Errorf("failed to get policy subjects: %w", err)
+	}
+	for _, subject := range subjects {
+		if subject == role {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // GetUsersForRole returns all User UUIDs with a specific role.
-func GetUsersForRole(enforcer *casbin.Enforcer, role string) ([]string, error) {
+func GetUsersForRole(enforcer *casbin.SyncedEnforcer, role string) ([]string, error) {
 	users, err := enforcer.GetUsersForRole(role)
 	if err != nil {
 		logger.Log.WithFields(map[string]interface{}{
@@ -258,25 +308,27 @@ func GetUsersForRole(enforcer *casbin.Enforcer, role string) ([]string, error) {
 	return users, nil
 }
 
-// RequireAdminRole checks if the user has the admin role
-func RequireAdminRole(ctx context.Context, userUUID uuid.UUID) error {
+// RequireRole checks if the user has a role, directly or through roles it inherits
+func RequireRole(ctx context.Context, userUUID uuid.UUID, role string) error {
 	enforcer, err := GetEnforcerFromContext(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get RBAC enforcer: %w", err)
 	}
 
-	hasRole, err := HasRole(enforcer, userUUID.String(), "admin")
+	roles, err := enforcer.GetImplicitRolesForUser(userUUID.String())
 	if err != nil {
 		return fmt.Errorf("failed to check user role: %w", err)
 	}
 
-	if !hasRole {
-		logger.Log.WithFields(logrus.Fields{
-			"user_id": userUUID.String(),
-			"role":    "admin",
-		}).Warn("Unauthorized admin access attempt")
-		return appErrors.Forbidden("You do not have permission to perform this action")
+	for _, assigned := range roles {
+		if assigned == role {
+			return nil
+		}
 	}
 
-	return nil
+	logger.Log.WithFields(logrus.Fields{
+		"user_id": userUUID.String(),
+		"role":    role,
+	}).Warn("Access denied - missing required role")
+	return appErrors.Forbidden("You do not have permission to perform this action")
 }
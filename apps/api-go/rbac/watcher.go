@@ -0,0 +1,161 @@
+package rbac
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"sync"
+	"time"
+
+	"eztrip/api-go/logger"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	// policyChannel is the Postgres notification channel policy changes are announced on
+	policyChannel = "rbac_policy_changed"
+
+	// listenRetryDelay is how long the watcher waits before listening again after losing
+	// its connection
+	listenRetryDelay = 5 * time.Second
+)
+
+// Watcher keeps the enforcers of all API instances in sync. Casbin calls Update after
+// each change to policies or role assignments, which announces it with a Postgres
+// notification; every other instance reloads its policy when it hears one. Listening
+// holds one connection of the pool for as long as the watcher runs.
+type Watcher struct {
+	db         *gorm.DB
+	instanceID string
+	start      sync.Once
+	cancel     context.CancelFunc
+	ctx        context.Context
+
+	mu       sync.RWMutex
+	callback func(string)
+}
+
+// NewWatcher creates a watcher that listens for policy changes once Casbin sets its
+// update callback
+func NewWatcher(db *gorm.DB) *Watcher {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Watcher{
+		db:         db,
+		instanceID: uuid.NewString(),
+		ctx:        ctx,
+		cancel:     cancel,
+	}
+}
+
+// SetUpdateCallback sets the function that reloads the policy when another instance
+// changes it, and starts listening
+func (w *Watcher) SetUpdateCallback(callback func(string)) error {
+	w.mu.Lock()
+	w.callback = callback
+	w.mu.Unlock()
+
+	w.start.Do(func() {
+		go w.listen()
+	})
+	return nil
+}
+
+// Update announces a change made by this instance to the others. Casbin calls it once
+// the change is saved, so a failed announcement is logged rather than failing the change.
+func (w *Watcher) Update() error {
+	err := w.db.Exec("SELECT pg_notify(?, ?)", policyChannel, w.instanceID).Error
+	if err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"component": "rbac",
+			"error":     err.Error(),
+		}).Error("Failed to announce policy change")
+	}
+	return nil
+}
+
+// Close stops listening for policy changes
+func (w *Watcher) Close() {
+	w.cancel()
+}
+
+// listen waits for notifications until the watcher is closed, listening again after
+// connection failures. Changes missed while disconnected are picked up by reloading
+// the policy once listening resumes.
+func (w *Watcher) listen() {
+	reconnected := false
+	for w.ctx.Err() == nil {
+		err := w.listenOnce(reconnected)
+		if w.ctx.Err() != nil {
+			return
+		}
+
+		logger.Log.WithFields(logrus.Fields{
+			"component": "rbac",
+			"error":     err.Error(),
+		}).Warn("Stopped listening for policy changes, retrying")
+		reconnected = true
+
+		select {
+		case <-w.ctx.Done():
+			return
+		case <-time.After(listenRetryDelay):
+		}
+	}
+}
+
+// listenOnce listens on a connection taken from the pool until it fails. The
+// connection is discarded afterwards rather than returned to the pool still listening.
+func (w *Watcher) listenOnce(reload bool) error {
+	sqlDB, err := w.db.DB()
+	if err != nil {
+		return err
+	}
+	conn, err := sqlDB.Conn(w.ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return conn.Raw(func(driverConn any) error {
+		stdConn, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return errors.Join(errors.New("policy change notifications need a pgx connection"), driver.ErrBadConn)
+		}
+		pgConn := stdConn.Conn()
+
+		if _, err := pgConn.Exec(w.ctx, "LISTEN "+policyChannel); err != nil {
+			return errors.Join(err, driver.ErrBadConn)
+		}
+		if reload {
+			w.notify("")
+		}
+
+		for {
+			notification, err := pgConn.WaitForNotification(w.ctx)
+			if err != nil {
+				return errors.Join(err, driver.ErrBadConn)
+			}
+			if notification.Payload != w.instanceID {
+				w.notify(notification.Payload)
+			}
+		}
+	})
+}
+
+func (w *Watcher) notify(instanceID string) {
+	w.mu.RLock()
+	callback := w.callback
+	w.mu.RUnlock()
+
+	if callback != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"component":   "rbac",
+			"instance_id": instanceID,
+		}).Info("Reloading policy after a change by another instance")
+		callback(instanceID)
+	}
+}
@@ -0,0 +1,82 @@
+package rbacadmin
+
+import (
+	"regexp"
+	"slices"
+
+	"eztrip/api-go/pagination"
+	"eztrip/api-go/rbac"
+
+	"github.com/google/uuid"
+)
+
+// rolePattern keeps role names distinct from the user UUIDs that share Casbin's subject field
+var rolePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]*$`)
+
+// Policy lets a role take an action on a resource; either may be the wildcard
+type Policy struct {
+	Role     string
+	Resource string
+	Action   string
+}
+
+// RoleAssignment gives a user a role
+type RoleAssignment struct {
+	UserID uuid.UUID
+	Role   string
+}
+
+// PolicyInput names a policy to add or remove
+type PolicyInput struct {
+	Role     string `json:"role" validate:"required,max=50"`
+	Resource string `json:"resource" validate:"required,max=100"`
+	Action   string `json:"action" validate:"required,max=100"`
+}
+
+// RoleAssignmentInput names a role assignment to add or remove
+type RoleAssignmentInput struct {
+	UserID string `json:"userId" validate:"required,uuid"`
+	Role   string `json:"role" validate:"required,max=50"`
+}
+
+// RoleAssignmentFilter narrows the role assignment list
+type RoleAssignmentFilter struct {
+	Role   *string `json:"role" validate:"omitempty,max=50"`
+	UserID *string `json:"userId" validate:"omitempty,uuid"`
+}
+
+// AuditConnection is a page of audit entries
+type AuditConnection struct {
+	Edges      []*AuditEdge
+	PageInfo   *pagination.PageInfo
+	TotalCount int
+}
+
+// AuditEdge is an audit entry with its cursor
+type AuditEdge struct {
+	Cursor string
+	Node   *rbac.AuditEntry
+}
+
+// Nodes returns the audit entries of the page without their cursors
+func (c *AuditConnection) Nodes() []*rbac.AuditEntry {
+	nodes := make([]*rbac.AuditEntry, len(c.Edges))
+	for i, edge := range c.Edges {
+		nodes[i] = edge.Node
+	}
+	return nodes
+}
+
+// isKnown checks if a policy names a resource or action the API enforces, or the wildcard
+func isKnown(known []string, value string) bool {
+	return value == rbac.Wildcard || slices.Contains(known, value)
+}
+
+// isValidRoleName checks that a role name can't be mistaken for a user ID
+func isValidRoleName(role string) bool {
+	if !rolePattern.MatchString(role) {
+		return false
+	}
+	_, err := uuid.Parse(role)
+	return err != nil
+}
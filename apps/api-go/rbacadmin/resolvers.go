@@ -0,0 +1,96 @@
+package rbacadmin
+
+import (
+	"context"
+
+	"eztrip/api-go/pagination"
+	"eztrip/api-go/rbac"
+	"eztrip/api-go/validation"
+)
+
+// Resolver handles GraphQL resolver operations for RBAC administration. Every
+// operation is admin only, enforced by @hasRole in the schema.
+type Resolver struct {
+	Service *Service
+}
+
+// NewResolver creates a new RBAC administration resolver
+func NewResolver(service *Service) *Resolver {
+	return &Resolver{
+		Service: service,
+	}
+}
+
+// Policies returns the policies, optionally only those of a role
+func (r *Resolver) Policies(ctx context.Context, role *string) ([]*Policy, error) {
+	return r.Service.ListPolicies(ctx, role)
+}
+
+// RoleAssignments returns the role assignments matching the filter
+func (r *Resolver) RoleAssignments(ctx context.Context, filter *RoleAssignmentFilter) ([]*RoleAssignment, error) {
+	if filter == nil {
+		filter = &RoleAssignmentFilter{}
+	}
+	if err := validation.ValidateStruct(*filter); err != nil {
+		return nil, err
+	}
+
+	return r.Service.ListRoleAssignments(ctx, *filter)
+}
+
+// Resources returns the resources policies can name besides the wildcard
+func (r *Resolver) Resources(ctx context.Context) []string {
+	return rbac.Resources
+}
+
+// Actions returns the actions policies can name besides the wildcard
+func (r *Resolver) Actions(ctx context.Context) []string {
+	return rbac.Actions
+}
+
+// AuditLog returns a page of changes to policies and role assignments
+func (r *Resolver) AuditLog(ctx context.Context, first *int32, after *string) (*AuditConnection, error) {
+	return r.Service.AuditLog(ctx, pagination.Params{First: first, After: after})
+}
+
+// AddPolicy grants a role an action on a resource
+func (r *Resolver) AddPolicy(ctx context.Context, input PolicyInput) (*Policy, error) {
+	if err := validation.ValidateStruct(input); err != nil {
+		return nil, err
+	}
+
+	return r.Service.AddPolicy(ctx, input)
+}
+
+// RemovePolicy revokes a policy
+func (r *Resolver) RemovePolicy(ctx context.Context, input PolicyInput) (bool, error) {
+	if err := validation.ValidateStruct(input); err != nil {
+		return false, err
+	}
+
+	if err := r.Service.RemovePolicy(ctx, input); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// AssignRole gives a user a role
+func (r *Resolver) AssignRole(ctx context.Context, input RoleAssignmentInput) (*RoleAssignment, error) {
+	if err := validation.ValidateStruct(input); err != nil {
+		return nil, err
+	}
+
+	return r.Service.AssignRole(ctx, input)
+}
+
+// UnassignRole takes a role from a user
+func (r *Resolver) UnassignRole(ctx context.Context, input RoleAssignmentInput) (bool, error) {
+	if err := validation.ValidateStruct(input); err != nil {
+		return false, err
+	}
+
+	if err := r.Service.UnassignRole(ctx, input); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,386 @@
+package rbacadmin
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	appErrors "eztrip/api-go/errors"
+	"eztrip/api-go/logger"
+	"eztrip/api-go/pagination"
+	"eztrip/api-go/rbac"
+	"eztrip/api-go/user"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// auditOrder lists the newest changes first
+var auditOrder = pagination.Order{Column: "rbac_audit_log.created_at", Kind: pagination.KindTime, Direction: pagination.Desc}
+
+// Service lets admins manage RBAC policies and role assignments. Every change is
+// recorded in the audit log, and the enforcer's watcher reloads it on other instances.
+type Service struct {
+	db *gorm.DB
+}
+
+// NewService creates a new RBAC administration service
+func NewService(db *gorm.DB) *Service {
+	return &Service{db: db}
+}
+
+// ListPolicies returns the policies, optionally only those of a role, sorted by role,
+// resource and action
+func (s *Service) ListPolicies(ctx context.Context, role *string) ([]*Policy, error) {
+	enforcer, err := rbac.GetEnforcerFromContext(ctx)
+	if err != nil {
+		return nil, appErrors.Internal("Failed to list policies")
+	}
+
+	var rules [][]string
+	if role != nil {
+		rules, err = enforcer.GetFilteredPolicy(0, *role)
+	} else {
+		rules, err = enforcer.GetPolicy()
+	}
+	if err != nil {
+		logger.Log.WithField("error", err.Error()).Error("Failed to list policies")
+		return nil, appErrors.Internal("Failed to list policies")
+	}
+
+	policies := make([]*Policy, 0, len(rules))
+	for _, rule := range rules {
+		if len(rule) < 3 {
+			continue
+		}
+		policies = append(policies, &Policy{Role: rule[0], Resource: rule[1], Action: rule[2]})
+	}
+	slices.SortFunc(policies, func(a, b *Policy) int {
+		return strings.Compare(a.Role+"\x00"+a.Resource+"\x00"+a.Action, b.Role+"\x00"+b.Resource+"\x00"+b.Action)
+	})
+
+	return policies, nil
+}
+
+// ListRoleAssignments returns the role assignments, optionally only those of a role or
+// a user, sorted by role and user
+func (s *Service) ListRoleAssignments(ctx context.Context, filter RoleAssignmentFilter) ([]*RoleAssignment, error) {
+	enforcer, err := rbac.GetEnforcerFromContext(ctx)
+	if err != nil {
+		return nil, appErrors.Internal("Failed to list role assignments")
+	}
+
+	// Empty filter values match anything
+	userField, roleField := "", ""
+	if filter.UserID != nil {
+		userField = *filter.UserID
+	}
+	if filter.Role != nil {
+		roleField = *filter.Role
+	}
+
+	rules, err := enforcer.GetFilteredGroupingPolicy(0, userField, roleField)
+	if err != nil {
+		logger.Log.WithField("error", err.Error()).Error("Failed to list role assignments")
+		return nil, appErrors.Internal("Failed to list role assignments")
+	}
+
+	assignments := make([]*RoleAssignment, 0, len(rules))
+	for _, rule := range rules {
+		if len(rule) < 2 {
+			continue
+		}
+		// Only users are assigned roles; other subjects would be roles inheriting roles
+		userID, err := uuid.Parse(rule[0])
+		if err != nil {
+			continue
+		}
+		assignments = append(assignments, &RoleAssignment{UserID: userID, Role: rule[1]})
+	}
+	slices.SortFunc(assignments, func(a, b *RoleAssignment) int {
+		if c := strings.Compare(a.Role, b.Role); c != 0 {
+			return c
+		}
+		return strings.Compare(a.UserID.String(), b.UserID.String())
+	})
+
+	return assignments, nil
+}
+
+// AddPolicy grants a role an action on a resource. Policies may only name resources
+// and actions the API enforces, or the wildcard.
+func (s *Service) AddPolicy(ctx context.Context, input PolicyInput) (*Policy, error) {
+	if err := validatePolicy(input); err != nil {
+		return nil, err
+	}
+
+	actor, enforcer, err := s.prepareChange(ctx, "Failed to add policy")
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := enforcer.HasPolicy(input.Role, input.Resource, input.Action)
+	if err != nil {
+		return nil, appErrors.Internal("Failed to add policy")
+	}
+	if exists {
+		return nil, appErrors.ValidationError("role", "Policy already exists")
+	}
+
+	entry := policyEntry(actor, rbac.ChangePolicyAdded, input)
+	err = rbac.ApplyAudited(s.db.WithContext(ctx), []rbac.AuditEntry{entry},
+		func() error {
+			_, err := enforcer.AddPolicy(input.Role, input.Resource, input.Action)
+			return err
+		},
+		func() error {
+			_, err := enforcer.RemovePolicy(input.Role, input.Resource, input.Action)
+			return err
+		},
+	)
+	if err != nil {
+		logChangeFailure(actor, "Failed to add policy", err)
+		return nil, appErrors.Internal("Failed to add policy")
+	}
+
+	logger.Log.WithFields(logrus.Fields{
+		"actor_id": actor,
+		"role":     input.Role,
+		"resource": input.Resource,
+		"action":   input.Action,
+	}).Info("Policy added")
+
+	return &Policy{Role: input.Role, Resource: input.Resource, Action: input.Action}, nil
+}
+
+// RemovePolicy revokes a policy. A role's last policy can't be removed while users
+// are assigned the role, so every assigned role keeps meaning something.
+func (s *Service) RemovePolicy(ctx context.Context, input PolicyInput) error {
+	actor, enforcer, err := s.prepareChange(ctx, "Failed to remove policy")
+	if err != nil {
+		return err
+	}
+
+	exists, err := enforcer.HasPolicy(input.Role, input.Resource, input.Action)
+	if err != nil {
+		return appErrors.Internal("Failed to remove policy")
+	}
+	if !exists {
+		return appErrors.NotFound("Policy")
+	}
+
+	rolePolicies, err := enforcer.GetFilteredPolicy(0, input.Role)
+	if err != nil {
+		return appErrors.Internal("Failed to remove policy")
+	}
+	if len(rolePolicies) == 1 {
+		users, err := enforcer.GetUsersForRole(input.Role)
+		if err != nil {
+			return appErrors.Internal("Failed to remove policy")
+		}
+		if len(users) > 0 {
+			return appErrors.ValidationError("role", fmt.Sprintf(
+				"This is the last policy of a role assigned to %d users; remove their assignments first", len(users),
+			))
+		}
+	}
+
+	entry := policyEntry(actor, rbac.ChangePolicyRemoved, input)
+	err = rbac.ApplyAudited(s.db.WithContext(ctx), []rbac.AuditEntry{entry},
+		func() error {
+			_, err := enforcer.RemovePolicy(input.Role, input.Resource, input.Action)
+			return err
+		},
+		func() error {
+			_, err := enforcer.AddPolicy(input.Role, input.Resource, input.Action)
+			return err
+		},
+	)
+	if err != nil {
+		logChangeFailure(actor, "Failed to remove policy", err)
+		return appErrors.Internal("Failed to remove policy")
+	}
+
+	logger.Log.WithFields(logrus.Fields{
+		"actor_id": actor,
+		"role":     input.Role,
+		"resource": input.Resource,
+		"action":   input.Action,
+	}).Info("Policy removed")
+
+	return nil
+}
+
+// AssignRole gives a user a role that policies grant permissions to, in addition to
+// the roles they have
+func (s *Service) AssignRole(ctx context.Context, input RoleAssignmentInput) (*RoleAssignment, error) {
+	userID, err := uuid.Parse(input.UserID)
+	if err != nil {
+		return nil, appErrors.ValidationError("userId", "userId must be a valid ID")
+	}
+
+	actor, enforcer, err := s.prepareChange(ctx, "Failed to assign role")
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := rbac.RoleExists(enforcer, input.Role)
+	if err != nil {
+		return nil, appErrors.Internal("Failed to assign role")
+	}
+	if !exists {
+		return nil, appErrors.ValidationError("role", "Unknown role")
+	}
+
+	if err := s.db.WithContext(ctx).Select("id").First(&user.User{}, "id = ?", userID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, appErrors.NotFound("User")
+		}
+		logger.Log.WithFields(logrus.Fields{
+			"user_id": userID,
+			"error":   err.Error(),
+		}).Error("Failed to fetch user by ID")
+		return nil, appErrors.Internal("Failed to assign role")
+	}
+
+	subject := userID.String()
+	assigned, err := enforcer.HasRoleForUser(subject, input.Role)
+	if err != nil {
+		return nil, appErrors.Internal("Failed to assign role")
+	}
+	if assigned {
+		return nil, appErrors.ValidationError("role", "User already has this role")
+	}
+
+	entry := roleEntry(actor, rbac.ChangeRoleAssigned, userID, input.Role)
+	err = rbac.ApplyAudited(s.db.WithContext(ctx), []rbac.AuditEntry{entry},
+		func() error { return rbac.AddRoleForUser(enforcer, subject, input.Role) },
+		func() error { return rbac.RemoveRoleForUser(enforcer, subject, input.Role) },
+	)
+	if err != nil {
+		logChangeFailure(actor, "Failed to assign role", err)
+		return nil, appErrors.Internal("Failed to assign role")
+	}
+
+	return &RoleAssignment{UserID: userID, Role: input.Role}, nil
+}
+
+// UnassignRole takes a role from a user. Admins can't take roles from themselves, so
+// they can't lock themselves out.
+func (s *Service) UnassignRole(ctx context.Context, input RoleAssignmentInput) error {
+	userID, err := uuid.Parse(input.UserID)
+	if err != nil {
+		return appErrors.ValidationError("userId", "userId must be a valid ID")
+	}
+
+	actor, enforcer, err := s.prepareChange(ctx, "Failed to remove role")
+	if err != nil {
+		return err
+	}
+	if userID == actor {
+		return appErrors.ValidationError("userId", "You can't remove your own roles")
+	}
+
+	subject := userID.String()
+	assigned, err := enforcer.HasRoleForUser(subject, input.Role)
+	if err != nil {
+		return appErrors.Internal("Failed to remove role")
+	}
+	if !assigned {
+		return appErrors.NotFound("Role assignment")
+	}
+
+	entry := roleEntry(actor, rbac.ChangeRoleUnassigned, userID, input.Role)
+	err = rbac.ApplyAudited(s.db.WithContext(ctx), []rbac.AuditEntry{entry},
+		func() error { return rbac.RemoveRoleForUser(enforcer, subject, input.Role) },
+		func() error { return rbac.AddRoleForUser(enforcer, subject, input.Role) },
+	)
+	if err != nil {
+		logChangeFailure(actor, "Failed to remove role", err)
+		return appErrors.Internal("Failed to remove role")
+	}
+
+	return nil
+}
+
+// AuditLog returns a page of changes to policies and role assignments, newest first
+func (s *Service) AuditLog(ctx context.Context, params pagination.Params) (*AuditConnection, error) {
+	query := s.db.WithContext(ctx).Model(&rbac.AuditEntry{})
+
+	page, err := pagination.Paginate(query, params, auditOrder, func(entry *rbac.AuditEntry) (any, uuid.UUID) {
+		return entry.CreatedAt, entry.ID
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	connection := &AuditConnection{
+		Edges:      make([]*AuditEdge, len(page.Items)),
+		PageInfo:   &page.PageInfo,
+		TotalCount: int(page.TotalCount),
+	}
+	for i := range page.Items {
+		connection.Edges[i] = &AuditEdge{Cursor: page.Cursors[i], Node: &page.Items[i]}
+	}
+
+	return connection, nil
+}
+
+// prepareChange returns the admin making a change and the enforcer to make it with
+func (s *Service) prepareChange(ctx context.Context, failure string) (uuid.UUID, *casbin.SyncedEnforcer, error) {
+	_, actor, err := user.GetAuthenticatedUser(ctx, s.db)
+	if err != nil {
+		return uuid.Nil, nil, err
+	}
+
+	enforcer, err := rbac.GetEnforcerFromContext(ctx)
+	if err != nil {
+		return uuid.Nil, nil, appErrors.Internal(failure)
+	}
+
+	return actor, enforcer, nil
+}
+
+// validatePolicy checks that a policy names a valid role and a known resource and action
+func validatePolicy(input PolicyInput) error {
+	if !isValidRoleName(input.Role) {
+		return appErrors.ValidationError("role", "Role must start with a letter and contain only letters, digits, hyphens and underscores")
+	}
+	if !isKnown(rbac.Resources, input.Resource) {
+		return appErrors.ValidationError("resource", "resource must be one of: "+rbac.Wildcard+" "+strings.Join(rbac.Resources, " "))
+	}
+	if !isKnown(rbac.Actions, input.Action) {
+		return appErrors.ValidationError("action", "action must be one of: "+rbac.Wildcard+" "+strings.Join(rbac.Actions, " "))
+	}
+	return nil
+}
+
+func policyEntry(actor uuid.UUID, change rbac.Change, input PolicyInput) rbac.AuditEntry {
+	resource, action := input.Resource, input.Action
+	return rbac.AuditEntry{
+		ActorID:  &actor,
+		Change:   change,
+		Role:     input.Role,
+		Resource: &resource,
+		Action:   &action,
+	}
+}
+
+func roleEntry(actor uuid.UUID, change rbac.Change, userID uuid.UUID, role string) rbac.AuditEntry {
+	return rbac.AuditEntry{
+		ActorID: &actor,
+		Change:  change,
+		Role:    role,
+		UserID:  &userID,
+	}
+}
+
+func logChangeFailure(actor uuid.UUID, message string, err error) {
+	logger.Log.WithFields(logrus.Fields{
+		"actor_id": actor,
+		"error":    err.Error(),
+	}).Error(message)
+}
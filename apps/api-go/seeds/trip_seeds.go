@@ -12,6 +12,11 @@ import (
 	"gorm.io/gorm"
 )
 
+const kauaiTimeZone = "Pacific/Honolulu"
+
+// kauaiLocation is used so seeded activity times are local Hawaii times
+var kauaiLocation = trip.LoadLocation(kauaiTimeZone)
+
 // SeedTrips populates the trips, itinerary_days, and activities tables with sample data
 func SeedTrips(db *gorm.DB) error {
 	logger.Log.Info("Seeding trips...")
@@ -62,8 +67,8 @@ func SeedTrips(db *gorm.DB) error {
 }
 
 func createKauaiTrip(ownerID uuid.UUID) trip.Trip {
-	startDate := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
-	endDate := time.Date(2026, 1, 16, 0, 0, 0, 0, time.UTC)
+	startDate := time.Date(2026, 1, 10, 0, 0, 0, 0, kauaiLocation)
+	endDate := time.Date(2026, 1, 16, 0, 0, 0, 0, kauaiLocation)
 
 	return trip.Trip{
 		OwnerID:     ownerID,
@@ -72,6 +77,8 @@ func createKauaiTrip(ownerID uuid.UUID) trip.Trip {
 		StartDate:   startDate,
 		EndDate:     endDate,
 		Travelers:   4,
+		TimeZone:    kauaiTimeZone,
+		IsTemplate:  true, // Published as a starting point for other families
 		Itinerary: []trip.ItineraryDay{
 			createDay1(startDate),
 			createDay2(startDate.AddDate(0, 0, 1)),
@@ -91,7 +98,7 @@ func createDay1(date time.Time) trip.ItineraryDay {
 		Activities: []trip.Activity{
 			{
 				Type:        trip.ActivityTypeTransport,
-				Time:        time.Date(2026, 1, 10, 10, 30, 0, 0, time.UTC),
+				Time:        time.Date(2026, 1, 10, 10, 30, 0, 0, kauaiLocation),
 				Title:       "Arrive at Lihue Airport",
 				Location:    "Lihue Airport (LIH)",
 				Category:    trip.ActivityCategoryTransport,
@@ -100,7 +107,7 @@ func createDay1(date time.Time) trip.ItineraryDay {
 			},
 			{
 				Type:        trip.ActivityTypePlaceBased,
-				Time:        time.Date(2026, 1, 10, 12, 30, 0, 0, time.UTC),
+				Time:        time.Date(2026, 1, 10, 12, 30, 0, 0, kauaiLocation),
 				Title:       "Kalapaki Beach Hut",
 				Location:    "3474 Rice St, Lihue",
 				Category:    trip.ActivityCategoryFood,
@@ -109,7 +116,7 @@ func createDay1(date time.Time) trip.ItineraryDay {
 			},
 			{
 				Type:        trip.ActivityTypePlaceBased,
-				Time:        time.Date(2026, 1, 10, 14, 0, 0, 0, time.UTC),
+				Time:        time.Date(2026, 1, 10, 14, 0, 0, 0, kauaiLocation),
 				Title:       "Kalapaki Beach",
 				Location:    "Kalapaki Beach, Lihue",
 				Category:    trip.ActivityCategoryBeach,
@@ -118,7 +125,7 @@ func createDay1(date time.Time) trip.ItineraryDay {
 			},
 			{
 				Type:        trip.ActivityTypePlaceBased,
-				Time:        time.Date(2026, 1, 10, 18, 0, 0, 0, time.UTC),
+				Time:        time.Date(2026, 1, 10, 18, 0, 0, 0, kauaiLocation),
 				Title:       "Dinner at Hukilau Lanai",
 				Location:    "520 Aleka Loop, Kapaa",
 				Category:    trip.ActivityCategoryFood,
@@ -136,7 +143,7 @@ func createDay2(date time.Time) trip.ItineraryDay {
 		Activities: []trip.Activity{
 			{
 				Type:        trip.ActivityTypePlaceBased,
-				Time:        time.Date(2026, 1, 11, 8, 0, 0, 0, time.UTC),
+				Time:        time.Date(2026, 1, 11, 8, 0, 0, 0, kauaiLocation),
 				Title:       "Breakfast at Tip Top Cafe",
 				Location:    "3173 Akahi St, Lihue",
 				Category:    trip.ActivityCategoryFood,
@@ -145,7 +152,7 @@ func createDay2(date time.Time) trip.ItineraryDay {
 			},
 			{
 				Type:        trip.ActivityTypePlaceBased,
-				Time:        time.Date(2026, 1, 11, 9, 30, 0, 0, time.UTC),
+				Time:        time.Date(2026, 1, 11, 9, 30, 0, 0, kauaiLocation),
 				Title:       "Sleeping Giant Trail (Nounou East)",
 				Location:    "Nounou Trail East, Kapaa",
 				Category:    trip.ActivityCategoryHike,
@@ -154,7 +161,7 @@ func createDay2(date time.Time) trip.ItineraryDay {
 			},
 			{
 				Type:        trip.ActivityTypePlaceBased,
-				Time:        time.Date(2026, 1, 11, 13, 0, 0, 0, time.UTC),
+				Time:        time.Date(2026, 1, 11, 13, 0, 0, 0, kauaiLocation),
 				Title:       "Lunch at Opakapaka Grill",
 				Location:    "4-1543 Kuhio Hwy, Kapaa",
 				Category:    trip.ActivityCategoryFood,
@@ -163,7 +170,7 @@ func createDay2(date time.Time) trip.ItineraryDay {
 			},
 			{
 				Type:        trip.ActivityTypePlaceBased,
-				Time:        time.Date(2026, 1, 11, 15, 0, 0, 0, time.UTC),
+				Time:        time.Date(2026, 1, 11, 15, 0, 0, 0, kauaiLocation),
 				Title:       "Lydgate Beach Park",
 				Location:    "Lydgate State Park, Kapaa",
 				Category:    trip.ActivityCategoryBeach,
@@ -181,7 +188,7 @@ func createDay3(date time.Time) trip.ItineraryDay {
 		Activities: []trip.Activity{
 			{
 				Type:        trip.ActivityTypeTransport,
-				Time:        time.Date(2026, 1, 12, 7, 30, 0, 0, time.UTC),
+				Time:        time.Date(2026, 1, 12, 7, 30, 0, 0, kauaiLocation),
 				Title:       "Drive to North Shore",
 				Location:    "Kapaa to Hanalei",
 				Category:    trip.ActivityCategoryTransport,
@@ -190,7 +197,7 @@ func createDay3(date time.Time) trip.ItineraryDay {
 			},
 			{
 				Type:        trip.ActivityTypePlaceBased,
-				Time:        time.Date(2026, 1, 12, 8, 30, 0, 0, time.UTC),
+				Time:        time.Date(2026, 1, 12, 8, 30, 0, 0, kauaiLocation),
 				Title:       "Breakfast at Hanalei Bread Company",
 				Location:    "5-5161 Kuhio Hwy, Hanalei",
 				Category:    trip.ActivityCategoryFood,
@@ -199,7 +206,7 @@ func createDay3(date time.Time) trip.ItineraryDay {
 			},
 			{
 				Type:        trip.ActivityTypePlaceBased,
-				Time:        time.Date(2026, 1, 12, 10, 0, 0, 0, time.UTC),
+				Time:        time.Date(2026, 1, 12, 10, 0, 0, 0, kauaiLocation),
 				Title:       "Hanalei Bay Beach",
 				Location:    "Hanalei Bay, Hanalei",
 				Category:    trip.ActivityCategoryBeach,
@@ -208,7 +215,7 @@ func createDay3(date time.Time) trip.ItineraryDay {
 			},
 			{
 				Type:        trip.ActivityTypePlaceBased,
-				Time:        time.Date(2026, 1, 12, 13, 0, 0, 0, time.UTC),
+				Time:        time.Date(2026, 1, 12, 13, 0, 0, 0, kauaiLocation),
 				Title:       "Lunch at Hanalei Taro & Juice",
 				Location:    "5-5070 Kuhio Hwy, Hanalei",
 				Category:    trip.ActivityCategoryFood,
@@ -217,7 +224,7 @@ func createDay3(date time.Time) trip.ItineraryDay {
 			},
 			{
 				Type:        trip.ActivityTypePlaceBased,
-				Time:        time.Date(2026, 1, 12, 15, 30, 0, 0, time.UTC),
+				Time:        time.Date(2026, 1, 12, 15, 30, 0, 0, kauaiLocation),
 				Title:       "Anini Beach",
 				Location:    "Anini Beach Park",
 				Category:    trip.ActivityCategoryBeach,
@@ -235,7 +242,7 @@ func createDay4(date time.Time) trip.ItineraryDay {
 		Activities: []trip.Activity{
 			{
 				Type:        trip.ActivityTypePlaceBased,
-				Time:        time.Date(2026, 1, 13, 8, 0, 0, 0, time.UTC),
+				Time:        time.Date(2026, 1, 13, 8, 0, 0, 0, kauaiLocation),
 				Title:       "Breakfast at Java Kai",
 				Location:    "4-1384 Kuhio Hwy, Kapaa",
 				Category:    trip.ActivityCategoryFood,
@@ -244,7 +251,7 @@ func createDay4(date time.Time) trip.ItineraryDay {
 			},
 			{
 				Type:        trip.ActivityTypePlaceBased,
-				Time:        time.Date(2026, 1, 13, 9, 0, 0, 0, time.UTC),
+				Time:        time.Date(2026, 1, 13, 9, 0, 0, 0, kauaiLocation),
 				Title:       "Wailua Falls",
 				Location:    "Wailua Falls Overlook",
 				Category:    trip.ActivityCategoryActivity,
@@ -253,7 +260,7 @@ func createDay4(date time.Time) trip.ItineraryDay {
 			},
 			{
 				Type:        trip.ActivityTypePlaceBased,
-				Time:        time.Date(2026, 1, 13, 11, 0, 0, 0, time.UTC),
+				Time:        time.Date(2026, 1, 13, 11, 0, 0, 0, kauaiLocation),
 				Title:       "Opaekaa Falls",
 				Location:    "Kuamoo Rd, Kapaa",
 				Category:    trip.ActivityCategoryActivity,
@@ -262,7 +269,7 @@ func createDay4(date time.Time) trip.ItineraryDay {
 			},
 			{
 				Type:        trip.ActivityTypePlaceBased,
-				Time:        time.Date(2026, 1, 13, 12, 30, 0, 0, time.UTC),
+				Time:        time.Date(2026, 1, 13, 12, 30, 0, 0, kauaiLocation),
 				Title:       "Lunch at Hamura Saimin",
 				Location:    "2956 Kress St, Lihue",
 				Category:    trip.ActivityCategoryFood,
@@ -271,7 +278,7 @@ func createDay4(date time.Time) trip.ItineraryDay {
 			},
 			{
 				Type:        trip.ActivityTypePlaceBased,
-				Time:        time.Date(2026, 1, 13, 14, 30, 0, 0, time.UTC),
+				Time:        time.Date(2026, 1, 13, 14, 30, 0, 0, kauaiLocation),
 				Title:       "Poipu Beach",
 				Location:    "Poipu Beach Park",
 				Category:    trip.ActivityCategoryBeach,
@@ -280,7 +287,7 @@ func createDay4(date time.Time) trip.ItineraryDay {
 			},
 			{
 				Type:        trip.ActivityTypePlaceBased,
-				Time:        time.Date(2026, 1, 13, 18, 30, 0, 0, time.UTC),
+				Time:        time.Date(2026, 1, 13, 18, 30, 0, 0, kauaiLocation),
 				Title:       "Dinner at Eating House 1849",
 				Location:    "2829 Ala Kalanikaumaka St, Poipu",
 				Category:    trip.ActivityCategoryFood,
@@ -298,7 +305,7 @@ func createDay5(date time.Time) trip.ItineraryDay {
 		Activities: []trip.Activity{
 			{
 				Type:        trip.ActivityTypePlaceBased,
-				Time:        time.Date(2026, 1, 14, 7, 0, 0, 0, time.UTC),
+				Time:        time.Date(2026, 1, 14, 7, 0, 0, 0, kauaiLocation),
 				Title:       "Early Morning Na Pali Coast Boat Tour",
 				Location:    "Port Allen Harbor",
 				Category:    trip.ActivityCategoryActivity,
@@ -307,7 +314,7 @@ func createDay5(date time.Time) trip.ItineraryDay {
 			},
 			{
 				Type:        trip.ActivityTypePlaceBased,
-				Time:        time.Date(2026, 1, 14, 13, 0, 0, 0, time.UTC),
+				Time:        time.Date(2026, 1, 14, 13, 0, 0, 0, kauaiLocation),
 				Title:       "Late Lunch at Tidepools Restaurant",
 				Location:    "1571 Poipu Rd, Koloa",
 				Category:    trip.ActivityCategoryFood,
@@ -316,7 +323,7 @@ func createDay5(date time.Time) trip.ItineraryDay {
 			},
 			{
 				Type:        trip.ActivityTypeCustom,
-				Time:        time.Date(2026, 1, 14, 15, 30, 0, 0, time.UTC),
+				Time:        time.Date(2026, 1, 14, 15, 30, 0, 0, kauaiLocation),
 				Title:       "Relax at Hotel Pool",
 				Location:    "Hotel",
 				Category:    trip.ActivityCategoryActivity,
@@ -325,7 +332,7 @@ func createDay5(date time.Time) trip.ItineraryDay {
 			},
 			{
 				Type:        trip.ActivityTypePlaceBased,
-				Time:        time.Date(2026, 1, 14, 18, 0, 0, 0, time.UTC),
+				Time:        time.Date(2026, 1, 14, 18, 0, 0, 0, kauaiLocation),
 				Title:       "Casual Dinner at Brennecke's Beach Broiler",
 				Location:    "2100 Hoone Rd, Poipu",
 				Category:    trip.ActivityCategoryFood,
@@ -343,7 +350,7 @@ func createDay6(date time.Time) trip.ItineraryDay {
 		Activities: []trip.Activity{
 			{
 				Type:        trip.ActivityTypePlaceBased,
-				Time:        time.Date(2026, 1, 15, 8, 30, 0, 0, time.UTC),
+				Time:        time.Date(2026, 1, 15, 8, 30, 0, 0, kauaiLocation),
 				Title:       "Breakfast at Kountry Kitchen",
 				Location:    "4-1485 Kuhio Hwy, Kapaa",
 				Category:    trip.ActivityCategoryFood,
@@ -352,7 +359,7 @@ func createDay6(date time.Time) trip.ItineraryDay {
 			},
 			{
 				Type:        trip.ActivityTypePlaceBased,
-				Time:        time.Date(2026, 1, 15, 10, 30, 0, 0, time.UTC),
+				Time:        time.Date(2026, 1, 15, 10, 30, 0, 0, kauaiLocation),
 				Title:       "Kilauea Lighthouse",
 				Location:    "Kilauea Point National Wildlife Refuge",
 				Category:    trip.ActivityCategoryActivity,
@@ -361,7 +368,7 @@ func createDay6(date time.Time) trip.ItineraryDay {
 			},
 			{
 				Type:        trip.ActivityTypePlaceBased,
-				Time:        time.Date(2026, 1, 15, 13, 0, 0, 0, time.UTC),
+				Time:        time.Date(2026, 1, 15, 13, 0, 0, 0, kauaiLocation),
 				Title:       "Lunch at Chicken in a Barrel",
 				Location:    "4-1586 Kuhio Hwy, Kapaa",
 				Category:    trip.ActivityCategoryFood,
@@ -370,7 +377,7 @@ func createDay6(date time.Time) trip.ItineraryDay {
 			},
 			{
 				Type:        trip.ActivityTypePlaceBased,
-				Time:        time.Date(2026, 1, 15, 14, 30, 0, 0, time.UTC),
+				Time:        time.Date(2026, 1, 15, 14, 30, 0, 0, kauaiLocation),
 				Title:       "Secret Beach (Kauapea Beach)",
 				Location:    "End of Kalihiwai Rd",
 				Category:    trip.ActivityCategoryBeach,
@@ -379,7 +386,7 @@ func createDay6(date time.Time) trip.ItineraryDay {
 			},
 			{
 				Type:        trip.ActivityTypePlaceBased,
-				Time:        time.Date(2026, 1, 15, 18, 30, 0, 0, time.UTC),
+				Time:        time.Date(2026, 1, 15, 18, 30, 0, 0, kauaiLocation),
 				Title:       "Farewell Dinner at Bar Acuda",
 				Location:    "5-5161 Kuhio Hwy, Hanalei",
 				Category:    trip.ActivityCategoryFood,
@@ -397,7 +404,7 @@ func createDay7(date time.Time) trip.ItineraryDay {
 		Activities: []trip.Activity{
 			{
 				Type:        trip.ActivityTypePlaceBased,
-				Time:        time.Date(2026, 1, 16, 7, 0, 0, 0, time.UTC),
+				Time:        time.Date(2026, 1, 16, 7, 0, 0, 0, kauaiLocation),
 				Title:       "Breakfast at Hotel",
 				Location:    "Hotel Restaurant",
 				Category:    trip.ActivityCategoryFood,
@@ -406,7 +413,7 @@ func createDay7(date time.Time) trip.ItineraryDay {
 			},
 			{
 				Type:        trip.ActivityTypeTransport,
-				Time:        time.Date(2026, 1, 16, 10, 0, 0, 0, time.UTC),
+				Time:        time.Date(2026, 1, 16, 10, 0, 0, 0, kauaiLocation),
 				Title:       "Return Rental Car",
 				Location:    "Lihue Airport",
 				Category:    trip.ActivityCategoryTransport,
@@ -415,7 +422,7 @@ func createDay7(date time.Time) trip.ItineraryDay {
 			},
 			{
 				Type:        trip.ActivityTypeTransport,
-				Time:        time.Date(2026, 1, 16, 13, 0, 0, 0, time.UTC),
+				Time:        time.Date(2026, 1, 16, 13, 0, 0, 0, kauaiLocation),
 				Title:       "Depart Lihue Airport",
 				Location:    "Lihue Airport (LIH)",
 				Category:    trip.ActivityCategoryTransport,
@@ -3,7 +3,7 @@ package seeds
 import (
 	"fmt"
 
-	"eztrip/api-go/auth0"
+	"eztrip/api-go/identity"
 	"eztrip/api-go/logger"
 	"eztrip/api-go/rbac"
 	"eztrip/api-go/user"
@@ -25,7 +25,7 @@ type userWithRole struct {
 }
 
 // SeedUsers populates the users table with sample data and assigns RBAC roles
-func SeedUsers(db *gorm.DB, enforcer *casbin.Enforcer) error {
+func SeedUsers(db *gorm.DB, enforcer *casbin.SyncedEnforcer) error {
 	logger.Log.Info("Seeding users...")
 
 	auth0Client := initializeAuth0Client()
@@ -45,8 +45,8 @@ func SeedUsers(db *gorm.DB, enforcer *casbin.Enforcer) error {
 	return nil
 }
 
-func initializeAuth0Client() *auth0.Client {
-	auth0Client, err := auth0.NewClient()
+func initializeAuth0Client() identity.Directory {
+	auth0Client, err := identity.NewDefaultDirectory()
 	if err != nil {
 		logger.Log.WithField("error", err.Error()).Warn("Auth0 client not configured, skipping Auth0 sync")
 		return nil
@@ -54,7 +54,7 @@ func initializeAuth0Client() *auth0.Client {
 	return auth0Client
 }
 
-func syncAuth0Users(db *gorm.DB, enforcer *casbin.Enforcer, auth0Client *auth0.Client) error {
+func syncAuth0Users(db *gorm.DB, enforcer *casbin.SyncedEnforcer, auth0Client identity.Directory) error {
 	logger.Log.Info("Fetching users from Auth0...")
 	
 	auth0Users, err := auth0Client.ListUsers()
@@ -67,11 +67,11 @@ func syncAuth0Users(db *gorm.DB, enforcer *casbin.Enforcer, auth0Client *auth0.C
 	for _, auth0User := range auth0Users {
 		// Check if user already exists in database by auth0_user_id
 		var existingUser user.User
-		err := db.Where("auth0_user_id = ?", auth0User.UserID).First(&existingUser).Error
+		err := db.Where("auth0_user_id = ?", auth0User.ID).First(&existingUser).Error
 		if err == nil {
 			logger.Log.WithFields(logrus.Fields{
 				"email":    auth0User.Email,
-				"auth0_id": auth0User.UserID,
+				"auth0_id": auth0User.ID,
 			}).Debug("Auth0 user already exists in database")
 			continue
 		}
@@ -80,7 +80,7 @@ func syncAuth0Users(db *gorm.DB, enforcer *casbin.Enforcer, auth0Client *auth0.C
 		err = db.Where("email = ?", auth0User.Email).First(&existingUser).Error
 		if err == nil {
 			// Update existing user with Auth0 ID
-			existingUser.Auth0UserID = &auth0User.UserID
+			existingUser.Auth0UserID = &auth0User.ID
 			existingUser.FirstName = auth0User.FirstName
 			existingUser.LastName = auth0User.LastName
 			if err := db.Save(&existingUser).Error; err != nil {
@@ -94,14 +94,14 @@ func syncAuth0Users(db *gorm.DB, enforcer *casbin.Enforcer, auth0Client *auth0.C
 			logger.Log.WithFields(logrus.Fields{
 				"user_id":  existingUser.ID,
 				"email":    auth0User.Email,
-				"auth0_id": auth0User.UserID,
+				"auth0_id": auth0User.ID,
 			}).Info("Updated existing user with Auth0 ID")
 			continue
 		}
 
 		// Create new user from Auth0
 		newUser := user.User{
-			Auth0UserID: &auth0User.UserID,
+			Auth0UserID: &auth0User.ID,
 			FirstName:   auth0User.FirstName,
 			LastName:    auth0User.LastName,
 			Email:       auth0User.Email,
@@ -126,7 +126,7 @@ func syncAuth0Users(db *gorm.DB, enforcer *casbin.Enforcer, auth0Client *auth0.C
 		logger.Log.WithFields(logrus.Fields{
 			"user_id":  newUser.ID,
 			"email":    auth0User.Email,
-			"auth0_id": auth0User.UserID,
+			"auth0_id": auth0User.ID,
 		}).Info("Synced Auth0 user to database")
 	}
 
@@ -160,7 +160,7 @@ func buildUserList() []userWithRole {
 	return usersToSeed
 }
 
-func seedAllUsers(db *gorm.DB, enforcer *casbin.Enforcer, usersToSeed []userWithRole) {
+func seedAllUsers(db *gorm.DB, enforcer *casbin.SyncedEnforcer, usersToSeed []userWithRole) {
 	for _, uwr := range usersToSeed {
 		if err := seedUserWithRole(db, enforcer, &uwr.user, uwr.role); err != nil {
 			logger.Log.WithFields(logrus.Fields{
@@ -191,7 +191,7 @@ func logSeedingSummary(usersToSeed []userWithRole) {
 	}).Info("Successfully seeded users with roles")
 }
 
-func seedUserWithRole(db *gorm.DB, enforcer *casbin.Enforcer, u *user.User, role string) error {
+func seedUserWithRole(db *gorm.DB, enforcer *casbin.SyncedEnforcer, u *user.User, role string) error {
 	// Check if user already exists in database by email
 	var existingUser user.User
 	err := db.Where("email = ?", u.Email).First(&existingUser).Error
@@ -202,7 +202,7 @@ func seedUserWithRole(db *gorm.DB, enforcer *casbin.Enforcer, u *user.User, role
 		return nil
 	}
 
-	// Real users will be auto-provisioned with Auth0 ID on first login
+	// Real users are linked to their Auth0 ID by verified email on first login
 	if err := createUserInDatabase(db, u); err != nil {
 		return err
 	}
@@ -222,7 +222,7 @@ func createUserInDatabase(db *gorm.DB, u *user.User) error {
 	return nil
 }
 
-func assignRoleToUser(enforcer *casbin.Enforcer, u *user.User, role string) error {
+func assignRoleToUser(enforcer *casbin.SyncedEnforcer, u *user.User, role string) error {
 	if err := rbac.AddRoleForUser(enforcer, u.ID.String(), role); err != nil {
 		logger.Log.WithFields(logrus.Fields{
 			"user_id": u.ID,
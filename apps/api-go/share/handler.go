@@ -0,0 +1,134 @@
+package share
+
+import (
+	"bytes"
+	"embed"
+	"html/template"
+	"net/http"
+	"time"
+
+	appErrors "eztrip/api-go/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+const contentType = "text/html; charset=utf-8"
+
+//go:embed templates/password.html
+var templates embed.FS
+
+var pageTemplate = template.Must(template.ParseFS(templates, "templates/password.html"))
+
+// page is the content of the password prompt and error pages
+type page struct {
+	Heading     string
+	Message     string
+	Failed      bool
+	AskPassword bool
+}
+
+// Handler serves shared trips to viewers without an account
+type Handler struct {
+	Service  *Service
+	attempts *attemptLimiter
+}
+
+// NewHandler creates a new share link HTTP handler
+func NewHandler(service *Service) *Handler {
+	return &Handler{
+		Service:  service,
+		attempts: newAttemptLimiter(),
+	}
+}
+
+// View shows the shared trip, or asks for the password of a protected link. This route
+// is public, so the token in the path is the credential.
+func (h *Handler) View(c *gin.Context) {
+	link, err := h.Service.Resolve(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		h.renderError(c, err)
+		return
+	}
+
+	if link.HasPassword() {
+		h.renderPage(c, http.StatusOK, page{
+			Heading:     "This trip is password protected",
+			Message:     "Enter the password you were given to view it.",
+			AskPassword: true,
+		})
+		return
+	}
+
+	h.render(c, link, "")
+}
+
+// Unlock checks the password submitted from the prompt and shows the shared trip.
+// Incorrect passwords are throttled per link and client address.
+func (h *Handler) Unlock(c *gin.Context) {
+	link, err := h.Service.Resolve(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		h.renderError(c, err)
+		return
+	}
+
+	if link.HasPassword() && !h.attempts.allowed(link.ID, c.ClientIP(), time.Now()) {
+		h.renderPage(c, http.StatusTooManyRequests, page{
+			Heading: "This trip is password protected",
+			Message: "Too many incorrect passwords. Try again later.",
+			Failed:  true,
+		})
+		return
+	}
+
+	h.render(c, link, c.PostForm("password"))
+}
+
+func (h *Handler) render(c *gin.Context, link *Link, password string) {
+	body, err := h.Service.Render(c.Request.Context(), link, password)
+	if err != nil {
+		if appErrors.HTTPStatus(err) == http.StatusUnauthorized {
+			h.attempts.recordFailure(link.ID, c.ClientIP(), time.Now())
+			h.renderPage(c, http.StatusUnauthorized, page{
+				Heading:     "This trip is password protected",
+				Message:     "That password isn't right. Try again.",
+				Failed:      true,
+				AskPassword: true,
+			})
+			return
+		}
+		h.renderError(c, err)
+		return
+	}
+
+	setHeaders(c)
+	c.Data(http.StatusOK, contentType, body)
+}
+
+func (h *Handler) renderError(c *gin.Context, err error) {
+	status := appErrors.HTTPStatus(err)
+	message := "Something went wrong. Please try again later."
+	if status == http.StatusNotFound {
+		message = "This link has expired or is no longer shared."
+	}
+
+	h.renderPage(c, status, page{Heading: "Trip unavailable", Message: message, Failed: true})
+}
+
+func (h *Handler) renderPage(c *gin.Context, status int, p page) {
+	var buf bytes.Buffer
+	if err := pageTemplate.Execute(&buf, p); err != nil {
+		c.String(http.StatusInternalServerError, "Failed to render page")
+		return
+	}
+
+	setHeaders(c)
+	c.Data(status, contentType, buf.Bytes())
+}
+
+// setHeaders keeps shared pages out of caches, search engines and referrer headers,
+// since the URL itself grants access
+func setHeaders(c *gin.Context) {
+	c.Header("Cache-Control", "private, no-store")
+	c.Header("X-Robots-Tag", "noindex, nofollow")
+	c.Header("Referrer-Policy", "no-referrer")
+}
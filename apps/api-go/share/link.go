@@ -0,0 +1,70 @@
+package share
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const tokenBytes = 32
+
+// Link is a public, read-only link to a trip for people without an account. Only hashes
+// of the token and password are stored, so the URL is shown once when the link is created.
+type Link struct {
+	ID             uuid.UUID      `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	TripID         uuid.UUID      `gorm:"type:uuid;not null;index"`
+	CreatedBy      uuid.UUID      `gorm:"type:uuid;not null"`
+	TokenHash      string         `gorm:"column:token_hash;not null;uniqueIndex"`
+	Label          *string        `gorm:"column:label"`         // Nullable - helps members tell links apart, e.g. "Grandparents"
+	PasswordHash   *string        `gorm:"column:password_hash"` // Nullable - bcrypt hash when the link is password protected
+	ExpiresAt      *time.Time     `gorm:"column:expires_at"`    // Nullable - links without an expiry work until revoked
+	LastAccessedAt *time.Time     `gorm:"column:last_accessed_at"`
+	AccessCount    int            `gorm:"column:access_count;not null;default:0"`
+	CreatedAt      time.Time      `gorm:"column:created_at"`
+	UpdatedAt      time.Time      `gorm:"column:updated_at"`
+	DeletedAt      gorm.DeletedAt `gorm:"column:deleted_at;index"` // Set when the link is revoked
+
+	URL *string `gorm:"-"` // Only populated right after the link is created
+}
+
+// TableName specifies the table name for the Link model
+func (Link) TableName() string {
+	return "share_links"
+}
+
+// HasPassword checks if viewers must enter a password
+func (l *Link) HasPassword() bool {
+	return l.PasswordHash != nil
+}
+
+// IsExpired checks if the link's expiry has passed
+func (l *Link) IsExpired(now time.Time) bool {
+	return l.ExpiresAt != nil && !now.Before(*l.ExpiresAt)
+}
+
+// LinkInput represents the options submitted when creating a share link
+type LinkInput struct {
+	Label     *string `json:"label" validate:"omitempty,min=1,max=255"`
+	ExpiresAt *string `json:"expiresAt" validate:"omitempty"`
+	Password  *string `json:"password" validate:"omitempty,min=8,max=72"`
+}
+
+// generateToken returns a random URL-safe token and its hash
+func generateToken() (string, string, error) {
+	raw := make([]byte, tokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+	return token, hashToken(token), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
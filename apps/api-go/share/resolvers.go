@@ -0,0 +1,54 @@
+package share
+
+import (
+	"context"
+
+	"eztrip/api-go/trip"
+	"eztrip/api-go/validation"
+
+	"github.com/google/uuid"
+)
+
+// Resolver handles GraphQL resolver operations for share links
+type Resolver struct {
+	Service *Service
+}
+
+// NewResolver creates a new share link resolver
+func NewResolver(service *Service) *Resolver {
+	return &Resolver{
+		Service: service,
+	}
+}
+
+// TripShareLinks returns the active share links of an already authorized trip
+func (r *Resolver) TripShareLinks(ctx context.Context, t *trip.Trip) ([]*Link, error) {
+	return r.Service.GetByTrip(ctx, t.ID)
+}
+
+// CreateShareLink creates a public read-only link to a trip
+func (r *Resolver) CreateShareLink(ctx context.Context, tripID string, input LinkInput) (*Link, error) {
+	if err := validation.ValidateStruct(input); err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Service.Create(ctx, id, input)
+}
+
+// RevokeShareLink disables a share link
+func (r *Resolver) RevokeShareLink(ctx context.Context, id string) (bool, error) {
+	linkID, err := uuid.Parse(id)
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.Service.Revoke(ctx, linkID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
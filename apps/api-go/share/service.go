@@ -0,0 +1,234 @@
+package share
+
+import (
+	"context"
+	"os"
+	"strings"
+	"time"
+
+	appErrors "eztrip/api-go/errors"
+	"eztrip/api-go/logger"
+	"eztrip/api-go/printout"
+	"eztrip/api-go/trip"
+	"eztrip/api-go/user"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+const (
+	envPublicURL     = "PUBLIC_API_URL"
+	defaultPublicURL = "http://localhost:8080"
+
+	// PathPrefix is where shared trips are served, authenticated by the link's token alone
+	PathPrefix = "/shared/"
+)
+
+// Service manages public share links and renders the read-only trip view behind them
+type Service struct {
+	db              *gorm.DB
+	tripService     *trip.Service
+	printoutService *printout.Service
+	publicURL       string
+}
+
+// NewService creates a new share link service
+func NewService(db *gorm.DB, tripService *trip.Service) *Service {
+	publicURL := strings.TrimRight(strings.TrimSpace(os.Getenv(envPublicURL)), "/")
+	if publicURL == "" {
+		publicURL = defaultPublicURL
+	}
+
+	return &Service{
+		db:              db,
+		tripService:     tripService,
+		printoutService: printout.NewService(db, tripService),
+		publicURL:       publicURL,
+	}
+}
+
+// GetByTrip returns the active share links of an already authorized trip, newest first
+func (s *Service) GetByTrip(ctx context.Context, tripID uuid.UUID) ([]*Link, error) {
+	var links []*Link
+	err := s.db.WithContext(ctx).
+		Where("trip_id = ?", tripID).
+		Order("created_at DESC").
+		Find(&links).Error
+
+	if err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"trip_id": tripID,
+			"error":   err.Error(),
+		}).Error("Failed to fetch share links for trip")
+		return nil, appErrors.Internal("Failed to fetch share links")
+	}
+
+	return links, nil
+}
+
+// Create adds a share link to a trip the user has access to
+func (s *Service) Create(ctx context.Context, tripID uuid.UUID, input LinkInput) (*Link, error) {
+	if _, err := s.tripService.GetByID(ctx, tripID); err != nil {
+		return nil, err
+	}
+
+	_, userID, err := user.GetAuthenticatedUser(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+
+	link := &Link{
+		TripID:    tripID,
+		CreatedBy: userID,
+	}
+
+	if input.Label != nil {
+		label := strings.TrimSpace(*input.Label)
+		link.Label = &label
+	}
+
+	if input.ExpiresAt != nil {
+		expiresAt, err := time.Parse(time.RFC3339, *input.ExpiresAt)
+		if err != nil {
+			return nil, appErrors.ValidationError("expiresAt", "Expiry must be an RFC 3339 timestamp")
+		}
+		if !expiresAt.After(time.Now()) {
+			return nil, appErrors.ValidationError("expiresAt", "Expiry must be in the future")
+		}
+		link.ExpiresAt = &expiresAt
+	}
+
+	if input.Password != nil {
+		hash, err := bcrypt.GenerateFromPassword([]byte(*input.Password), bcrypt.DefaultCost)
+		if err != nil {
+			logger.Log.WithField("error", err.Error()).Error("Failed to hash share link password")
+			return nil, appErrors.Internal("Failed to create share link")
+		}
+		passwordHash := string(hash)
+		link.PasswordHash = &passwordHash
+	}
+
+	token, tokenHash, err := generateToken()
+	if err != nil {
+		logger.Log.WithField("error", err.Error()).Error("Failed to generate share link token")
+		return nil, appErrors.Internal("Failed to create share link")
+	}
+	link.TokenHash = tokenHash
+
+	if err := s.db.WithContext(ctx).Create(link).Error; err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"trip_id": tripID,
+			"error":   err.Error(),
+		}).Error("Failed to create share link")
+		return nil, appErrors.Internal("Failed to create share link")
+	}
+
+	url := s.publicURL + PathPrefix + token
+	link.URL = &url
+
+	logger.Log.WithFields(logrus.Fields{
+		"trip_id":       tripID,
+		"share_link_id": link.ID,
+		"has_password":  link.HasPassword(),
+		"expires_at":    link.ExpiresAt,
+	}).Info("Share link created successfully")
+
+	return link, nil
+}
+
+// Revoke disables a share link of a trip the user has access to
+func (s *Service) Revoke(ctx context.Context, id uuid.UUID) error {
+	var link Link
+	if err := s.db.WithContext(ctx).First(&link, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return appErrors.NotFound("Share link")
+		}
+		logger.Log.WithFields(logrus.Fields{
+			"share_link_id": id,
+			"error":         err.Error(),
+		}).Error("Failed to fetch share link")
+		return appErrors.Internal("Failed to revoke share link")
+	}
+
+	if _, err := s.tripService.GetByID(ctx, link.TripID); err != nil {
+		return err
+	}
+
+	if err := s.db.WithContext(ctx).Delete(&link).Error; err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"share_link_id": id,
+			"error":         err.Error(),
+		}).Error("Failed to revoke share link")
+		return appErrors.Internal("Failed to revoke share link")
+	}
+
+	logger.Log.WithFields(logrus.Fields{
+		"trip_id":       link.TripID,
+		"share_link_id": id,
+	}).Info("Share link revoked successfully")
+
+	return nil
+}
+
+// Resolve finds the active link behind a token. Revoked and expired links are reported
+// as not found so viewers can't tell them apart from made-up tokens.
+func (s *Service) Resolve(ctx context.Context, token string) (*Link, error) {
+	var link Link
+	if err := s.db.WithContext(ctx).First(&link, "token_hash = ?", hashToken(token)).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, appErrors.NotFound("Share link")
+		}
+		logger.Log.WithField("error", err.Error()).Error("Failed to fetch share link")
+		return nil, appErrors.Internal("Failed to open share link")
+	}
+
+	if link.IsExpired(time.Now()) {
+		return nil, appErrors.NotFound("Share link")
+	}
+
+	return &link, nil
+}
+
+// Render checks the password of a protected link and renders the read-only trip view
+// without private notes, booking details or traveler names. The link's creator must
+// still be a member of the trip, so removing a collaborator also disables the links
+// they created.
+func (s *Service) Render(ctx context.Context, link *Link, password string) ([]byte, error) {
+	if link.HasPassword() {
+		if err := bcrypt.CompareHashAndPassword([]byte(*link.PasswordHash), []byte(password)); err != nil {
+			logger.Log.WithField("share_link_id", link.ID).Warn("Incorrect share link password")
+			return nil, appErrors.Unauthorized("Incorrect password")
+		}
+	}
+
+	t, err := s.tripService.GetByIDForUser(ctx, link.TripID, link.CreatedBy)
+	if err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"share_link_id": link.ID,
+			"trip_id":       link.TripID,
+			"error":         err.Error(),
+		}).Warn("Share link no longer grants access to its trip")
+		return nil, appErrors.NotFound("Share link")
+	}
+
+	body, err := s.printoutService.RenderTrip(ctx, t, printout.FormatHTML, printout.Options{HideNotes: true, HideBookingDetails: true, HideTravelers: true})
+	if err != nil {
+		return nil, err
+	}
+
+	// Access tracking is best effort and shouldn't fail the view
+	err = s.db.WithContext(ctx).Model(link).UpdateColumns(map[string]interface{}{
+		"last_accessed_at": time.Now(),
+		"access_count":     gorm.Expr("access_count + 1"),
+	}).Error
+	if err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"share_link_id": link.ID,
+			"error":         err.Error(),
+		}).Warn("Failed to record share link access")
+	}
+
+	return body, nil
+}
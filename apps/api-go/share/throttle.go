@@ -0,0 +1,86 @@
+package share
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// attemptWindow is how long an incorrect password counts against a link
+	attemptWindow = 15 * time.Minute
+
+	// maxClientAttempts limits incorrect passwords from one address for a link
+	maxClientAttempts = 5
+
+	// maxLinkAttempts limits incorrect passwords for a link from all addresses, so
+	// spreading guesses over many addresses doesn't help much either
+	maxLinkAttempts = 50
+
+	// sweepThreshold is how many tracked keys trigger dropping expired ones
+	sweepThreshold = 1024
+)
+
+// attemptLimiter throttles password guesses against protected share links. Failures
+// are kept in memory, so each API instance limits the attempts it sees.
+type attemptLimiter struct {
+	mu       sync.Mutex
+	failures map[string][]time.Time
+}
+
+func newAttemptLimiter() *attemptLimiter {
+	return &attemptLimiter{failures: make(map[string][]time.Time)}
+}
+
+// allowed checks if a client may try another password for the link
+func (l *attemptLimiter) allowed(linkID uuid.UUID, client string, now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return len(l.recent(linkKey(linkID), now)) < maxLinkAttempts &&
+		len(l.recent(clientKey(linkID, client), now)) < maxClientAttempts
+}
+
+// recordFailure counts an incorrect password for the link and client
+func (l *attemptLimiter) recordFailure(linkID uuid.UUID, client string, now time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.failures) > sweepThreshold {
+		for key := range l.failures {
+			l.recent(key, now)
+		}
+	}
+
+	for _, key := range []string{linkKey(linkID), clientKey(linkID, client)} {
+		l.failures[key] = append(l.recent(key, now), now)
+	}
+}
+
+// recent drops the expired failures of a key and returns the rest. Callers hold the lock.
+func (l *attemptLimiter) recent(key string, now time.Time) []time.Time {
+	failures := l.failures[key]
+	cutoff := now.Add(-attemptWindow)
+	kept := failures[:0]
+	for _, at := range failures {
+		if at.After(cutoff) {
+			kept = append(kept, at)
+		}
+	}
+
+	if len(kept) == 0 {
+		delete(l.failures, key)
+		return nil
+	}
+	l.failures[key] = kept
+	return kept
+}
+
+func linkKey(linkID uuid.UUID) string {
+	return linkID.String()
+}
+
+func clientKey(linkID uuid.UUID, client string) string {
+	return linkID.String() + "|" + client
+}
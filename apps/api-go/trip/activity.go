@@ -37,6 +37,8 @@ type Activity struct {
 	PlaceID        *uuid.UUID       `gorm:"type:uuid;index"` // Nullable - references places table
 	Type           ActivityType     `gorm:"column:type;not null;default:'place_based'"`
 	Time           time.Time        `gorm:"column:time;not null"`
+	EndTime        *time.Time       `gorm:"column:end_time"`  // Nullable - activities without an end time are treated as instantaneous
+	TimeZone       *string          `gorm:"column:time_zone"` // Nullable - overrides the trip's time zone for multi-city trips
 	Title          string           `gorm:"column:title;not null"`
 	Location       string           `gorm:"column:location"`
 	Category       ActivityCategory `gorm:"column:category;not null"`
@@ -45,9 +47,48 @@ type Activity struct {
 	CreatedAt      time.Time        `gorm:"column:created_at"`
 	UpdatedAt      time.Time        `gorm:"column:updated_at"`
 	DeletedAt      gorm.DeletedAt   `gorm:"column:deleted_at;index"`
+
+	// Relationships
+	TransportLeg *TransportLeg `gorm:"foreignKey:ActivityID;constraint:OnDelete:CASCADE"`
+	Travelers    []Traveler    `gorm:"many2many:activity_travelers"` // Travelers taking part; empty means everyone
+
+	tripTimeZone string // Populated from the parent trip when loaded through the service
 }
 
 // TableName specifies the table name for the Activity model
 func (Activity) TableName() string {
 	return "activities"
 }
+
+// EndsAt returns when the activity ends, falling back to the start time when no end time is set
+func (a *Activity) EndsAt() time.Time {
+	if a.EndTime == nil {
+		return a.Time
+	}
+	return *a.EndTime
+}
+
+// Duration returns how long the activity lasts
+func (a *Activity) Duration() time.Duration {
+	return a.EndsAt().Sub(a.Time)
+}
+
+// TimeLocation returns the activity's own time zone, falling back to the trip's time zone
+func (a *Activity) TimeLocation() *time.Location {
+	if a.TimeZone != nil && *a.TimeZone != "" {
+		return LoadLocation(*a.TimeZone)
+	}
+	return LoadLocation(a.tripTimeZone)
+}
+
+// redact drops the booking references and traveler list, which stay private to trip
+// members when the itinerary is shown in the template library
+func (a *Activity) redact() {
+	if a.TransportLeg != nil {
+		leg := *a.TransportLeg
+		leg.ConfirmationNumber = nil
+		leg.Seat = nil
+		a.TransportLeg = &leg
+	}
+	a.Travelers = nil
+}
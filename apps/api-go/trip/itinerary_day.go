@@ -19,9 +19,60 @@ type ItineraryDay struct {
 
 	// Relationships
 	Activities []Activity `gorm:"foreignKey:ItineraryDayID;constraint:OnDelete:CASCADE"`
+
+	trip     *Trip // Parent trip, linked when loaded through the service
+	redacted bool  // Set when read from the template library, hiding bookings and travelers
 }
 
 // TableName specifies the table name for the ItineraryDay model
 func (ItineraryDay) TableName() string {
 	return "itinerary_days"
 }
+
+// TimeLocation returns the time zone of the trip this day belongs to
+func (d *ItineraryDay) TimeLocation() *time.Location {
+	if d.trip == nil {
+		return time.UTC
+	}
+	return d.trip.TimeLocation()
+}
+
+// LocalDate returns the day's calendar date in the trip's time zone
+func (d *ItineraryDay) LocalDate() string {
+	return FormatDate(d.Date, d.TimeLocation())
+}
+
+// Lodgings returns the trip's lodging stays that cover this day, from check-in to check-out
+func (d *ItineraryDay) Lodgings() []Lodging {
+	if d.trip == nil {
+		return []Lodging{}
+	}
+
+	date := d.LocalDate()
+	lodgings := make([]Lodging, 0)
+	for _, lodging := range d.trip.Lodgings {
+		if lodging.CoversDate(date) {
+			lodgings = append(lodgings, lodging)
+		}
+	}
+	return lodgings
+}
+
+// needsLodging checks if travelers spend the night after this day within the trip
+func (d *ItineraryDay) needsLodging() bool {
+	if d.trip == nil {
+		return false
+	}
+	return d.LocalDate() < FormatDate(d.trip.EndDate, d.TimeLocation())
+}
+
+// hasLodgingForNight checks if any lodging stay covers the night after this day
+func (d *ItineraryDay) hasLodgingForNight() bool {
+	date := d.LocalDate()
+	for _, lodging := range d.trip.Lodgings {
+		if lodging.CoversNight(date) {
+			return true
+		}
+	}
+	return false
+}
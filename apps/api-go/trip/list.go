@@ -0,0 +1,161 @@
+package trip
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	appErrors "eztrip/api-go/errors"
+	"eztrip/api-go/pagination"
+	"eztrip/api-go/user"
+
+	"github.com/google/uuid"
+)
+
+// Timing selects trips by where they fall relative to today
+type Timing string
+
+const (
+	TimingUpcoming Timing = "upcoming"
+	TimingOngoing  Timing = "ongoing"
+	TimingPast     Timing = "past"
+)
+
+// SortField is a column trips can be listed by
+type SortField string
+
+const (
+	SortFieldStartDate SortField = "start_date"
+	SortFieldTitle     SortField = "title"
+	SortFieldCreatedAt SortField = "created_at"
+)
+
+// ListFilter narrows the user's trips. From and To select trips overlapping the date range.
+type ListFilter struct {
+	Destination *string     `json:"destination" validate:"omitempty,max=255"`
+	From        *string     `json:"from" validate:"omitempty,datetime=2006-01-02"`
+	To          *string     `json:"to" validate:"omitempty,datetime=2006-01-02"`
+	Timing      *Timing     `json:"timing" validate:"omitempty,oneof=upcoming ongoing past"`
+	Role        *MemberRole `json:"role" validate:"omitempty,oneof=owner collaborator"`
+}
+
+// ListOrder is the sort order of a trip list
+type ListOrder struct {
+	Field     SortField            `json:"field" validate:"required,oneof=start_date title created_at"`
+	Direction pagination.Direction `json:"direction" validate:"required,oneof=asc desc"`
+}
+
+// Connection is a page of trips
+type Connection struct {
+	Edges      []*Edge
+	PageInfo   *pagination.PageInfo
+	TotalCount int
+}
+
+// Edge is a trip with its cursor
+type Edge struct {
+	Cursor string
+	Node   *Trip
+}
+
+// Nodes returns the trips of the page without their cursors
+func (c *Connection) Nodes() []*Trip {
+	nodes := make([]*Trip, len(c.Edges))
+	for i, edge := range c.Edges {
+		nodes[i] = edge.Node
+	}
+	return nodes
+}
+
+// DefaultListOrder lists the soonest trips first
+var DefaultListOrder = ListOrder{Field: SortFieldStartDate, Direction: pagination.Asc}
+
+// List returns a page of the trips the authenticated user owns or collaborates on
+func (s *Service) List(ctx context.Context, params pagination.Params, filter ListFilter, order ListOrder) (*Connection, error) {
+	_, userID, err := user.GetAuthenticatedUser(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+
+	collaborating := s.db.Table("trip_collaborators").
+		Select("trip_id").
+		Where("user_id = ? AND deleted_at IS NULL", userID)
+
+	query := s.db.WithContext(ctx).Model(&Trip{})
+
+	switch {
+	case filter.Role != nil && *filter.Role == MemberRoleOwner:
+		query = query.Where("owner_id = ?", userID)
+	case filter.Role != nil && *filter.Role == MemberRoleCollaborator:
+		query = query.Where("id IN (?)", collaborating)
+	default:
+		query = query.Where(s.db.Where("owner_id = ?", userID).Or("id IN (?)", collaborating))
+	}
+
+	if filter.Destination != nil && strings.TrimSpace(*filter.Destination) != "" {
+		query = query.Where("destination ILIKE ?", "%"+escapeLike(strings.TrimSpace(*filter.Destination))+"%")
+	}
+	// Trip dates are compared as calendar dates in each trip's own time zone
+	if filter.From != nil {
+		if _, err := time.Parse(dateFormat, *filter.From); err != nil {
+			return nil, appErrors.ValidationError("from", "From date must be formatted as YYYY-MM-DD")
+		}
+		query = query.Where("(end_date AT TIME ZONE time_zone)::date >= ?::date", *filter.From)
+	}
+	if filter.To != nil {
+		if _, err := time.Parse(dateFormat, *filter.To); err != nil {
+			return nil, appErrors.ValidationError("to", "To date must be formatted as YYYY-MM-DD")
+		}
+		query = query.Where("(start_date AT TIME ZONE time_zone)::date <= ?::date", *filter.To)
+	}
+
+	// Trips end at the start of their last day, so they last until a day after end_date
+	now := time.Now()
+	if filter.Timing != nil {
+		switch *filter.Timing {
+		case TimingUpcoming:
+			query = query.Where("start_date > ?", now)
+		case TimingOngoing:
+			query = query.Where("start_date <= ? AND end_date + INTERVAL '1 day' > ?", now, now)
+		case TimingPast:
+			query = query.Where("end_date + INTERVAL '1 day' <= ?", now)
+		}
+	}
+
+	page, err := pagination.Paginate(query, params, order.pageOrder(), order.key, preloadTripDetails)
+	if err != nil {
+		return nil, err
+	}
+
+	connection := &Connection{
+		Edges:      make([]*Edge, len(page.Items)),
+		PageInfo:   &page.PageInfo,
+		TotalCount: int(page.TotalCount),
+	}
+	for i := range page.Items {
+		connection.Edges[i] = &Edge{Cursor: page.Cursors[i], Node: &page.Items[i]}
+	}
+
+	return connection, nil
+}
+
+// pageOrder maps the sort field to its column
+func (o ListOrder) pageOrder() pagination.Order {
+	kind := pagination.KindTime
+	if o.Field == SortFieldTitle {
+		kind = pagination.KindString
+	}
+	return pagination.Order{Column: "trips." + string(o.Field), Kind: kind, Direction: o.Direction}
+}
+
+// key returns the sort value of a trip for its cursor
+func (o ListOrder) key(t *Trip) (any, uuid.UUID) {
+	switch o.Field {
+	case SortFieldTitle:
+		return t.Title, t.ID
+	case SortFieldCreatedAt:
+		return t.CreatedAt, t.ID
+	default:
+		return t.StartDate, t.ID
+	}
+}
@@ -0,0 +1,201 @@
+package trip
+
+import (
+	"context"
+
+	"eztrip/api-go/dataloader"
+	appErrors "eztrip/api-go/errors"
+	"eztrip/api-go/logger"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type (
+	daysByTripLoaderKey          struct{}
+	activitiesByDayLoaderKey     struct{}
+	collaboratorsByTripLoaderKey struct{}
+)
+
+// LoadItinerary returns a trip's days, fetching them in one batch with the other trips
+// of the request unless they were loaded with the trip. Days come without activities.
+func (s *Service) LoadItinerary(ctx context.Context, t *Trip) ([]ItineraryDay, error) {
+	if t.Itinerary != nil {
+		return t.Itinerary, nil
+	}
+
+	loaded, err := dataloader.For(ctx, daysByTripLoaderKey{}, s.fetchDaysByTrip).Load(ctx, t.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Copies keep the loader's cached days unaffected when linked to this trip
+	days := make([]ItineraryDay, len(loaded))
+	copy(days, loaded)
+	for i := range days {
+		days[i].trip = t
+	}
+	return days, nil
+}
+
+// LoadActivities returns a day's activities, fetching them in one batch with the other
+// days of the request unless they were loaded with the day
+func (s *Service) LoadActivities(ctx context.Context, day *ItineraryDay) ([]Activity, error) {
+	loaded := day.Activities
+	if loaded == nil {
+		var err error
+		loaded, err = dataloader.For(ctx, activitiesByDayLoaderKey{}, s.fetchActivitiesByDay).Load(ctx, day.ID)
+		if err != nil {
+			return nil, err
+		}
+	} else if !day.redacted {
+		return loaded, nil
+	}
+
+	activities := make([]Activity, len(loaded))
+	copy(activities, loaded)
+	for i := range activities {
+		if day.trip != nil {
+			activities[i].tripTimeZone = day.trip.TimeZone
+		}
+		if day.redacted {
+			activities[i].redact()
+		}
+	}
+	return activities, nil
+}
+
+// LoadCollaborators returns a trip's collaborators, fetching them in one batch with the
+// other trips of the request unless they were loaded with the trip
+func (s *Service) LoadCollaborators(ctx context.Context, t *Trip) ([]TripCollaborator, error) {
+	if t.Collaborators != nil {
+		return t.Collaborators, nil
+	}
+	return dataloader.For(ctx, collaboratorsByTripLoaderKey{}, s.fetchCollaboratorsByTrip).Load(ctx, t.ID)
+}
+
+// loadPlan returns a trip's days with their activities, for checks that need the whole plan
+func (s *Service) loadPlan(ctx context.Context, t *Trip) ([]ItineraryDay, error) {
+	days, err := s.LoadItinerary(ctx, t)
+	if err != nil {
+		return nil, err
+	}
+
+	missing := make([]uuid.UUID, 0, len(days))
+	for _, day := range days {
+		if day.Activities == nil {
+			missing = append(missing, day.ID)
+		}
+	}
+	if len(missing) == 0 {
+		return days, nil
+	}
+
+	activities, err := dataloader.For(ctx, activitiesByDayLoaderKey{}, s.fetchActivitiesByDay).LoadAll(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := make([]ItineraryDay, len(days))
+	copy(plan, days)
+	next := 0
+	for i := range plan {
+		if plan[i].Activities != nil {
+			continue
+		}
+		plan[i].Activities = make([]Activity, len(activities[next]))
+		copy(plan[i].Activities, activities[next])
+		for j := range plan[i].Activities {
+			plan[i].Activities[j].tripTimeZone = t.TimeZone
+		}
+		next++
+	}
+	return plan, nil
+}
+
+func (s *Service) fetchDaysByTrip(ctx context.Context, tripIDs []uuid.UUID) (map[uuid.UUID][]ItineraryDay, error) {
+	var days []ItineraryDay
+	err := s.db.WithContext(ctx).
+		Where("trip_id IN ?", tripIDs).
+		Order("date ASC").
+		Find(&days).Error
+	if err != nil {
+		return nil, batchFailed("itinerary days", len(tripIDs), err)
+	}
+
+	byTrip := make(map[uuid.UUID][]ItineraryDay, len(tripIDs))
+	for _, day := range days {
+		byTrip[day.TripID] = append(byTrip[day.TripID], day)
+	}
+	for _, id := range tripIDs {
+		if byTrip[id] == nil {
+			byTrip[id] = []ItineraryDay{}
+		}
+	}
+	return byTrip, nil
+}
+
+func (s *Service) fetchActivitiesByDay(ctx context.Context, dayIDs []uuid.UUID) (map[uuid.UUID][]Activity, error) {
+	var activities []Activity
+	err := s.db.WithContext(ctx).
+		Preload("TransportLeg").
+		Preload("Travelers").
+		Where("itinerary_day_id IN ?", dayIDs).
+		Order("time ASC").
+		Find(&activities).Error
+	if err != nil {
+		return nil, batchFailed("activities", len(dayIDs), err)
+	}
+
+	byDay := make(map[uuid.UUID][]Activity, len(dayIDs))
+	for _, activity := range activities {
+		byDay[activity.ItineraryDayID] = append(byDay[activity.ItineraryDayID], activity)
+	}
+	for _, id := range dayIDs {
+		if byDay[id] == nil {
+			byDay[id] = []Activity{}
+		}
+	}
+	return byDay, nil
+}
+
+func (s *Service) fetchCollaboratorsByTrip(ctx context.Context, tripIDs []uuid.UUID) (map[uuid.UUID][]TripCollaborator, error) {
+	var collaborators []TripCollaborator
+	if err := s.db.WithContext(ctx).Where("trip_id IN ?", tripIDs).Find(&collaborators).Error; err != nil {
+		return nil, batchFailed("collaborators", len(tripIDs), err)
+	}
+
+	byTrip := make(map[uuid.UUID][]TripCollaborator, len(tripIDs))
+	for _, collaborator := range collaborators {
+		byTrip[collaborator.TripID] = append(byTrip[collaborator.TripID], collaborator)
+	}
+	for _, id := range tripIDs {
+		if byTrip[id] == nil {
+			byTrip[id] = []TripCollaborator{}
+		}
+	}
+	return byTrip, nil
+}
+
+// batchFailed logs a failed loader query and returns the error reported to the client
+func batchFailed(records string, keyCount int, err error) error {
+	logger.Log.WithFields(logrus.Fields{
+		"records":   records,
+		"key_count": keyCount,
+		"error":     err.Error(),
+	}).Error("Failed to batch load " + records)
+	return appErrors.Internal("Failed to fetch " + records)
+}
+
+// preloadTripDetails loads the lodging and travelers that itinerary days and head counts
+// are derived from
+func preloadTripDetails(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Lodgings", func(db *gorm.DB) *gorm.DB {
+			return db.Order("check_in_date ASC")
+		}).
+		Preload("TravelerList", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC")
+		})
+}
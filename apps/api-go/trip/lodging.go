@@ -0,0 +1,101 @@
+package trip
+
+import (
+	"time"
+
+	appErrors "eztrip/api-go/errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Lodging represents a hotel or rental stay spanning one or more nights of a trip.
+// Check-in and check-out are calendar dates stored without a time zone.
+type Lodging struct {
+	ID                 uuid.UUID      `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	TripID             uuid.UUID      `gorm:"type:uuid;not null;index"`
+	PlaceID            *uuid.UUID     `gorm:"type:uuid;index"` // Nullable - references places table
+	Name               string         `gorm:"column:name;not null"`
+	Address            string         `gorm:"column:address;type:text"`
+	CheckInDate        time.Time      `gorm:"column:check_in_date;type:date;not null"`
+	CheckOutDate       time.Time      `gorm:"column:check_out_date;type:date;not null"`
+	ConfirmationNumber *string        `gorm:"column:confirmation_number"`
+	CostAmount         *float64       `gorm:"column:cost_amount"`
+	CostCurrency       *string        `gorm:"column:cost_currency"`
+	CreatedAt          time.Time      `gorm:"column:created_at"`
+	UpdatedAt          time.Time      `gorm:"column:updated_at"`
+	DeletedAt          gorm.DeletedAt `gorm:"column:deleted_at;index"`
+}
+
+// TableName specifies the table name for the Lodging model
+func (Lodging) TableName() string {
+	return "lodgings"
+}
+
+// CoversDate checks if the stay includes the given date (YYYY-MM-DD), from check-in through check-out
+func (l *Lodging) CoversDate(date string) bool {
+	return date >= l.CheckInDate.Format(dateFormat) && date <= l.CheckOutDate.Format(dateFormat)
+}
+
+// CoversNight checks if travelers sleep at the lodging on the night starting on the given date
+func (l *Lodging) CoversNight(date string) bool {
+	return date >= l.CheckInDate.Format(dateFormat) && date < l.CheckOutDate.Format(dateFormat)
+}
+
+// Nights returns the number of nights in the stay
+func (l *Lodging) Nights() int {
+	return int(l.CheckOutDate.Sub(l.CheckInDate).Hours() / 24)
+}
+
+// LodgingInput represents the details submitted when adding or updating a lodging stay
+type LodgingInput struct {
+	Name               string   `json:"name" validate:"required,min=1,max=255"`
+	PlaceID            *string  `json:"placeId" validate:"omitempty,uuid"`
+	Address            *string  `json:"address" validate:"omitempty,max=1000"`
+	CheckInDate        string   `json:"checkInDate" validate:"required,datetime=2006-01-02"`
+	CheckOutDate       string   `json:"checkOutDate" validate:"required,datetime=2006-01-02"`
+	ConfirmationNumber *string  `json:"confirmationNumber" validate:"omitempty,max=100"`
+	CostAmount         *float64 `json:"costAmount" validate:"omitempty,gte=0"`
+	CostCurrency       *string  `json:"costCurrency" validate:"required_with=CostAmount,omitempty,iso4217"`
+}
+
+// toLodging parses the input dates and checks that check-out is after check-in
+func (input LodgingInput) toLodging(tripID uuid.UUID) (*Lodging, error) {
+	checkIn, err := time.Parse(dateFormat, input.CheckInDate)
+	if err != nil {
+		return nil, appErrors.ValidationError("checkInDate", "Check-in date must be formatted as YYYY-MM-DD")
+	}
+
+	checkOut, err := time.Parse(dateFormat, input.CheckOutDate)
+	if err != nil {
+		return nil, appErrors.ValidationError("checkOutDate", "Check-out date must be formatted as YYYY-MM-DD")
+	}
+
+	if !checkOut.After(checkIn) {
+		return nil, appErrors.ValidationError("checkOutDate", "Check-out date must be after check-in date")
+	}
+
+	lodging := &Lodging{
+		TripID:             tripID,
+		Name:               input.Name,
+		CheckInDate:        checkIn,
+		CheckOutDate:       checkOut,
+		ConfirmationNumber: input.ConfirmationNumber,
+		CostAmount:         input.CostAmount,
+		CostCurrency:       input.CostCurrency,
+	}
+
+	if input.Address != nil {
+		lodging.Address = *input.Address
+	}
+
+	if input.PlaceID != nil {
+		placeID, err := uuid.Parse(*input.PlaceID)
+		if err != nil {
+			return nil, appErrors.ValidationError("placeId", "Invalid place ID")
+		}
+		lodging.PlaceID = &placeID
+	}
+
+	return lodging, nil
+}
@@ -0,0 +1,56 @@
+package trip
+
+import (
+	"context"
+
+	appErrors "eztrip/api-go/errors"
+	"eztrip/api-go/logger"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// MemberRole is a user's part in a trip. Owners can do everything collaborators can.
+type MemberRole string
+
+const (
+	MemberRoleOwner        MemberRole = "owner"
+	MemberRoleCollaborator MemberRole = "collaborator"
+)
+
+// memberRoleRanks orders roles from least to most privileged; non-members rank zero
+var memberRoleRanks = map[MemberRole]int{
+	MemberRoleCollaborator: 1,
+	MemberRoleOwner:        2,
+}
+
+// AtLeast checks if the role grants everything the minimum role does
+func (r MemberRole) AtLeast(min MemberRole) bool {
+	return memberRoleRanks[r] > 0 && memberRoleRanks[r] >= memberRoleRanks[min]
+}
+
+// GetMemberRole returns the user's part in a trip without loading the trip's plan.
+// Returns false if the user isn't a member and a not found error if the trip doesn't exist.
+func (s *Service) GetMemberRole(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) (MemberRole, bool, error) {
+	var trip Trip
+	err := s.db.WithContext(ctx).
+		Select("id", "owner_id").
+		Preload("Collaborators", "user_id = ?", userID).
+		First(&trip, "id = ?", tripID).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return "", false, appErrors.NotFound("Trip")
+		}
+		logger.Log.WithFields(logrus.Fields{
+			"trip_id": tripID,
+			"user_id": userID,
+			"error":   err.Error(),
+		}).Error("Failed to fetch trip membership")
+		return "", false, appErrors.Internal("Failed to fetch trip")
+	}
+
+	role, ok := trip.MemberRole(userID)
+	return role, ok, nil
+}
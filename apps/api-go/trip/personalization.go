@@ -0,0 +1,67 @@
+package trip
+
+import (
+	"fmt"
+	"strings"
+
+	"eztrip/api-go/user"
+)
+
+// DefaultHomeCurrency is used for new trips when neither the trip nor its owner's
+// preferences name a currency
+const DefaultHomeCurrency = "USD"
+
+// DefaultsFor returns the time zone and home currency a user's new trips start with:
+// their home time zone and preferred currency if they set them
+func DefaultsFor(u *user.User) (timeZone, homeCurrency string) {
+	timeZone, homeCurrency = DefaultTimeZone, DefaultHomeCurrency
+	if u == nil {
+		return timeZone, homeCurrency
+	}
+
+	preferences := u.Preferences
+	if preferences.HomeTimeZone != nil && IsValidTimeZone(*preferences.HomeTimeZone) {
+		timeZone = *preferences.HomeTimeZone
+	}
+	if preferences.PreferredCurrency != nil {
+		homeCurrency = *preferences.PreferredCurrency
+	}
+	return timeZone, homeCurrency
+}
+
+// personalizedSystemPrompt adds the user's preferences to the system prompt, so
+// suggestions respect them without the user repeating them in every prompt
+func personalizedSystemPrompt(preferences user.Preferences) string {
+	if preferences.IsEmpty() {
+		return systemPrompt
+	}
+
+	var lines []string
+	if preferences.HomeCity != nil {
+		lines = append(lines, "Home city: "+*preferences.HomeCity)
+	}
+	if preferences.HomeTimeZone != nil {
+		lines = append(lines, "Home time zone: "+*preferences.HomeTimeZone)
+	}
+	if preferences.PreferredCurrency != nil {
+		lines = append(lines, "Give prices in "+*preferences.PreferredCurrency)
+	}
+	if preferences.Units != nil {
+		lines = append(lines, fmt.Sprintf("Use %s units", *preferences.Units))
+	}
+	if preferences.Language != nil {
+		lines = append(lines, "Answer in the language with the tag "+*preferences.Language)
+	}
+	if len(preferences.DietaryNeeds) > 0 {
+		lines = append(lines, "Dietary needs: "+strings.Join(preferences.DietaryNeeds, "; "))
+	}
+	if len(preferences.AccessibilityNeeds) > 0 {
+		lines = append(lines, "Accessibility needs: "+strings.Join(preferences.AccessibilityNeeds, "; "))
+	}
+	if len(preferences.TravelInterests) > 0 {
+		lines = append(lines, "Travel interests: "+strings.Join(preferences.TravelInterests, "; "))
+	}
+
+	return systemPrompt + "\n\nThe traveler set these preferences. Respect them unless the request says otherwise:\n- " +
+		strings.Join(lines, "\n- ")
+}
@@ -3,6 +3,9 @@ package trip
 import (
 	"context"
 
+	"eztrip/api-go/pagination"
+	"eztrip/api-go/validation"
+
 	"github.com/google/uuid"
 )
 
@@ -18,9 +21,23 @@ func NewResolver(service *Service) *Resolver {
 	}
 }
 
-// Trips returns all trips for the authenticated user
-func (r *Resolver) Trips(ctx context.Context) ([]Trip, error) {
-	return r.Service.GetAll(ctx)
+// Trips returns a page of the authenticated user's trips
+func (r *Resolver) Trips(ctx context.Context, first *int32, after *string, filter *ListFilter, orderBy *ListOrder) (*Connection, error) {
+	if filter == nil {
+		filter = &ListFilter{}
+	}
+	if orderBy == nil {
+		orderBy = &DefaultListOrder
+	}
+
+	if err := validation.ValidateStruct(*filter); err != nil {
+		return nil, err
+	}
+	if err := validation.ValidateStruct(*orderBy); err != nil {
+		return nil, err
+	}
+
+	return r.Service.List(ctx, pagination.Params{First: first, After: after}, *filter, *orderBy)
 }
 
 // Trip returns a single trip by ID
@@ -41,7 +58,269 @@ func (r *Resolver) Activity(ctx context.Context, id string) (*Activity, error) {
 	return r.Service.GetActivityByID(ctx, activityID)
 }
 
+// SetBudget validates and updates a trip's budget
+func (r *Resolver) SetBudget(ctx context.Context, tripID string, input BudgetInput) (*Trip, error) {
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validation.ValidateStruct(input); err != nil {
+		return nil, err
+	}
+
+	return r.Service.SetBudget(ctx, id, input)
+}
+
+// SetTimeZone changes a trip's time zone
+func (r *Resolver) SetTimeZone(ctx context.Context, tripID string, timeZone string) (*Trip, error) {
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Service.SetTimeZone(ctx, id, timeZone)
+}
+
+// SetTransportLeg validates and saves the booking details of a transport activity
+func (r *Resolver) SetTransportLeg(ctx context.Context, activityID string, input TransportLegInput) (*TransportLeg, error) {
+	id, err := uuid.Parse(activityID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validation.ValidateStruct(input); err != nil {
+		return nil, err
+	}
+
+	return r.Service.SetTransportLeg(ctx, id, input)
+}
+
+// RemoveTransportLeg deletes the booking details of a transport activity
+func (r *Resolver) RemoveTransportLeg(ctx context.Context, activityID string) (bool, error) {
+	id, err := uuid.Parse(activityID)
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.Service.RemoveTransportLeg(ctx, id); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// AddLodging validates and adds a lodging stay to a trip
+func (r *Resolver) AddLodging(ctx context.Context, tripID string, input LodgingInput) (*Lodging, error) {
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validation.ValidateStruct(input); err != nil {
+		return nil, err
+	}
+
+	return r.Service.AddLodging(ctx, id, input)
+}
+
+// UpdateLodging validates and replaces the details of a lodging stay
+func (r *Resolver) UpdateLodging(ctx context.Context, lodgingID string, input LodgingInput) (*Lodging, error) {
+	id, err := uuid.Parse(lodgingID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validation.ValidateStruct(input); err != nil {
+		return nil, err
+	}
+
+	return r.Service.UpdateLodging(ctx, id, input)
+}
+
+// RemoveLodging deletes a lodging stay
+func (r *Resolver) RemoveLodging(ctx context.Context, lodgingID string) (bool, error) {
+	id, err := uuid.Parse(lodgingID)
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.Service.RemoveLodging(ctx, id); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// AddTraveler validates and adds a named traveler to a trip
+func (r *Resolver) AddTraveler(ctx context.Context, tripID string, input TravelerInput) (*Traveler, error) {
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validation.ValidateStruct(input); err != nil {
+		return nil, err
+	}
+
+	return r.Service.AddTraveler(ctx, id, input)
+}
+
+// UpdateTraveler validates and replaces the details of a traveler
+func (r *Resolver) UpdateTraveler(ctx context.Context, travelerID string, input TravelerInput) (*Traveler, error) {
+	id, err := uuid.Parse(travelerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validation.ValidateStruct(input); err != nil {
+		return nil, err
+	}
+
+	return r.Service.UpdateTraveler(ctx, id, input)
+}
+
+// RemoveTraveler deletes a traveler
+func (r *Resolver) RemoveTraveler(ctx context.Context, travelerID string) (bool, error) {
+	id, err := uuid.Parse(travelerID)
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.Service.RemoveTraveler(ctx, id); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// SetActivityTravelers replaces the travelers taking part in an activity
+func (r *Resolver) SetActivityTravelers(ctx context.Context, activityID string, travelerIDs []string) (*Activity, error) {
+	id, err := uuid.Parse(activityID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Service.SetActivityTravelers(ctx, id, travelerIDs)
+}
+
+// Itinerary returns a trip's days in date order
+func (r *Resolver) Itinerary(ctx context.Context, trip *Trip) ([]*ItineraryDay, error) {
+	days, err := r.Service.LoadItinerary(ctx, trip)
+	if err != nil {
+		return nil, err
+	}
+	return toPointers(days), nil
+}
+
+// TemplateItinerary returns a template's days for the template library. Every user can
+// read templates, so their activities come without booking references or travelers.
+func (r *Resolver) TemplateItinerary(ctx context.Context, trip *Trip) ([]*ItineraryDay, error) {
+	days, err := r.Service.LoadItinerary(ctx, trip)
+	if err != nil {
+		return nil, err
+	}
+
+	redacted := make([]ItineraryDay, len(days))
+	copy(redacted, days)
+	for i := range redacted {
+		redacted[i].redacted = true
+	}
+	return toPointers(redacted), nil
+}
+
+// DayActivities returns a day's activities in time order
+func (r *Resolver) DayActivities(ctx context.Context, day *ItineraryDay) ([]*Activity, error) {
+	activities, err := r.Service.LoadActivities(ctx, day)
+	if err != nil {
+		return nil, err
+	}
+	return toPointers(activities), nil
+}
+
+// Collaborators returns the members a trip is shared with
+func (r *Resolver) Collaborators(ctx context.Context, trip *Trip) ([]*TripCollaborator, error) {
+	collaborators, err := r.Service.LoadCollaborators(ctx, trip)
+	if err != nil {
+		return nil, err
+	}
+	return toPointers(collaborators), nil
+}
+
+// TripWarnings returns scheduling warnings for every day of a trip
+func (r *Resolver) TripWarnings(ctx context.Context, trip *Trip) ([]*ScheduleWarning, error) {
+	days, err := r.Service.loadPlan(ctx, trip)
+	if err != nil {
+		return nil, err
+	}
+
+	warnings, err := r.Service.GetScheduleWarnings(ctx, days)
+	if err != nil {
+		return nil, err
+	}
+	return toPointers(warnings), nil
+}
+
+// DayWarnings returns scheduling warnings for a single itinerary day
+func (r *Resolver) DayWarnings(ctx context.Context, day *ItineraryDay) ([]*ScheduleWarning, error) {
+	activities, err := r.Service.LoadActivities(ctx, day)
+	if err != nil {
+		return nil, err
+	}
+
+	planned := *day
+	planned.Activities = activities
+	warnings, err := r.Service.GetScheduleWarnings(ctx, []ItineraryDay{planned})
+	if err != nil {
+		return nil, err
+	}
+	return toPointers(warnings), nil
+}
+
+// TripTemplates returns the template library, optionally filtered
+func (r *Resolver) TripTemplates(ctx context.Context, filter *TemplateFilter) ([]Trip, error) {
+	if filter == nil {
+		filter = &TemplateFilter{}
+	}
+
+	if err := validation.ValidateStruct(*filter); err != nil {
+		return nil, err
+	}
+
+	return r.Service.GetTemplates(ctx, *filter)
+}
+
+// CloneTrip validates and copies a trip or template to a new start date
+func (r *Resolver) CloneTrip(ctx context.Context, tripID string, newStartDate string, title *string) (*Trip, error) {
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return nil, err
+	}
+
+	input := CloneInput{NewStartDate: newStartDate, Title: title}
+	if err := validation.ValidateStruct(input); err != nil {
+		return nil, err
+	}
+
+	return r.Service.Clone(ctx, id, input)
+}
+
+// SetTripTemplate adds a trip to the template library or removes it
+func (r *Resolver) SetTripTemplate(ctx context.Context, tripID string, isTemplate bool) (*Trip, error) {
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Service.SetTemplate(ctx, id, isTemplate)
+}
+
 // TripSuggestion generates an AI-powered travel suggestion
 func (r *Resolver) TripSuggestion(ctx context.Context, prompt string) (string, error) {
 	return r.Service.GetSuggestion(ctx, prompt)
 }
+
+func toPointers[T any](items []T) []*T {
+	result := make([]*T, len(items))
+	for i := range items {
+		result[i] = &items[i]
+	}
+	return result
+}
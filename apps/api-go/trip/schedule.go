@@ -0,0 +1,199 @@
+package trip
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"eztrip/api-go/place"
+
+	"github.com/google/uuid"
+)
+
+const (
+	earthRadiusKm        = 6371.0
+	averageTravelSpeedKm = 40.0 // Conservative door-to-door speed including parking and traffic
+	minTravelDistanceKm  = 0.5  // Places closer than this are treated as walkable without a warning
+)
+
+// ScheduleWarningType represents the kind of scheduling problem found in an itinerary
+type ScheduleWarningType string
+
+const (
+	ScheduleWarningOverlap          ScheduleWarningType = "overlap"           // Two activities run at the same time
+	ScheduleWarningInsufficientTime ScheduleWarningType = "insufficient_time" // Not enough time to travel between activities
+	ScheduleWarningOutsideDay       ScheduleWarningType = "outside_day"       // Activity starts on a different date than its day
+	ScheduleWarningInvalidEndTime   ScheduleWarningType = "invalid_end_time"  // Activity ends before it starts
+	ScheduleWarningMissingLodging   ScheduleWarningType = "missing_lodging"   // No lodging booked for the night after this day
+)
+
+// ScheduleWarning describes a scheduling problem detected in an itinerary day
+type ScheduleWarning struct {
+	Type           ScheduleWarningType
+	Message        string
+	ItineraryDayID uuid.UUID
+	ActivityIDs    []uuid.UUID
+}
+
+// DetectScheduleWarnings checks a day's activities for overlaps, impossible transitions
+// and activities that fall outside the day's date in local time, and checks that the
+// night after the day has lodging. Places are keyed by ID and are used
+// to estimate travel time between consecutive activities.
+func DetectScheduleWarnings(day *ItineraryDay, places map[uuid.UUID]*place.Place) []ScheduleWarning {
+	activities := sortedActivities(day.Activities)
+
+	warnings := make([]ScheduleWarning, 0)
+	warnings = append(warnings, detectInvalidEndTimes(day, activities)...)
+	warnings = append(warnings, detectOutsideDay(day, activities)...)
+	warnings = append(warnings, detectOverlaps(day, activities)...)
+	warnings = append(warnings, detectInsufficientTravelTime(day, activities, places)...)
+	warnings = append(warnings, detectMissingLodging(day)...)
+
+	return warnings
+}
+
+// EstimateTravelTime estimates the travel time between two places using the straight-line
+// distance at an average travel speed. Returns false when either place has no coordinates.
+func EstimateTravelTime(from, to *place.Place) (time.Duration, bool) {
+	if from == nil || to == nil || !from.HasCoordinates() || !to.HasCoordinates() {
+		return 0, false
+	}
+
+	distance := haversineDistanceKm(*from.Latitude, *from.Longitude, *to.Latitude, *to.Longitude)
+	if distance < minTravelDistanceKm {
+		return 0, true
+	}
+
+	hours := distance / averageTravelSpeedKm
+	return time.Duration(hours * float64(time.Hour)).Round(time.Minute), true
+}
+
+func sortedActivities(activities []Activity) []Activity {
+	sorted := make([]Activity, len(activities))
+	copy(sorted, activities)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Time.Before(sorted[j].Time)
+	})
+	return sorted
+}
+
+func detectInvalidEndTimes(day *ItineraryDay, activities []Activity) []ScheduleWarning {
+	var warnings []ScheduleWarning
+	for _, activity := range activities {
+		if activity.Duration() >= 0 {
+			continue
+		}
+		warnings = append(warnings, ScheduleWarning{
+			Type:           ScheduleWarningInvalidEndTime,
+			Message:        fmt.Sprintf("%q ends before it starts", activity.Title),
+			ItineraryDayID: day.ID,
+			ActivityIDs:    []uuid.UUID{activity.ID},
+		})
+	}
+	return warnings
+}
+
+func detectOutsideDay(day *ItineraryDay, activities []Activity) []ScheduleWarning {
+	var warnings []ScheduleWarning
+	for _, activity := range activities {
+		activityDate := FormatDate(activity.Time, activity.TimeLocation())
+		dayDate := day.LocalDate()
+		if activityDate == dayDate {
+			continue
+		}
+		warnings = append(warnings, ScheduleWarning{
+			Type: ScheduleWarningOutsideDay,
+			Message: fmt.Sprintf("%q is scheduled on %s but belongs to day %d (%s)",
+				activity.Title, activityDate, day.DayNumber, dayDate),
+			ItineraryDayID: day.ID,
+			ActivityIDs:    []uuid.UUID{activity.ID},
+		})
+	}
+	return warnings
+}
+
+// detectOverlaps compares each activity with the activity that ends latest among those
+// before it, so a long activity is reported against every activity it overlaps.
+func detectOverlaps(day *ItineraryDay, activities []Activity) []ScheduleWarning {
+	var warnings []ScheduleWarning
+	var latest *Activity
+
+	for i := range activities {
+		current := &activities[i]
+		if latest != nil && overlaps(latest, current) {
+			warnings = append(warnings, ScheduleWarning{
+				Type:           ScheduleWarningOverlap,
+				Message:        fmt.Sprintf("%q overlaps with %q", current.Title, latest.Title),
+				ItineraryDayID: day.ID,
+				ActivityIDs:    []uuid.UUID{latest.ID, current.ID},
+			})
+		}
+		if latest == nil || current.EndsAt().After(latest.EndsAt()) {
+			latest = current
+		}
+	}
+
+	return warnings
+}
+
+func overlaps(earlier, later *Activity) bool {
+	if later.Time.Equal(earlier.Time) {
+		return true
+	}
+	return later.Time.Before(earlier.EndsAt())
+}
+
+func detectInsufficientTravelTime(day *ItineraryDay, activities []Activity, places map[uuid.UUID]*place.Place) []ScheduleWarning {
+	var warnings []ScheduleWarning
+
+	for i := 1; i < len(activities); i++ {
+		previous := &activities[i-1]
+		next := &activities[i]
+
+		gap := next.Time.Sub(previous.EndsAt())
+		if gap < 0 || previous.PlaceID == nil || next.PlaceID == nil || *previous.PlaceID == *next.PlaceID {
+			continue
+		}
+
+		travelTime, ok := EstimateTravelTime(places[*previous.PlaceID], places[*next.PlaceID])
+		if !ok || travelTime <= gap {
+			continue
+		}
+
+		warnings = append(warnings, ScheduleWarning{
+			Type: ScheduleWarningInsufficientTime,
+			Message: fmt.Sprintf("Travel from %q to %q takes about %d minutes but only %d minutes are available",
+				previous.Title, next.Title, int(travelTime.Minutes()), int(gap.Minutes())),
+			ItineraryDayID: day.ID,
+			ActivityIDs:    []uuid.UUID{previous.ID, next.ID},
+		})
+	}
+
+	return warnings
+}
+
+func detectMissingLodging(day *ItineraryDay) []ScheduleWarning {
+	if !day.needsLodging() || day.hasLodgingForNight() {
+		return nil
+	}
+
+	return []ScheduleWarning{{
+		Type:           ScheduleWarningMissingLodging,
+		Message:        fmt.Sprintf("No lodging booked for the night of day %d (%s)", day.DayNumber, day.LocalDate()),
+		ItineraryDayID: day.ID,
+		ActivityIDs:    []uuid.UUID{},
+	}}
+}
+
+func haversineDistanceKm(lat1, lng1, lat2, lng2 float64) float64 {
+	toRadians := func(deg float64) float64 { return deg * math.Pi / 180 }
+
+	dLat := toRadians(lat2 - lat1)
+	dLng := toRadians(lng2 - lng1)
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRadians(lat1))*math.Cos(toRadians(lat2))*math.Sin(dLng/2)*math.Sin(dLng/2)
+
+	return 2 * earthRadiusKm * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
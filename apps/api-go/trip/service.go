@@ -3,10 +3,12 @@ package trip
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	appErrors "eztrip/api-go/errors"
 	"eztrip/api-go/llm"
 	"eztrip/api-go/logger"
+	"eztrip/api-go/place"
 	"eztrip/api-go/user"
 
 	"github.com/google/uuid"
@@ -20,8 +22,9 @@ const (
 
 // Service handles trip operations
 type Service struct {
-	db  *gorm.DB
-	llm *llm.Service
+	db     *gorm.DB
+	llm    *llm.Service
+	places *place.Service
 }
 
 // NewService creates a new trip service
@@ -32,56 +35,53 @@ func NewService(db *gorm.DB) *Service {
 	}
 
 	return &Service{
-		db:  db,
-		llm: llmService,
+		db:     db,
+		llm:    llmService,
+		places: place.NewService(db),
 	}
 }
 
-// GetAll retrieves all trips for the authenticated user (owner or collaborator)
-func (s *Service) GetAll(ctx context.Context) ([]Trip, error) {
+// GetByID retrieves a trip by ID with all related data
+func (s *Service) GetByID(ctx context.Context, id uuid.UUID) (*Trip, error) {
 	_, userID, err := user.GetAuthenticatedUser(ctx, s.db)
 	if err != nil {
 		return nil, err
 	}
 
-	var trips []Trip
-	err = s.db.WithContext(ctx).
-		Preload("Itinerary.Activities").
-		Preload("Collaborators").
-		Where("owner_id = ?", userID).
-		Or("id IN (?)",
-			s.db.Table("trip_collaborators").
-				Select("trip_id").
-				Where("user_id = ? AND deleted_at IS NULL", userID),
-		).
-		Find(&trips).Error
+	return s.GetByIDForUser(ctx, id, userID)
+}
 
+// GetByIDForUser retrieves a trip by ID on behalf of a user who isn't authenticated by
+// the request, such as the owner of a calendar feed token
+func (s *Service) GetByIDForUser(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*Trip, error) {
+	trip, err := s.load(ctx, id)
 	if err != nil {
+		return nil, err
+	}
+
+	if !trip.HasMember(userID) {
 		logger.Log.WithFields(logrus.Fields{
+			"trip_id": id,
 			"user_id": userID,
-			"error":   err.Error(),
-		}).Error("Failed to fetch trips for user")
-		return nil, appErrors.Internal("Failed to fetch trips")
+		}).Warn("User attempted to access trip without permission")
+		return nil, appErrors.Forbidden("You don't have permission to access this trip")
 	}
 
-	return trips, nil
+	return trip, nil
 }
 
-// GetByID retrieves a trip by ID with all related data
-func (s *Service) GetByID(ctx context.Context, id uuid.UUID) (*Trip, error) {
-	_, userID, err := user.GetAuthenticatedUser(ctx, s.db)
-	if err != nil {
-		return nil, err
-	}
-
+// load retrieves a trip with all related data, without checking who may see it
+func (s *Service) load(ctx context.Context, id uuid.UUID) (*Trip, error) {
 	var trip Trip
-	err = s.db.WithContext(ctx).
+	err := preloadTripDetails(s.db.WithContext(ctx)).
 		Preload("Itinerary", func(db *gorm.DB) *gorm.DB {
 			return db.Order("date ASC")
 		}).
 		Preload("Itinerary.Activities", func(db *gorm.DB) *gorm.DB {
 			return db.Order("time ASC")
 		}).
+		Preload("Itinerary.Activities.TransportLeg").
+		Preload("Itinerary.Activities.Travelers").
 		Preload("Collaborators").
 		First(&trip, "id = ?", id).Error
 
@@ -96,25 +96,54 @@ func (s *Service) GetByID(ctx context.Context, id uuid.UUID) (*Trip, error) {
 		return nil, appErrors.Internal("Failed to fetch trip")
 	}
 
-	// Check if user is owner or collaborator
-	isOwner := trip.OwnerID == userID
-	isCollaborator := false
-	for _, collaborator := range trip.Collaborators {
-		if collaborator.UserID == userID {
-			isCollaborator = true
-			break
-		}
+	trip.linkItinerary()
+
+	return &trip, nil
+}
+
+// Create saves a new trip with its itinerary in one transaction, owned by the authenticated user.
+// Prepare functions run first in the same transaction, e.g. to create places the trip refers to.
+// A trip without a time zone or home currency gets the owner's preferred ones.
+func (s *Service) Create(ctx context.Context, trip *Trip, prepare ...func(tx *gorm.DB) error) (*Trip, error) {
+	owner, userID, err := user.GetAuthenticatedUser(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+	trip.OwnerID = userID
+
+	timeZone, homeCurrency := DefaultsFor(owner)
+	if trip.TimeZone == "" {
+		trip.TimeZone = timeZone
+	}
+	if trip.HomeCurrency == "" {
+		trip.HomeCurrency = homeCurrency
 	}
 
-	if !isOwner && !isCollaborator {
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, fn := range prepare {
+			if err := fn(tx); err != nil {
+				return err
+			}
+		}
+		return tx.Create(trip).Error
+	})
+
+	if err != nil {
 		logger.Log.WithFields(logrus.Fields{
-			"trip_id": id,
 			"user_id": userID,
-		}).Warn("User attempted to access trip without permission")
-		return nil, appErrors.Forbidden("You don't have permission to access this trip")
+			"error":   err.Error(),
+		}).Error("Failed to create trip")
+		return nil, appErrors.Internal("Failed to create trip")
 	}
 
-	return &trip, nil
+	trip.linkItinerary()
+
+	logger.Log.WithFields(logrus.Fields{
+		"trip_id": trip.ID,
+		"days":    len(trip.Itinerary),
+	}).Info("Trip created successfully")
+
+	return trip, nil
 }
 
 // GetActivityByID retrieves an activity by ID and verifies user authorization
@@ -125,7 +154,7 @@ func (s *Service) GetActivityByID(ctx context.Context, id uuid.UUID) (*Activity,
 	}
 
 	var activity Activity
-	err = s.db.WithContext(ctx).First(&activity, "id = ?", id).Error
+	err = s.db.WithContext(ctx).Preload("TransportLeg").Preload("Travelers").First(&activity, "id = ?", id).Error
 
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -163,17 +192,7 @@ func (s *Service) GetActivityByID(ctx context.Context, id uuid.UUID) (*Activity,
 		return nil, appErrors.Internal("Failed to fetch trip")
 	}
 
-	// Check if user is owner or collaborator
-	isOwner := trip.OwnerID == userID
-	isCollaborator := false
-	for _, collaborator := range trip.Collaborators {
-		if collaborator.UserID == userID {
-			isCollaborator = true
-			break
-		}
-	}
-
-	if !isOwner && !isCollaborator {
+	if !trip.HasMember(userID) {
 		logger.Log.WithFields(logrus.Fields{
 			"activity_id": id,
 			"trip_id":     itineraryDay.TripID,
@@ -182,14 +201,509 @@ func (s *Service) GetActivityByID(ctx context.Context, id uuid.UUID) (*Activity,
 		return nil, appErrors.Forbidden("You don't have permission to access this activity")
 	}
 
+	activity.tripTimeZone = trip.TimeZone
+
 	return &activity, nil
 }
 
-// GetSuggestion generates an AI-powered travel suggestion
+// SetBudget updates a trip's budget and the home currency totals are reported in
+func (s *Service) SetBudget(ctx context.Context, tripID uuid.UUID, input BudgetInput) (*Trip, error) {
+	trip, err := s.GetByID(ctx, tripID)
+	if err != nil {
+		return nil, err
+	}
+
+	trip.Budget = input.Amount
+	trip.HomeCurrency = strings.ToUpper(input.HomeCurrency)
+
+	err = s.db.WithContext(ctx).
+		Model(&Trip{}).
+		Where("id = ?", tripID).
+		Updates(map[string]interface{}{
+			"budget":        trip.Budget,
+			"home_currency": trip.HomeCurrency,
+		}).Error
+
+	if err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"trip_id": tripID,
+			"error":   err.Error(),
+		}).Error("Failed to update trip budget")
+		return nil, appErrors.Internal("Failed to update trip budget")
+	}
+
+	logger.Log.WithField("trip_id", tripID).Info("Trip budget updated successfully")
+	return trip, nil
+}
+
+// SetTimeZone changes a trip's time zone. Day dates, the trip's dates and the times of
+// activities without their own time zone are stored as instants, so they are moved to
+// keep the same local date and time in the new zone rather than shifting by the offset.
+// Transport activities follow their booked departure, which keeps its own zone.
+func (s *Service) SetTimeZone(ctx context.Context, tripID uuid.UUID, timeZone string) (*Trip, error) {
+	if !IsValidTimeZone(timeZone) {
+		return nil, appErrors.ValidationError("timeZone", "Unknown time zone")
+	}
+
+	trip, err := s.GetByID(ctx, tripID)
+	if err != nil {
+		return nil, err
+	}
+	from := trip.TimeLocation().String()
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		days := tx.Table("itinerary_days").Select("id").Where("trip_id = ?", tripID)
+
+		if err := tx.Exec(`
+			UPDATE activities SET
+				time = (time AT TIME ZONE ?) AT TIME ZONE ?,
+				end_time = (end_time AT TIME ZONE ?) AT TIME ZONE ?
+			WHERE itinerary_day_id IN (?) AND (time_zone IS NULL OR time_zone = '')
+				AND NOT EXISTS (
+					SELECT 1 FROM transport_legs
+					WHERE transport_legs.activity_id = activities.id AND transport_legs.deleted_at IS NULL
+				)`,
+			from, timeZone, from, timeZone, days).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Exec(`
+			UPDATE itinerary_days SET date = (date AT TIME ZONE ?) AT TIME ZONE ?
+			WHERE trip_id = ?`, from, timeZone, tripID).Error; err != nil {
+			return err
+		}
+
+		return tx.Exec(`
+			UPDATE trips SET
+				time_zone = ?,
+				start_date = (start_date AT TIME ZONE ?) AT TIME ZONE ?,
+				end_date = (end_date AT TIME ZONE ?) AT TIME ZONE ?
+			WHERE id = ?`, timeZone, from, timeZone, from, timeZone, tripID).Error
+	})
+
+	if err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"trip_id":   tripID,
+			"time_zone": timeZone,
+			"error":     err.Error(),
+		}).Error("Failed to update trip time zone")
+		return nil, appErrors.Internal("Failed to update trip time zone")
+	}
+
+	logger.Log.WithFields(logrus.Fields{
+		"trip_id":   tripID,
+		"from":      from,
+		"time_zone": timeZone,
+	}).Info("Trip time zone updated successfully")
+
+	return s.GetByID(ctx, tripID)
+}
+
+// SetTransportLeg creates or replaces the booking details of a transport activity.
+// The activity's start and end times are aligned with the departure and arrival, and
+// it takes the departure's time zone.
+func (s *Service) SetTransportLeg(ctx context.Context, activityID uuid.UUID, input TransportLegInput) (*TransportLeg, error) {
+	activity, err := s.GetActivityByID(ctx, activityID)
+	if err != nil {
+		return nil, err
+	}
+
+	if activity.Type != ActivityTypeTransport {
+		return nil, appErrors.ValidationError("activityId", "Transport details can only be added to transport activities")
+	}
+
+	leg, err := input.toTransportLeg(activity.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if activity.TransportLeg != nil {
+			leg.ID = activity.TransportLeg.ID
+			leg.CreatedAt = activity.TransportLeg.CreatedAt
+		}
+
+		if err := tx.Save(leg).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&Activity{}).
+			Where("id = ?", activity.ID).
+			Updates(map[string]interface{}{
+				"time":      leg.DepartTime,
+				"end_time":  leg.ArriveTime,
+				"time_zone": leg.DepartTimeZone,
+			}).Error
+	})
+
+	if err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"activity_id": activityID,
+			"error":       err.Error(),
+		}).Error("Failed to save transport leg")
+		return nil, appErrors.Internal("Failed to save transport details")
+	}
+
+	logger.Log.WithFields(logrus.Fields{
+		"activity_id":      activityID,
+		"transport_leg_id": leg.ID,
+	}).Info("Transport leg saved successfully")
+
+	return leg, nil
+}
+
+// RemoveTransportLeg deletes the booking details of a transport activity
+func (s *Service) RemoveTransportLeg(ctx context.Context, activityID uuid.UUID) error {
+	activity, err := s.GetActivityByID(ctx, activityID)
+	if err != nil {
+		return err
+	}
+
+	if activity.TransportLeg == nil {
+		return appErrors.NotFound("Transport leg")
+	}
+
+	if err := s.db.WithContext(ctx).Delete(activity.TransportLeg).Error; err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"activity_id": activityID,
+			"error":       err.Error(),
+		}).Error("Failed to delete transport leg")
+		return appErrors.Internal("Failed to delete transport details")
+	}
+
+	logger.Log.WithField("activity_id", activityID).Info("Transport leg deleted successfully")
+	return nil
+}
+
+// AddLodging adds a lodging stay to a trip the user has access to
+func (s *Service) AddLodging(ctx context.Context, tripID uuid.UUID, input LodgingInput) (*Lodging, error) {
+	if _, err := s.GetByID(ctx, tripID); err != nil {
+		return nil, err
+	}
+
+	lodging, err := input.toLodging(tripID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.db.WithContext(ctx).Create(lodging).Error; err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"trip_id": tripID,
+			"error":   err.Error(),
+		}).Error("Failed to create lodging")
+		return nil, appErrors.Internal("Failed to create lodging")
+	}
+
+	logger.Log.WithFields(logrus.Fields{
+		"trip_id":    tripID,
+		"lodging_id": lodging.ID,
+	}).Info("Lodging created successfully")
+
+	return lodging, nil
+}
+
+// UpdateLodging replaces the details of an existing lodging stay
+func (s *Service) UpdateLodging(ctx context.Context, id uuid.UUID, input LodgingInput) (*Lodging, error) {
+	existing, err := s.getLodgingForUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	lodging, err := input.toLodging(existing.TripID)
+	if err != nil {
+		return nil, err
+	}
+	lodging.ID = existing.ID
+	lodging.CreatedAt = existing.CreatedAt
+
+	if err := s.db.WithContext(ctx).Save(lodging).Error; err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"lodging_id": id,
+			"error":      err.Error(),
+		}).Error("Failed to update lodging")
+		return nil, appErrors.Internal("Failed to update lodging")
+	}
+
+	logger.Log.WithField("lodging_id", id).Info("Lodging updated successfully")
+	return lodging, nil
+}
+
+// RemoveLodging deletes a lodging stay
+func (s *Service) RemoveLodging(ctx context.Context, id uuid.UUID) error {
+	lodging, err := s.getLodgingForUser(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.db.WithContext(ctx).Delete(lodging).Error; err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"lodging_id": id,
+			"error":      err.Error(),
+		}).Error("Failed to delete lodging")
+		return appErrors.Internal("Failed to delete lodging")
+	}
+
+	logger.Log.WithField("lodging_id", id).Info("Lodging deleted successfully")
+	return nil
+}
+
+// getLodgingForUser retrieves a lodging stay and verifies the user can access its trip
+func (s *Service) getLodgingForUser(ctx context.Context, id uuid.UUID) (*Lodging, error) {
+	var lodging Lodging
+	if err := s.db.WithContext(ctx).First(&lodging, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, appErrors.NotFound("Lodging")
+		}
+		logger.Log.WithFields(logrus.Fields{
+			"lodging_id": id,
+			"error":      err.Error(),
+		}).Error("Failed to fetch lodging by ID")
+		return nil, appErrors.Internal("Failed to fetch lodging")
+	}
+
+	if _, err := s.GetByID(ctx, lodging.TripID); err != nil {
+		return nil, err
+	}
+
+	return &lodging, nil
+}
+
+// AddTraveler adds a named traveler to a trip the user has access to
+func (s *Service) AddTraveler(ctx context.Context, tripID uuid.UUID, input TravelerInput) (*Traveler, error) {
+	trip, err := s.GetByID(ctx, tripID)
+	if err != nil {
+		return nil, err
+	}
+
+	traveler, err := input.toTraveler(trip)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkUniqueTravelerUser(trip, traveler); err != nil {
+		return nil, err
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(traveler).Error; err != nil {
+			return err
+		}
+		return syncTravelerCount(tx, tripID)
+	})
+
+	if err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"trip_id": tripID,
+			"error":   err.Error(),
+		}).Error("Failed to create traveler")
+		return nil, appErrors.Internal("Failed to create traveler")
+	}
+
+	logger.Log.WithFields(logrus.Fields{
+		"trip_id":     tripID,
+		"traveler_id": traveler.ID,
+	}).Info("Traveler created successfully")
+
+	return traveler, nil
+}
+
+// UpdateTraveler replaces the details of a traveler
+func (s *Service) UpdateTraveler(ctx context.Context, id uuid.UUID, input TravelerInput) (*Traveler, error) {
+	existing, trip, err := s.getTravelerForUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	traveler, err := input.toTraveler(trip)
+	if err != nil {
+		return nil, err
+	}
+	traveler.ID = existing.ID
+	traveler.CreatedAt = existing.CreatedAt
+
+	if err := checkUniqueTravelerUser(trip, traveler); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.WithContext(ctx).Save(traveler).Error; err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"traveler_id": id,
+			"error":       err.Error(),
+		}).Error("Failed to update traveler")
+		return nil, appErrors.Internal("Failed to update traveler")
+	}
+
+	logger.Log.WithField("traveler_id", id).Info("Traveler updated successfully")
+	return traveler, nil
+}
+
+// RemoveTraveler deletes a traveler and unassigns them from activities and expenses
+func (s *Service) RemoveTraveler(ctx context.Context, id uuid.UUID) error {
+	traveler, _, err := s.getTravelerForUser(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM activity_travelers WHERE traveler_id = ?", id).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM expense_travelers WHERE traveler_id = ?", id).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(traveler).Error; err != nil {
+			return err
+		}
+		return syncTravelerCount(tx, traveler.TripID)
+	})
+
+	if err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"traveler_id": id,
+			"error":       err.Error(),
+		}).Error("Failed to delete traveler")
+		return appErrors.Internal("Failed to delete traveler")
+	}
+
+	logger.Log.WithField("traveler_id", id).Info("Traveler deleted successfully")
+	return nil
+}
+
+// SetActivityTravelers replaces the travelers taking part in an activity.
+// An empty list means the whole group takes part.
+func (s *Service) SetActivityTravelers(ctx context.Context, activityID uuid.UUID, travelerIDs []string) (*Activity, error) {
+	activity, err := s.GetActivityByID(ctx, activityID)
+	if err != nil {
+		return nil, err
+	}
+
+	var day ItineraryDay
+	if err := s.db.WithContext(ctx).First(&day, "id = ?", activity.ItineraryDayID).Error; err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"itinerary_day_id": activity.ItineraryDayID,
+			"error":            err.Error(),
+		}).Error("Failed to fetch itinerary day")
+		return nil, appErrors.Internal("Failed to fetch itinerary day")
+	}
+
+	trip := Trip{ID: day.TripID}
+	if err := s.db.WithContext(ctx).Where("trip_id = ?", day.TripID).Find(&trip.TravelerList).Error; err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"trip_id": day.TripID,
+			"error":   err.Error(),
+		}).Error("Failed to fetch travelers for trip")
+		return nil, appErrors.Internal("Failed to fetch travelers")
+	}
+
+	travelers, err := trip.ResolveTravelers(travelerIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.db.WithContext(ctx).Model(activity).Association("Travelers").Replace(travelers); err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"activity_id": activityID,
+			"error":       err.Error(),
+		}).Error("Failed to assign travelers to activity")
+		return nil, appErrors.Internal("Failed to assign travelers")
+	}
+
+	activity.Travelers = travelers
+	logger.Log.WithField("activity_id", activityID).Info("Activity travelers updated successfully")
+	return activity, nil
+}
+
+// getTravelerForUser retrieves a traveler and verifies the user can access its trip
+func (s *Service) getTravelerForUser(ctx context.Context, id uuid.UUID) (*Traveler, *Trip, error) {
+	var traveler Traveler
+	if err := s.db.WithContext(ctx).First(&traveler, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil, appErrors.NotFound("Traveler")
+		}
+		logger.Log.WithFields(logrus.Fields{
+			"traveler_id": id,
+			"error":       err.Error(),
+		}).Error("Failed to fetch traveler by ID")
+		return nil, nil, appErrors.Internal("Failed to fetch traveler")
+	}
+
+	trip, err := s.GetByID(ctx, traveler.TripID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &traveler, trip, nil
+}
+
+// checkUniqueTravelerUser ensures a user is linked to at most one traveler per trip
+func checkUniqueTravelerUser(trip *Trip, traveler *Traveler) error {
+	if traveler.UserID == nil {
+		return nil
+	}
+	for _, other := range trip.TravelerList {
+		if other.ID != traveler.ID && other.UserID != nil && *other.UserID == *traveler.UserID {
+			return appErrors.ValidationError("userId", "This user is already linked to another traveler")
+		}
+	}
+	return nil
+}
+
+// syncTravelerCount keeps the trip's head count equal to its named travelers once
+// travelers are added or removed. A trip always counts at least one traveler, so
+// removing the last named traveler leaves a count of one.
+func syncTravelerCount(tx *gorm.DB, tripID uuid.UUID) error {
+	return tx.Exec(`
+		UPDATE trips SET travelers = GREATEST(counts.total, 1)
+		FROM (SELECT COUNT(*) AS total FROM travelers WHERE trip_id = ? AND deleted_at IS NULL) AS counts
+		WHERE trips.id = ?`, tripID, tripID).Error
+}
+
+// GetScheduleWarnings detects scheduling problems across the given itinerary days.
+// Days are expected to be loaded through an authorized trip query with their activities.
+func (s *Service) GetScheduleWarnings(ctx context.Context, days []ItineraryDay) ([]ScheduleWarning, error) {
+	places, err := s.loadActivityPlaces(ctx, days)
+	if err != nil {
+		return nil, err
+	}
+
+	warnings := make([]ScheduleWarning, 0)
+	for i := range days {
+		warnings = append(warnings, DetectScheduleWarnings(&days[i], places)...)
+	}
+
+	return warnings, nil
+}
+
+// loadActivityPlaces fetches the places referenced by activities in the given days, keyed by ID
+func (s *Service) loadActivityPlaces(ctx context.Context, days []ItineraryDay) (map[uuid.UUID]*place.Place, error) {
+	var placeIDs []uuid.UUID
+	for _, day := range days {
+		for _, activity := range day.Activities {
+			if activity.PlaceID != nil {
+				placeIDs = append(placeIDs, *activity.PlaceID)
+			}
+		}
+	}
+
+	places, err := s.places.LoadByIDs(ctx, placeIDs)
+	if err != nil {
+		return nil, appErrors.Internal("Failed to fetch places")
+	}
+
+	return places, nil
+}
+
+// GetSuggestion generates an AI-powered travel suggestion, personalized with the
+// current user's preferences
 func (s *Service) GetSuggestion(ctx context.Context, prompt string) (string, error) {
 	if s.llm == nil {
 		return "", fmt.Errorf("AI features are not available")
 	}
 
-	return s.llm.Complete(ctx, systemPrompt, prompt)
+	current, _, err := user.GetAuthenticatedUser(ctx, s.db)
+	if err != nil {
+		return "", err
+	}
+
+	return s.llm.Complete(ctx, personalizedSystemPrompt(current.Preferences), prompt)
 }
@@ -0,0 +1,245 @@
+package trip
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	appErrors "eztrip/api-go/errors"
+	"eztrip/api-go/logger"
+	"eztrip/api-go/user"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// TemplateFilter narrows the template library by destination and trip length in days
+type TemplateFilter struct {
+	Destination *string `json:"destination" validate:"omitempty,max=255"`
+	MinDays     *int32  `json:"minDays" validate:"omitempty,gte=1"`
+	MaxDays     *int32  `json:"maxDays" validate:"omitempty,gte=1"`
+}
+
+// CloneInput represents the details submitted when cloning a trip
+type CloneInput struct {
+	NewStartDate string  `json:"newStartDate" validate:"required,datetime=2006-01-02"`
+	Title        *string `json:"title" validate:"omitempty,min=1,max=255"`
+}
+
+// LengthDays returns the number of calendar days the trip spans, in its own time zone
+func (t *Trip) LengthDays() int {
+	location := t.TimeLocation()
+	start, _ := time.Parse(dateFormat, FormatDate(t.StartDate, location))
+	end, _ := time.Parse(dateFormat, FormatDate(t.EndDate, location))
+	return int(end.Sub(start).Hours()/24) + 1
+}
+
+// GetTemplates lists the template library, matching destinations case-insensitively.
+// Templates are readable by every user, so they are returned without membership checks.
+func (s *Service) GetTemplates(ctx context.Context, filter TemplateFilter) ([]Trip, error) {
+	if _, _, err := user.GetAuthenticatedUser(ctx, s.db); err != nil {
+		return nil, err
+	}
+
+	query := s.db.WithContext(ctx).Where("is_template = ?", true)
+
+	if filter.Destination != nil && strings.TrimSpace(*filter.Destination) != "" {
+		query = query.Where("destination ILIKE ?", "%"+escapeLike(strings.TrimSpace(*filter.Destination))+"%")
+	}
+
+	dayCount := "(SELECT COUNT(*) FROM itinerary_days WHERE itinerary_days.trip_id = trips.id AND itinerary_days.deleted_at IS NULL)"
+	if filter.MinDays != nil {
+		query = query.Where(dayCount+" >= ?", *filter.MinDays)
+	}
+	if filter.MaxDays != nil {
+		query = query.Where(dayCount+" <= ?", *filter.MaxDays)
+	}
+
+	var templates []Trip
+	if err := query.Order("destination ASC, title ASC").Find(&templates).Error; err != nil {
+		logger.Log.WithField("error", err.Error()).Error("Failed to fetch trip templates")
+		return nil, appErrors.Internal("Failed to fetch trip templates")
+	}
+
+	return templates, nil
+}
+
+// SetTemplate adds a trip to the template library or removes it. Only the owner can
+// publish a trip, since every user can then read and clone it.
+func (s *Service) SetTemplate(ctx context.Context, tripID uuid.UUID, isTemplate bool) (*Trip, error) {
+	trip, err := s.GetByID(ctx, tripID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, userID, err := user.GetAuthenticatedUser(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+	if role, _ := trip.MemberRole(userID); !role.AtLeast(MemberRoleOwner) {
+		return nil, appErrors.Forbidden("Only the trip owner can change whether it is a template")
+	}
+
+	if err := s.db.WithContext(ctx).Model(trip).Update("is_template", isTemplate).Error; err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"trip_id": tripID,
+			"error":   err.Error(),
+		}).Error("Failed to update trip template flag")
+		return nil, appErrors.Internal("Failed to update trip")
+	}
+
+	logger.Log.WithFields(logrus.Fields{
+		"trip_id":     tripID,
+		"is_template": isTemplate,
+	}).Info("Trip template flag updated successfully")
+
+	return trip, nil
+}
+
+// Clone deep-copies a trip the user is a member of, or any template, into a new trip
+// owned by the user. Days, activities and transport legs move to the new start date
+// keeping their local wall-clock times. Booking references, lodging, expenses and
+// collaborators stay with the original; travelers are copied only from the user's own trips.
+func (s *Service) Clone(ctx context.Context, tripID uuid.UUID, input CloneInput) (*Trip, error) {
+	_, userID, err := user.GetAuthenticatedUser(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+
+	source, err := s.load(ctx, tripID)
+	if err != nil {
+		return nil, err
+	}
+
+	isMember := source.HasMember(userID)
+	if !isMember && !source.IsTemplate {
+		logger.Log.WithFields(logrus.Fields{
+			"trip_id": tripID,
+			"user_id": userID,
+		}).Warn("User attempted to clone trip without permission")
+		return nil, appErrors.Forbidden("You don't have permission to access this trip")
+	}
+
+	location := source.TimeLocation()
+	newStart, err := time.ParseInLocation(dateFormat, input.NewStartDate, location)
+	if err != nil {
+		return nil, appErrors.ValidationError("newStartDate", "New start date must be formatted as YYYY-MM-DD")
+	}
+	sourceStart, _ := time.ParseInLocation(dateFormat, FormatDate(source.StartDate, location), location)
+	offset := int(newStart.Sub(sourceStart).Round(24*time.Hour).Hours() / 24)
+
+	clone := source.clone(offset, isMember)
+	if input.Title != nil {
+		clone.Title = strings.TrimSpace(*input.Title)
+	}
+
+	created, err := s.Create(ctx, clone)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Log.WithFields(logrus.Fields{
+		"trip_id":        created.ID,
+		"source_trip_id": tripID,
+		"offset_days":    offset,
+	}).Info("Trip cloned successfully")
+
+	return created, nil
+}
+
+// clone copies the trip with its itinerary shifted by a number of days
+func (t *Trip) clone(offsetDays int, withTravelers bool) *Trip {
+	location := t.TimeLocation()
+	sourceID := t.ID
+
+	clone := &Trip{
+		ID:           uuid.New(), // Assigned up front so copied travelers can be shared by activities
+		Title:        t.Title,
+		Destination:  t.Destination,
+		StartDate:    shiftDays(t.StartDate, location, offsetDays),
+		EndDate:      shiftDays(t.EndDate, location, offsetDays),
+		Travelers:    t.Travelers,
+		TimeZone:     t.TimeZone,
+		HomeCurrency: t.HomeCurrency,
+		Budget:       t.Budget,
+		ClonedFromID: &sourceID,
+		Itinerary:    make([]ItineraryDay, len(t.Itinerary)),
+	}
+
+	travelers := make(map[uuid.UUID]Traveler)
+	if withTravelers {
+		for _, traveler := range t.TravelerList {
+			copied := Traveler{
+				ID:       uuid.New(),
+				TripID:   clone.ID,
+				UserID:   traveler.UserID,
+				Name:     traveler.Name,
+				AgeGroup: traveler.AgeGroup,
+			}
+			// Only the owner carries over as a member, so only their link stays valid
+			if copied.UserID != nil && *copied.UserID != t.OwnerID {
+				copied.UserID = nil
+			}
+			travelers[traveler.ID] = copied
+			clone.TravelerList = append(clone.TravelerList, copied)
+		}
+	}
+
+	for i, day := range t.Itinerary {
+		clone.Itinerary[i] = ItineraryDay{
+			Date:       shiftDays(day.Date, location, offsetDays),
+			DayNumber:  day.DayNumber,
+			Activities: make([]Activity, len(day.Activities)),
+		}
+
+		for j, activity := range day.Activities {
+			activityLocation := activity.TimeLocation()
+			copied := Activity{
+				PlaceID:     activity.PlaceID,
+				Type:        activity.Type,
+				Time:        shiftDays(activity.Time, activityLocation, offsetDays),
+				TimeZone:    activity.TimeZone,
+				Title:       activity.Title,
+				Location:    activity.Location,
+				Category:    activity.Category,
+				Description: activity.Description,
+				Notes:       activity.Notes,
+			}
+			if activity.EndTime != nil {
+				endTime := shiftDays(*activity.EndTime, activityLocation, offsetDays)
+				copied.EndTime = &endTime
+			}
+			if leg := activity.TransportLeg; leg != nil {
+				copied.TransportLeg = &TransportLeg{
+					Mode:           leg.Mode,
+					Origin:         leg.Origin,
+					Destination:    leg.Destination,
+					DepartTime:     shiftDays(leg.DepartTime, LoadLocation(leg.DepartTimeZone), offsetDays),
+					DepartTimeZone: leg.DepartTimeZone,
+					ArriveTime:     shiftDays(leg.ArriveTime, LoadLocation(leg.ArriveTimeZone), offsetDays),
+					ArriveTimeZone: leg.ArriveTimeZone,
+					Carrier:        leg.Carrier,
+				}
+			}
+			for _, traveler := range activity.Travelers {
+				if copiedTraveler, ok := travelers[traveler.ID]; ok {
+					copied.Travelers = append(copied.Travelers, copiedTraveler)
+				}
+			}
+			clone.Itinerary[i].Activities[j] = copied
+		}
+	}
+
+	return clone
+}
+
+// shiftDays moves a timestamp by whole calendar days in the given location, keeping
+// its wall-clock time across daylight saving changes
+func shiftDays(t time.Time, location *time.Location, days int) time.Time {
+	return t.In(location).AddDate(0, 0, days)
+}
+
+// escapeLike escapes LIKE wildcards in user input
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
@@ -0,0 +1,89 @@
+package trip
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	// Embed the IANA database so zones resolve in minimal containers without /usr/share/zoneinfo
+	_ "time/tzdata"
+
+	"eztrip/api-go/logger"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultTimeZone is used when a trip has no time zone or an unknown one
+	DefaultTimeZone = "UTC"
+
+	dateFormat     = "2006-01-02"
+	dateTimeFormat = "2006-01-02T15:04:05Z07:00"
+)
+
+// localDateTimeFormats are accepted when a date-time is given without an offset
+var localDateTimeFormats = []string{
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+}
+
+// locationCache avoids re-parsing zone data for every resolved field
+var locationCache sync.Map
+
+// LoadLocation resolves an IANA time zone name, falling back to UTC for empty or unknown names
+func LoadLocation(name string) *time.Location {
+	if name == "" {
+		return time.UTC
+	}
+
+	if cached, ok := locationCache.Load(name); ok {
+		return cached.(*time.Location)
+	}
+
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"time_zone": name,
+			"error":     err.Error(),
+		}).Warn("Unknown time zone, falling back to UTC")
+		return time.UTC
+	}
+
+	locationCache.Store(name, location)
+	return location
+}
+
+// IsValidTimeZone checks if the name is a known IANA time zone
+func IsValidTimeZone(name string) bool {
+	if name == "" {
+		return false
+	}
+	_, err := time.LoadLocation(name)
+	return err == nil
+}
+
+// FormatDate formats a timestamp as a calendar date in the given location
+func FormatDate(t time.Time, location *time.Location) string {
+	return t.In(location).Format(dateFormat)
+}
+
+// FormatDateTime formats a timestamp as RFC 3339 with the offset of the given location
+func FormatDateTime(t time.Time, location *time.Location) string {
+	return t.In(location).Format(dateTimeFormat)
+}
+
+// ParseDateTime parses an RFC 3339 timestamp, or a local date-time without an offset
+// interpreted in the given location
+func ParseDateTime(value string, location *time.Location) (time.Time, error) {
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return parsed, nil
+	}
+
+	for _, format := range localDateTimeFormats {
+		if parsed, err := time.ParseInLocation(format, value, location); err == nil {
+			return parsed, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid date-time: %s", value)
+}
@@ -0,0 +1,92 @@
+package trip
+
+import (
+	"time"
+
+	appErrors "eztrip/api-go/errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TransportMode represents how a traveler gets from origin to destination
+type TransportMode string
+
+const (
+	TransportModeFlight TransportMode = "flight"
+	TransportModeTrain  TransportMode = "train"
+	TransportModeBus    TransportMode = "bus"
+	TransportModeCar    TransportMode = "car"
+	TransportModeFerry  TransportMode = "ferry"
+	TransportModeOther  TransportMode = "other"
+)
+
+// TransportLeg holds the booking details of a transport activity
+type TransportLeg struct {
+	ID                 uuid.UUID      `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	ActivityID         uuid.UUID      `gorm:"type:uuid;not null;index"`
+	Mode               TransportMode  `gorm:"column:mode;not null"`
+	Origin             string         `gorm:"column:origin;not null"`
+	Destination        string         `gorm:"column:destination;not null"`
+	DepartTime         time.Time      `gorm:"column:depart_time;not null"`
+	DepartTimeZone     string         `gorm:"column:depart_time_zone;not null"`
+	ArriveTime         time.Time      `gorm:"column:arrive_time;not null"`
+	ArriveTimeZone     string         `gorm:"column:arrive_time_zone;not null"`
+	Carrier            *string        `gorm:"column:carrier"`
+	ConfirmationNumber *string        `gorm:"column:confirmation_number"`
+	Seat               *string        `gorm:"column:seat"`
+	CreatedAt          time.Time      `gorm:"column:created_at"`
+	UpdatedAt          time.Time      `gorm:"column:updated_at"`
+	DeletedAt          gorm.DeletedAt `gorm:"column:deleted_at;index"`
+}
+
+// TableName specifies the table name for the TransportLeg model
+func (TransportLeg) TableName() string {
+	return "transport_legs"
+}
+
+// TransportLegInput represents the details submitted when booking a transport leg.
+// Times accept RFC 3339 with an offset or a local date-time in the matching time zone.
+type TransportLegInput struct {
+	Mode               TransportMode `json:"mode" validate:"required,oneof=flight train bus car ferry other"`
+	Origin             string        `json:"origin" validate:"required,min=1,max=255"`
+	Destination        string        `json:"destination" validate:"required,min=1,max=255"`
+	DepartTime         string        `json:"departTime" validate:"required"`
+	DepartTimeZone     string        `json:"departTimeZone" validate:"required,timezone"`
+	ArriveTime         string        `json:"arriveTime" validate:"required"`
+	ArriveTimeZone     string        `json:"arriveTimeZone" validate:"required,timezone"`
+	Carrier            *string       `json:"carrier" validate:"omitempty,max=255"`
+	ConfirmationNumber *string       `json:"confirmationNumber" validate:"omitempty,max=100"`
+	Seat               *string       `json:"seat" validate:"omitempty,max=50"`
+}
+
+// toTransportLeg parses the input times and checks that arrival is after departure
+func (input TransportLegInput) toTransportLeg(activityID uuid.UUID) (*TransportLeg, error) {
+	departTime, err := ParseDateTime(input.DepartTime, LoadLocation(input.DepartTimeZone))
+	if err != nil {
+		return nil, appErrors.ValidationError("departTime", "Departure time must be an ISO 8601 date-time")
+	}
+
+	arriveTime, err := ParseDateTime(input.ArriveTime, LoadLocation(input.ArriveTimeZone))
+	if err != nil {
+		return nil, appErrors.ValidationError("arriveTime", "Arrival time must be an ISO 8601 date-time")
+	}
+
+	if !arriveTime.After(departTime) {
+		return nil, appErrors.ValidationError("arriveTime", "Arrival time must be after departure time")
+	}
+
+	return &TransportLeg{
+		ActivityID:         activityID,
+		Mode:               input.Mode,
+		Origin:             input.Origin,
+		Destination:        input.Destination,
+		DepartTime:         departTime,
+		DepartTimeZone:     input.DepartTimeZone,
+		ArriveTime:         arriveTime,
+		ArriveTimeZone:     input.ArriveTimeZone,
+		Carrier:            input.Carrier,
+		ConfirmationNumber: input.ConfirmationNumber,
+		Seat:               input.Seat,
+	}, nil
+}
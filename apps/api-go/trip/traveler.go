@@ -0,0 +1,110 @@
+package trip
+
+import (
+	"strings"
+	"time"
+
+	appErrors "eztrip/api-go/errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AgeGroup represents the age bracket of a traveler, used for pricing and suitability
+type AgeGroup string
+
+const (
+	AgeGroupAdult  AgeGroup = "adult"
+	AgeGroupSenior AgeGroup = "senior"
+	AgeGroupTeen   AgeGroup = "teen"
+	AgeGroupChild  AgeGroup = "child"
+	AgeGroupInfant AgeGroup = "infant"
+)
+
+// Traveler represents a person on a trip, who may or may not have an account
+type Traveler struct {
+	ID        uuid.UUID      `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	TripID    uuid.UUID      `gorm:"type:uuid;not null;index"`
+	UserID    *uuid.UUID     `gorm:"type:uuid;index"` // Nullable - links the traveler to a registered trip member
+	Name      string         `gorm:"column:name;not null"`
+	AgeGroup  AgeGroup       `gorm:"column:age_group;not null;default:'adult'"`
+	CreatedAt time.Time      `gorm:"column:created_at"`
+	UpdatedAt time.Time      `gorm:"column:updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at;index"`
+}
+
+// TableName specifies the table name for the Traveler model
+func (Traveler) TableName() string {
+	return "travelers"
+}
+
+// TravelerInput represents the details submitted when adding or updating a traveler
+type TravelerInput struct {
+	Name     string   `json:"name" validate:"required,min=1,max=255"`
+	AgeGroup AgeGroup `json:"ageGroup" validate:"required,oneof=adult senior teen child infant"`
+	UserID   *string  `json:"userId" validate:"omitempty,uuid"`
+}
+
+// toTraveler checks that a linked user is a member of the trip
+func (input TravelerInput) toTraveler(trip *Trip) (*Traveler, error) {
+	traveler := &Traveler{
+		TripID:   trip.ID,
+		Name:     strings.TrimSpace(input.Name),
+		AgeGroup: input.AgeGroup,
+	}
+
+	if input.UserID != nil {
+		userID, err := uuid.Parse(*input.UserID)
+		if err != nil || !trip.HasMember(userID) {
+			return nil, appErrors.ValidationError("userId", "Linked user must be the trip owner or a collaborator")
+		}
+		traveler.UserID = &userID
+	}
+
+	return traveler, nil
+}
+
+// HasTraveler checks if the traveler belongs to the trip
+func (t *Trip) HasTraveler(travelerID uuid.UUID) bool {
+	for _, traveler := range t.TravelerList {
+		if traveler.ID == travelerID {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolveTravelers parses traveler IDs and checks they belong to the trip
+func (t *Trip) ResolveTravelers(travelerIDs []string) ([]Traveler, error) {
+	travelers := make([]Traveler, 0, len(travelerIDs))
+	seen := make(map[uuid.UUID]bool, len(travelerIDs))
+
+	for _, rawID := range travelerIDs {
+		id, err := uuid.Parse(rawID)
+		if err != nil || !t.HasTraveler(id) {
+			return nil, appErrors.ValidationError("travelerIds", "Travelers must belong to this trip")
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		for _, traveler := range t.TravelerList {
+			if traveler.ID == id {
+				travelers = append(travelers, traveler)
+				break
+			}
+		}
+	}
+
+	return travelers, nil
+}
+
+// TravelerCount returns the number of named travelers, falling back to the stored
+// head count for trips whose travelers haven't been named yet
+func (t *Trip) TravelerCount() int {
+	if len(t.TravelerList) > 0 {
+		return len(t.TravelerList)
+	}
+	return t.Travelers
+}
@@ -1,28 +1,39 @@
 package trip
 
 import (
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
+var unsafeFileNameChars = regexp.MustCompile(`[^a-zA-Z0-9]+`)
+
 // Trip represents a travel itinerary with multiple days and activities
 type Trip struct {
-	ID          uuid.UUID      `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
-	OwnerID     uuid.UUID      `gorm:"type:uuid;not null;index"`
-	Title       string         `gorm:"column:title;not null"`
-	Destination string         `gorm:"column:destination;not null"`
-	StartDate   time.Time      `gorm:"column:start_date;not null"`
-	EndDate     time.Time      `gorm:"column:end_date;not null"`
-	Travelers   int            `gorm:"column:travelers;default:1"`
-	CreatedAt   time.Time      `gorm:"column:created_at"`
-	UpdatedAt   time.Time      `gorm:"column:updated_at"`
-	DeletedAt   gorm.DeletedAt `gorm:"column:deleted_at;index"`
+	ID           uuid.UUID      `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	OwnerID      uuid.UUID      `gorm:"type:uuid;not null;index"`
+	Title        string         `gorm:"column:title;not null"`
+	Destination  string         `gorm:"column:destination;not null"`
+	StartDate    time.Time      `gorm:"column:start_date;not null"`
+	EndDate      time.Time      `gorm:"column:end_date;not null"`
+	Travelers    int            `gorm:"column:travelers;default:1"`                  // Head count - kept in sync with TravelerList once travelers are named
+	TimeZone     string         `gorm:"column:time_zone;not null;default:'UTC'"`     // IANA time zone, e.g. Pacific/Honolulu
+	HomeCurrency string         `gorm:"column:home_currency;not null;default:'USD'"` // ISO 4217 code budgets and totals are reported in
+	Budget       *float64       `gorm:"column:budget"`                               // Nullable - total budget in the home currency
+	IsTemplate   bool           `gorm:"column:is_template;not null;default:false"`   // Listed in the template library for anyone to clone
+	ClonedFromID *uuid.UUID     `gorm:"type:uuid;index"`                             // Nullable - the trip or template this one was cloned from
+	CreatedAt    time.Time      `gorm:"column:created_at"`
+	UpdatedAt    time.Time      `gorm:"column:updated_at"`
+	DeletedAt    gorm.DeletedAt `gorm:"column:deleted_at;index"`
 
 	// Relationships
 	Itinerary     []ItineraryDay     `gorm:"foreignKey:TripID;constraint:OnDelete:CASCADE"`
 	Collaborators []TripCollaborator `gorm:"foreignKey:TripID;constraint:OnDelete:CASCADE"`
+	Lodgings      []Lodging          `gorm:"foreignKey:TripID;constraint:OnDelete:CASCADE"`
+	TravelerList  []Traveler         `gorm:"foreignKey:TripID;constraint:OnDelete:CASCADE"`
 }
 
 // TableName specifies the table name for the Trip model
@@ -30,3 +41,74 @@ func (Trip) TableName() string {
 	return "trips"
 }
 
+// TimeLocation returns the trip's time zone
+func (t *Trip) TimeLocation() *time.Location {
+	return LoadLocation(t.TimeZone)
+}
+
+// FileName turns the trip's title into a safe download name
+func (t *Trip) FileName() string {
+	name := strings.Trim(unsafeFileNameChars.ReplaceAllString(strings.ToLower(t.Title), "-"), "-")
+	if name == "" {
+		return "trip"
+	}
+	return name
+}
+
+// BudgetInput represents the budget settings submitted for a trip
+type BudgetInput struct {
+	Amount       *float64 `json:"amount" validate:"omitempty,gte=0"`
+	HomeCurrency string   `json:"homeCurrency" validate:"required,iso4217"`
+}
+
+// HasMember checks if the user is the trip owner or a collaborator
+func (t *Trip) HasMember(userID uuid.UUID) bool {
+	_, ok := t.MemberRole(userID)
+	return ok
+}
+
+// MemberRole returns the user's part in the trip, or false if they aren't a member
+func (t *Trip) MemberRole(userID uuid.UUID) (MemberRole, bool) {
+	if t.OwnerID == userID {
+		return MemberRoleOwner, true
+	}
+	for _, collaborator := range t.Collaborators {
+		if collaborator.UserID == userID {
+			return MemberRoleCollaborator, true
+		}
+	}
+	return "", false
+}
+
+// MemberIDs returns the owner followed by the collaborators
+func (t *Trip) MemberIDs() []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(t.Collaborators)+1)
+	ids = append(ids, t.OwnerID)
+	for _, collaborator := range t.Collaborators {
+		ids = append(ids, collaborator.UserID)
+	}
+	return ids
+}
+
+// HasActivity checks if the activity belongs to one of the trip's itinerary days
+func (t *Trip) HasActivity(activityID uuid.UUID) bool {
+	for _, day := range t.Itinerary {
+		for _, activity := range day.Activities {
+			if activity.ID == activityID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// linkItinerary links days back to the trip and propagates the time zone to activities,
+// so they can be rendered in local time and checked against lodging without reloading the trip
+func (t *Trip) linkItinerary() {
+	for i := range t.Itinerary {
+		t.Itinerary[i].trip = t
+		for j := range t.Itinerary[i].Activities {
+			t.Itinerary[i].Activities[j].tripTimeZone = t.TimeZone
+		}
+	}
+}
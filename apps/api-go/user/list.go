@@ -0,0 +1,119 @@
+package user
+
+import (
+	"context"
+	"strings"
+
+	"eztrip/api-go/pagination"
+
+	"github.com/google/uuid"
+)
+
+// SortField is a column users can be listed by
+type SortField string
+
+const (
+	SortFieldCreatedAt SortField = "created_at"
+	SortFieldLastName  SortField = "last_name"
+	SortFieldEmail     SortField = "email"
+)
+
+// ListFilter narrows the user list. Search matches names and emails; Role matches
+// users assigned the RBAC role.
+type ListFilter struct {
+	Search *string `json:"search" validate:"omitempty,max=255"`
+	Role   *string `json:"role" validate:"omitempty,min=1,max=50"`
+}
+
+// ListOrder is the sort order of a user list
+type ListOrder struct {
+	Field     SortField            `json:"field" validate:"required,oneof=created_at last_name email"`
+	Direction pagination.Direction `json:"direction" validate:"required,oneof=asc desc"`
+}
+
+// Connection is a page of users
+type Connection struct {
+	Edges      []*Edge
+	PageInfo   *pagination.PageInfo
+	TotalCount int
+}
+
+// Edge is a user with its cursor
+type Edge struct {
+	Cursor string
+	Node   *User
+}
+
+// Nodes returns the users of the page without their cursors
+func (c *Connection) Nodes() []*User {
+	nodes := make([]*User, len(c.Edges))
+	for i, edge := range c.Edges {
+		nodes[i] = edge.Node
+	}
+	return nodes
+}
+
+// DefaultListOrder lists the newest users first
+var DefaultListOrder = ListOrder{Field: SortFieldCreatedAt, Direction: pagination.Desc}
+
+// List returns a page of users. Service accounts are listed on their own.
+func (s *Service) List(ctx context.Context, params pagination.Params, filter ListFilter, order ListOrder) (*Connection, error) {
+	query := s.db.WithContext(ctx).Model(&User{}).Where("service_account = ?", false)
+
+	if filter.Search != nil && strings.TrimSpace(*filter.Search) != "" {
+		pattern := "%" + escapeLike(strings.TrimSpace(*filter.Search)) + "%"
+		query = query.Where(
+			"first_name ILIKE ? OR last_name ILIKE ? OR email ILIKE ? OR CONCAT(first_name, ' ', last_name) ILIKE ?",
+			pattern, pattern, pattern, pattern,
+		)
+	}
+
+	// Role assignments are Casbin grouping rules keyed by the user's UUID
+	if filter.Role != nil {
+		query = query.Where("id::text IN (?)",
+			s.db.Table("casbin_rule").Select("v0").Where("ptype = ? AND v1 = ?", "g", *filter.Role),
+		)
+	}
+
+	page, err := pagination.Paginate(query, params, order.pageOrder(), order.key, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	connection := &Connection{
+		Edges:      make([]*Edge, len(page.Items)),
+		PageInfo:   &page.PageInfo,
+		TotalCount: int(page.TotalCount),
+	}
+	for i := range page.Items {
+		connection.Edges[i] = &Edge{Cursor: page.Cursors[i], Node: &page.Items[i]}
+	}
+
+	return connection, nil
+}
+
+// pageOrder maps the sort field to its column
+func (o ListOrder) pageOrder() pagination.Order {
+	kind := pagination.KindString
+	if o.Field == SortFieldCreatedAt {
+		kind = pagination.KindTime
+	}
+	return pagination.Order{Column: "users." + string(o.Field), Kind: kind, Direction: o.Direction}
+}
+
+// key returns the sort value of a user for its cursor
+func (o ListOrder) key(u *User) (any, uuid.UUID) {
+	switch o.Field {
+	case SortFieldLastName:
+		return u.LastName, u.ID
+	case SortFieldEmail:
+		return u.Email, u.ID
+	default:
+		return u.CreatedAt, u.ID
+	}
+}
+
+// escapeLike escapes LIKE wildcards in user input
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
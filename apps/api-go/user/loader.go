@@ -0,0 +1,37 @@
+package user
+
+import (
+	"context"
+
+	"eztrip/api-go/dataloader"
+	appErrors "eztrip/api-go/errors"
+	"eztrip/api-go/logger"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type byIDLoaderKey struct{}
+
+// LoadByID returns a user by ID, batching lookups made by other resolvers of the same
+// request. Returns nil when the user doesn't exist or was deleted.
+func (s *Service) LoadByID(ctx context.Context, id uuid.UUID) (*User, error) {
+	return dataloader.For(ctx, byIDLoaderKey{}, s.fetchByIDs).Load(ctx, id)
+}
+
+func (s *Service) fetchByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*User, error) {
+	var found []User
+	if err := s.db.WithContext(ctx).Where("id IN ?", ids).Find(&found).Error; err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"user_count": len(ids),
+			"error":      err.Error(),
+		}).Error("Failed to fetch users by ID")
+		return nil, appErrors.Internal("Failed to fetch users")
+	}
+
+	users := make(map[uuid.UUID]*User, len(found))
+	for i := range found {
+		users[found[i].ID] = &found[i]
+	}
+	return users, nil
+}
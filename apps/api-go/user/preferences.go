@@ -0,0 +1,133 @@
+package user
+
+import (
+	"context"
+	"strings"
+
+	appErrors "eztrip/api-go/errors"
+	"eztrip/api-go/logger"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// Units is the measurement system distances, weights and temperatures are given in
+type Units string
+
+const (
+	UnitsMetric   Units = "metric"
+	UnitsImperial Units = "imperial"
+)
+
+// Preferences are optional profile details that personalize AI suggestions and the
+// defaults of new trips. They are only shown to the user themselves.
+type Preferences struct {
+	HomeCity           *string  `json:"homeCity" gorm:"column:home_city"`
+	HomeTimeZone       *string  `json:"homeTimeZone" gorm:"column:home_time_zone"`          // IANA time zone, e.g. Europe/Berlin
+	PreferredCurrency  *string  `json:"preferredCurrency" gorm:"column:preferred_currency"` // ISO 4217 code
+	Units              *Units   `json:"units" gorm:"column:units"`
+	Language           *string  `json:"language" gorm:"column:language"` // BCP 47 language tag, e.g. en-GB
+	DietaryNeeds       []string `json:"dietaryNeeds" gorm:"column:dietary_needs;serializer:json;default:'[]'"`
+	AccessibilityNeeds []string `json:"accessibilityNeeds" gorm:"column:accessibility_needs;serializer:json;default:'[]'"`
+	TravelInterests    []string `json:"travelInterests" gorm:"column:travel_interests;serializer:json;default:'[]'"`
+}
+
+// IsEmpty checks if no preference is set
+func (p Preferences) IsEmpty() bool {
+	return p.HomeCity == nil && p.HomeTimeZone == nil && p.PreferredCurrency == nil &&
+		p.Units == nil && p.Language == nil && len(p.DietaryNeeds) == 0 &&
+		len(p.AccessibilityNeeds) == 0 && len(p.TravelInterests) == 0
+}
+
+// PreferencesInput replaces all of a user's preferences. Omitted fields are cleared.
+type PreferencesInput struct {
+	HomeCity           *string  `json:"homeCity" validate:"omitempty,max=100"`
+	HomeTimeZone       *string  `json:"homeTimeZone" validate:"omitempty,timezone"`
+	PreferredCurrency  *string  `json:"preferredCurrency" validate:"omitempty,iso4217"`
+	Units              *Units   `json:"units" validate:"omitempty,oneof=metric imperial"`
+	Language           *string  `json:"language" validate:"omitempty,bcp47_language_tag"`
+	DietaryNeeds       []string `json:"dietaryNeeds" validate:"max=20,dive,max=100"`
+	AccessibilityNeeds []string `json:"accessibilityNeeds" validate:"max=20,dive,max=100"`
+	TravelInterests    []string `json:"travelInterests" validate:"max=20,dive,max=100"`
+}
+
+// toPreferences trims the input, leaving blank values unset and dropping blank and
+// repeated list entries
+func (input PreferencesInput) toPreferences() Preferences {
+	return Preferences{
+		HomeCity:           trimmedOrNil(input.HomeCity),
+		HomeTimeZone:       trimmedOrNil(input.HomeTimeZone),
+		PreferredCurrency:  trimmedOrNil(input.PreferredCurrency),
+		Units:              input.Units,
+		Language:           trimmedOrNil(input.Language),
+		DietaryNeeds:       cleanList(input.DietaryNeeds),
+		AccessibilityNeeds: cleanList(input.AccessibilityNeeds),
+		TravelInterests:    cleanList(input.TravelInterests),
+	}
+}
+
+// UpdatePreferences replaces the current user's preferences
+func (s *Service) UpdatePreferences(ctx context.Context, input PreferencesInput) (*User, error) {
+	current, userID, err := GetAuthenticatedUser(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+
+	preferences := input.toPreferences()
+	err = s.db.WithContext(ctx).Model(current).Select(
+		"home_city", "home_time_zone", "preferred_currency", "units", "language",
+		"dietary_needs", "accessibility_needs", "travel_interests",
+	).Updates(&User{Preferences: preferences}).Error
+	if err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"user_id": userID,
+			"error":   err.Error(),
+		}).Error("Failed to update user preferences")
+		return nil, appErrors.Internal("Failed to update preferences")
+	}
+	current.Preferences = preferences
+
+	logger.Log.WithField("user_id", userID).Info("User preferences updated successfully")
+	return current, nil
+}
+
+// ClearedPreferenceColumns returns the column values that remove every preference, for
+// callers that update users with a column map
+func ClearedPreferenceColumns() map[string]interface{} {
+	return map[string]interface{}{
+		"home_city":           nil,
+		"home_time_zone":      nil,
+		"preferred_currency":  nil,
+		"units":               nil,
+		"language":            nil,
+		"dietary_needs":       gorm.Expr("'[]'::jsonb"),
+		"accessibility_needs": gorm.Expr("'[]'::jsonb"),
+		"travel_interests":    gorm.Expr("'[]'::jsonb"),
+	}
+}
+
+func trimmedOrNil(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*value)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
+
+func cleanList(values []string) []string {
+	cleaned := []string{}
+	seen := make(map[string]bool, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		key := strings.ToLower(value)
+		if value == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		cleaned = append(cleaned, value)
+	}
+	return cleaned
+}
@@ -0,0 +1,199 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	appErrors "eztrip/api-go/errors"
+	"eztrip/api-go/logger"
+	"eztrip/api-go/rbac"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"gorm.io/gorm"
+)
+
+// Profile is what an access token says about the user it was issued to
+type Profile struct {
+	Auth0ID       string
+	Email         string
+	EmailVerified bool
+	FirstName     string
+	LastName      string
+	// Name is the full display name, used when the given and family names are missing
+	Name string
+}
+
+// Provision returns the user an Auth0 ID belongs to, creating it on the first login.
+// An existing user without an Auth0 ID is linked when the profile's email matches and
+// is verified. Users without a role get the default one.
+func (s *Service) Provision(ctx context.Context, enforcer *casbin.SyncedEnforcer, profile Profile) (*User, error) {
+	if existing, err := s.findByAuth0ID(ctx, profile.Auth0ID); err != nil || existing != nil {
+		return existing, err
+	}
+
+	profile = s.completeProfile(profile)
+	if profile.Email == "" {
+		logger.Log.WithField("auth0_user_id", profile.Auth0ID).Warn("Cannot provision user without an email")
+		return nil, appErrors.Unauthorized("User profile has no email address")
+	}
+
+	var provisioned *User
+	created := false
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing User
+		err := tx.Where("LOWER(email) = LOWER(?)", profile.Email).First(&existing).Error
+		switch {
+		case err == nil:
+			linked, err := linkByEmail(tx, &existing, profile)
+			provisioned = linked
+			return err
+		case !errors.Is(err, gorm.ErrRecordNotFound):
+			return err
+		}
+
+		newUser := User{
+			Auth0UserID: &profile.Auth0ID,
+			FirstName:   profile.FirstName,
+			LastName:    profile.LastName,
+			Email:       profile.Email,
+		}
+		if err := tx.Create(&newUser).Error; err != nil {
+			return err
+		}
+		provisioned = &newUser
+		created = true
+		return nil
+	})
+	if err != nil {
+		var gqlErr *gqlerror.Error
+		if errors.As(err, &gqlErr) {
+			return nil, err
+		}
+		// A concurrent request for the same login may have provisioned the user first
+		if existing, findErr := s.findByAuth0ID(ctx, profile.Auth0ID); findErr == nil && existing != nil {
+			return existing, nil
+		}
+		logger.Log.WithFields(logrus.Fields{
+			"auth0_user_id": profile.Auth0ID,
+			"error":         err,
+		}).Error("Failed to provision user")
+		return nil, appErrors.Internal("Failed to provision user")
+	}
+
+	if err := assignDefaultRole(enforcer, provisioned); err != nil {
+		return nil, err
+	}
+
+	logger.Log.WithFields(logrus.Fields{
+		"user_id":       provisioned.ID,
+		"auth0_user_id": profile.Auth0ID,
+		"created":       created,
+	}).Info("User provisioned on first login")
+
+	return provisioned, nil
+}
+
+// assignDefaultRole gives the default role to users that have no role yet, such as
+// users created before their first login without one
+func assignDefaultRole(enforcer *casbin.SyncedEnforcer, u *User) error {
+	roles, err := rbac.GetRolesForUser(enforcer, u.ID.String())
+	if err != nil {
+		return appErrors.Internal("Failed to assign default role")
+	}
+	if len(roles) > 0 {
+		return nil
+	}
+	if err := rbac.AddRoleForUser(enforcer, u.ID.String(), rbac.DefaultRole); err != nil {
+		return appErrors.Internal("Failed to assign default role")
+	}
+	return nil
+}
+
+func (s *Service) findByAuth0ID(ctx context.Context, auth0ID string) (*User, error) {
+	var existing User
+	err := s.db.WithContext(ctx).Where("auth0_user_id = ?", auth0ID).First(&existing).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"auth0_user_id": auth0ID,
+			"error":         err,
+		}).Error("Failed to fetch user by Auth0 ID")
+		return nil, appErrors.Internal("Failed to fetch user")
+	}
+	return &existing, nil
+}
+
+// linkByEmail attaches an Auth0 ID to the user registered with the profile's email
+func linkByEmail(tx *gorm.DB, existing *User, profile Profile) (*User, error) {
+	if !profile.EmailVerified {
+		logger.Log.WithFields(logrus.Fields{
+			"user_id":       existing.ID,
+			"auth0_user_id": profile.Auth0ID,
+		}).Warn("Refusing to link user by unverified email")
+		return nil, appErrors.Forbidden("Verify your email address to sign in to your existing account")
+	}
+	if existing.Auth0UserID != nil && *existing.Auth0UserID != profile.Auth0ID {
+		logger.Log.WithFields(logrus.Fields{
+			"user_id":         existing.ID,
+			"auth0_user_id":   profile.Auth0ID,
+			"linked_auth0_id": *existing.Auth0UserID,
+		}).Warn("Email already linked to another Auth0 account")
+		return nil, DuplicateEmailError()
+	}
+
+	if err := tx.Model(existing).Update("auth0_user_id", profile.Auth0ID).Error; err != nil {
+		return nil, err
+	}
+	existing.Auth0UserID = &profile.Auth0ID
+	return existing, nil
+}
+
+// completeProfile fills in what the token doesn't carry from the identity provider, and
+// derives names when neither has them
+func (s *Service) completeProfile(profile Profile) Profile {
+	if (profile.Email == "" || profile.FirstName == "") && s.directory != nil {
+		auth0User, err := s.directory.GetUser(profile.Auth0ID)
+		if err != nil {
+			logger.Log.WithFields(logrus.Fields{
+				"auth0_user_id": profile.Auth0ID,
+				"error":         err,
+			}).Warn("Failed to fetch identity provider profile")
+		} else {
+			if profile.Email == "" {
+				profile.Email = auth0User.Email
+				profile.EmailVerified = auth0User.EmailVerified
+			}
+			profile.FirstName = firstNonEmpty(profile.FirstName, auth0User.FirstName)
+			profile.LastName = firstNonEmpty(profile.LastName, auth0User.LastName)
+			profile.Name = firstNonEmpty(profile.Name, auth0User.Name)
+		}
+	}
+
+	profile.Email = strings.TrimSpace(profile.Email)
+	if profile.FirstName == "" && profile.LastName == "" {
+		profile.FirstName, profile.LastName = splitName(profile.Name)
+	}
+	if profile.FirstName == "" {
+		profile.FirstName, _, _ = strings.Cut(profile.Email, "@")
+	}
+	return profile
+}
+
+func splitName(name string) (string, string) {
+	first, last, _ := strings.Cut(strings.TrimSpace(name), " ")
+	return first, strings.TrimSpace(last)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
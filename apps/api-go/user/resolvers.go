@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 
-	"eztrip/api-go/rbac"
+	"eztrip/api-go/pagination"
 	"eztrip/api-go/validation"
+
+	"github.com/google/uuid"
 )
 
 type Resolver struct {
@@ -24,48 +26,110 @@ func (r *Resolver) CurrentUser(ctx context.Context) (*User, error) {
 	return r.Service.GetByAuth0ID(ctx, auth0ID)
 }
 
-func (r *Resolver) Users(ctx context.Context) ([]*User, error) {
-	auth0ID := GetUserAuth0ID(ctx)
+// Users returns a page of users. Admin only, enforced by @hasRole in the schema.
+func (r *Resolver) Users(ctx context.Context, first *int32, after *string, filter *ListFilter, orderBy *ListOrder) (*Connection, error) {
+	if filter == nil {
+		filter = &ListFilter{}
+	}
+	if orderBy == nil {
+		orderBy = &DefaultListOrder
+	}
 
-	currentUser, err := r.Service.GetByAuth0ID(ctx, auth0ID)
-	if err != nil {
+	if err := validation.ValidateStruct(*filter); err != nil {
 		return nil, err
 	}
-
-	if err := rbac.RequireAdminRole(ctx, currentUser.ID); err != nil {
+	if err := validation.ValidateStruct(*orderBy); err != nil {
 		return nil, err
 	}
 
-	return r.Service.GetAll(ctx)
+	return r.Service.List(ctx, pagination.Params{First: first, After: after}, *filter, *orderBy)
 }
 
+// User returns a user by ID. Admin only, enforced by @hasRole in the schema.
 func (r *Resolver) User(ctx context.Context, id string) (*User, error) {
-	auth0ID := GetUserAuth0ID(ctx)
+	return r.Service.GetByID(ctx, id)
+}
+
+// Member returns the profile of a user the caller shares a trip with. Access is
+// checked when the trip is loaded, so no admin role is needed.
+func (r *Resolver) Member(ctx context.Context, id uuid.UUID) (*User, error) {
+	return r.Service.LoadByID(ctx, id)
+}
+
+// CreateUser adds a user with a password. Admin only, enforced by @hasRole in the schema.
+func (r *Resolver) CreateUser(ctx context.Context, input CreateUserInput) (*User, error) {
+	if err := validation.ValidateStruct(input); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	return r.Service.Create(ctx, input)
+}
+
+// UpdateMe changes the current user's profile
+func (r *Resolver) UpdateMe(ctx context.Context, input UpdateUserInput) (*User, error) {
+	if err := validation.ValidateStruct(input); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
 
-	currentUser, err := r.Service.GetByAuth0ID(ctx, auth0ID)
+	return r.Service.UpdateMe(ctx, input)
+}
+
+// DeleteMe deletes the current user's account
+func (r *Resolver) DeleteMe(ctx context.Context) (bool, error) {
+	if err := r.Service.DeleteMe(ctx); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// UpdateUser changes a user's profile. Admin only, enforced by @hasRole in the schema.
+func (r *Resolver) UpdateUser(ctx context.Context, id string, input UpdateUserInput) (*User, error) {
+	if err := validation.ValidateStruct(input); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	userID, err := uuid.Parse(id)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := rbac.RequireAdminRole(ctx, currentUser.ID); err != nil {
+	return r.Service.Update(ctx, userID, input)
+}
+
+// DeactivateUser blocks a user. Admin only, enforced by @hasRole in the schema.
+func (r *Resolver) DeactivateUser(ctx context.Context, id string) (*User, error) {
+	userID, err := uuid.Parse(id)
+	if err != nil {
 		return nil, err
 	}
 
-	return r.Service.GetByID(ctx, id)
+	return r.Service.Deactivate(ctx, userID)
 }
 
-func (r *Resolver) CreateUser(ctx context.Context, input CreateUserInput) (*User, error) {
-	if err := validation.ValidateStruct(input); err != nil {
-		return nil, fmt.Errorf("validation failed: %w", err)
+// SetUserRole replaces a user's roles. Admin only, enforced by @hasRole in the schema.
+func (r *Resolver) SetUserRole(ctx context.Context, id string, role string) (*User, error) {
+	userID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
 	}
 
-	return r.Service.Create(ctx, input)
+	return r.Service.SetRole(ctx, userID, role)
 }
 
-func (r *Resolver) UpdateUser(ctx context.Context, input UpdateUserInput) (*User, error) {
+// UpdateMyPreferences replaces the current user's preferences
+func (r *Resolver) UpdateMyPreferences(ctx context.Context, input PreferencesInput) (*User, error) {
 	if err := validation.ValidateStruct(input); err != nil {
 		return nil, fmt.Errorf("validation failed: %w", err)
 	}
 
-	return r.Service.Update(ctx, GetUserAuth0ID(ctx), input)
+	return r.Service.UpdatePreferences(ctx, input)
+}
+
+// Preferences returns a user's preferences to the user themselves, and nil to anyone else
+func (r *Resolver) Preferences(ctx context.Context, obj *User) (*Preferences, error) {
+	auth0ID := GetUserAuth0ID(ctx)
+	if obj.Auth0UserID == nil || auth0ID == "" || *obj.Auth0UserID != auth0ID {
+		return nil, nil
+	}
+	return &obj.Preferences, nil
 }
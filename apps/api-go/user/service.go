@@ -2,43 +2,40 @@ package user
 
 import (
 	"context"
+	"errors"
+	"slices"
 	"strings"
+	"time"
 
-	"eztrip/api-go/auth0"
 	appErrors "eztrip/api-go/errors"
+	"eztrip/api-go/identity"
 	"eztrip/api-go/logger"
+	"eztrip/api-go/rbac"
 
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type Service struct {
-	db          *gorm.DB
-	auth0Client *auth0.Client
+	db        *gorm.DB
+	directory identity.Directory
 }
 
 func NewService(db *gorm.DB) *Service {
-	auth0Client, err := auth0.NewClient()
+	directory, err := identity.NewDefaultDirectory()
 	if err != nil {
-		logger.Log.WithError(err).Error("Failed to initialize Auth0 client")
-		// Continue without Auth0 client - will fail on user creation attempts
+		logger.Log.WithError(err).Error("Failed to initialize identity provider user directory")
+		// Continue without a directory - will fail on user creation attempts
 	}
 
 	return &Service{
-		db:          db,
-		auth0Client: auth0Client,
+		db:        db,
+		directory: directory,
 	}
 }
 
-func (s *Service) GetAll(ctx context.Context) ([]*User, error) {
-	var users []*User
-	if err := s.db.WithContext(ctx).Order("created_at DESC").Find(&users).Error; err != nil {
-		logger.Log.WithError(err).Error("Failed to fetch all users")
-		return nil, appErrors.Internal("Failed to fetch users")
-	}
-	return users, nil
-}
-
 func (s *Service) GetByID(ctx context.Context, id string) (*User, error) {
 	var user User
 	if err := s.db.WithContext(ctx).Where("id = ?", id).First(&user).Error; err != nil {
@@ -81,14 +78,21 @@ func (s *Service) GetCurrent(ctx context.Context) (*User, error) {
 	return &current, nil
 }
 
+// Create adds a user with the default role to the database and the identity provider.
+// The provider account is deleted again if the user can't be saved.
 func (s *Service) Create(ctx context.Context, input CreateUserInput) (*User, error) {
-	if s.auth0Client == nil {
-		return nil, appErrors.Internal("Auth0 client not initialized")
+	if s.directory == nil {
+		return nil, appErrors.Internal("Identity provider not initialized")
+	}
+
+	enforcer, err := rbac.GetEnforcerFromContext(ctx)
+	if err != nil {
+		return nil, appErrors.Internal("Failed to create user")
 	}
 
 	var createdUser *User
 
-	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		user, err := s.createUserInDatabase(tx, input)
 		if err != nil {
 			return err
@@ -104,6 +108,11 @@ func (s *Service) Create(ctx context.Context, input CreateUserInput) (*User, err
 			return err
 		}
 
+		if err := assignDefaultRole(enforcer, user); err != nil {
+			s.cleanupAuth0User(auth0UserID)
+			return err
+		}
+
 		user.Auth0UserID = &auth0UserID
 		createdUser = user
 		return nil
@@ -153,7 +162,7 @@ func (s *Service) checkDuplicateEmail(err error, email string) error {
 }
 
 func (s *Service) createUserInAuth0(input CreateUserInput) (string, error) {
-	auth0User, err := s.auth0Client.CreateUser(input.Email, input.Password, input.FirstName, input.LastName)
+	auth0User, err := s.directory.CreateUser(input.Email, input.Password, input.FirstName, input.LastName)
 	if err != nil {
 		logger.Log.WithFields(logrus.Fields{
 			"email": input.Email,
@@ -161,7 +170,7 @@ func (s *Service) createUserInAuth0(input CreateUserInput) (string, error) {
 		}).Error("Failed to create user in Auth0")
 		return "", appErrors.Internal("Failed to create user account")
 	}
-	return auth0User.UserID, nil
+	return auth0User.ID, nil
 }
 
 func (s *Service) linkAuth0User(tx *gorm.DB, user *User, auth0UserID string) error {
@@ -177,7 +186,7 @@ func (s *Service) linkAuth0User(tx *gorm.DB, user *User, auth0UserID string) err
 }
 
 func (s *Service) cleanupAuth0User(auth0UserID string) {
-	if err := s.auth0Client.DeleteUser(auth0UserID); err != nil {
+	if err := s.directory.DeleteUser(auth0UserID); err != nil {
 		logger.Log.WithFields(logrus.Fields{
 			"auth0_user_id": auth0UserID,
 			"error":         err,
@@ -185,46 +194,320 @@ func (s *Service) cleanupAuth0User(auth0UserID string) {
 	}
 }
 
-func (s *Service) Update(ctx context.Context, auth0ID string, input UpdateUserInput) (*User, error) {
-	var user User
+// UpdateMe changes the current user's profile
+func (s *Service) UpdateMe(ctx context.Context, input UpdateUserInput) (*User, error) {
+	_, userID, err := GetAuthenticatedUser(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+	return s.Update(ctx, userID, input)
+}
+
+// Update changes a user's profile in the database and the identity provider. The
+// provider is updated inside the database transaction, so a provider failure leaves
+// the row unchanged, and a failed commit restores the provider's previous values.
+func (s *Service) Update(ctx context.Context, id uuid.UUID, input UpdateUserInput) (*User, error) {
+	var updated User
+	var previous identity.UserUpdate
+	directoryUpdated := false
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockUser(tx, id, &updated); err != nil {
+			return err
+		}
+		firstName, lastName := updated.FirstName, updated.LastName
+		previous = identity.UserUpdate{FirstName: &firstName, LastName: &lastName}
+
+		if input.FirstName != nil {
+			updated.FirstName = strings.TrimSpace(*input.FirstName)
+		}
+		if input.LastName != nil {
+			updated.LastName = strings.TrimSpace(*input.LastName)
+		}
+
+		err := tx.Model(&updated).Updates(map[string]interface{}{
+			"first_name": updated.FirstName,
+			"last_name":  updated.LastName,
+		}).Error
+		if err != nil {
+			logger.Log.WithFields(logrus.Fields{
+				"id":    id,
+				"error": err,
+			}).Error("Failed to update user")
+			return appErrors.Internal("Failed to update user")
+		}
+
+		change := identity.UserUpdate{FirstName: &updated.FirstName, LastName: &updated.LastName}
+		if err := s.updateInDirectory(&updated, change); err != nil {
+			return err
+		}
+		directoryUpdated = true
+		return nil
+	})
+
+	if err != nil {
+		if directoryUpdated {
+			s.restoreInDirectory(&updated, previous)
+		}
+		return nil, err
+	}
+
+	logger.Log.WithField("id", id).Info("User updated successfully")
+	return &updated, nil
+}
+
+// Deactivate blocks a user, in the database and the identity provider, so they can no
+// longer sign in or use their access tokens. Admins can't deactivate themselves.
+func (s *Service) Deactivate(ctx context.Context, id uuid.UUID) (*User, error) {
+	_, currentID, err := GetAuthenticatedUser(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+	if id == currentID {
+		return nil, appErrors.ValidationError("id", "You can't deactivate your own account")
+	}
+
+	var deactivated User
+	directoryUpdated := false
+	blocked, unblocked := true, false
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockUser(tx, id, &deactivated); err != nil {
+			return err
+		}
+		if deactivated.BlockedAt != nil {
+			return nil
+		}
+
+		now := time.Now()
+		if err := tx.Model(&deactivated).Update("blocked_at", now).Error; err != nil {
+			logger.Log.WithFields(logrus.Fields{
+				"id":    id,
+				"error": err,
+			}).Error("Failed to deactivate user")
+			return appErrors.Internal("Failed to deactivate user")
+		}
+		deactivated.BlockedAt = &now
+
+		if err := s.updateInDirectory(&deactivated, identity.UserUpdate{Blocked: &blocked}); err != nil {
+			return err
+		}
+		directoryUpdated = true
+		return nil
+	})
+
+	if err != nil {
+		if directoryUpdated {
+			s.restoreInDirectory(&deactivated, identity.UserUpdate{Blocked: &unblocked})
+		}
+		return nil, err
+	}
+
+	logger.Log.WithFields(logrus.Fields{
+		"id":             id,
+		"deactivated_by": currentID,
+	}).Info("User deactivated successfully")
+	return &deactivated, nil
+}
+
+// SetRole replaces a user's roles with a role that policies grant permissions to.
+// Admins can't change their own role, so they can't lock themselves out. The change is
+// recorded in the RBAC audit log.
+func (s *Service) SetRole(ctx context.Context, id uuid.UUID, role string) (*User, error) {
+	_, currentID, err := GetAuthenticatedUser(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+	if id == currentID {
+		return nil, appErrors.ValidationError("id", "You can't change your own role")
+	}
+
+	enforcer, err := rbac.GetEnforcerFromContext(ctx)
+	if err != nil {
+		return nil, appErrors.Internal("Failed to set user role")
+	}
+
+	exists, err := rbac.RoleExists(enforcer, role)
+	if err != nil {
+		return nil, appErrors.Internal("Failed to set user role")
+	}
+	if !exists {
+		return nil, appErrors.ValidationError("role", "Unknown role")
+	}
+
+	target, err := s.GetByID(ctx, id.String())
+	if err != nil {
+		return nil, err
+	}
 
-	result := s.db.WithContext(ctx).Model(&user).Where("auth0_user_id = ?", auth0ID).Updates(input)
-	if result.Error != nil {
+	subject := target.ID.String()
+	previous, err := rbac.GetRolesForUser(enforcer, subject)
+	if err != nil {
+		return nil, appErrors.Internal("Failed to set user role")
+	}
+
+	// Audit the roles the user loses and the one they gain
+	var entries []rbac.AuditEntry
+	for _, removed := range previous {
+		if removed != role {
+			entries = append(entries, rbac.AuditEntry{ActorID: &currentID, Change: rbac.ChangeRoleUnassigned, Role: removed, UserID: &target.ID})
+		}
+	}
+	if !slices.Contains(previous, role) {
+		entries = append(entries, rbac.AuditEntry{ActorID: &currentID, Change: rbac.ChangeRoleAssigned, Role: role, UserID: &target.ID})
+	}
+
+	err = rbac.ApplyAudited(s.db.WithContext(ctx), entries,
+		func() error { return rbac.UpdateUserRole(enforcer, subject, role) },
+		func() error { return rbac.SetRolesForUser(enforcer, subject, previous) },
+	)
+	if err != nil {
 		logger.Log.WithFields(logrus.Fields{
-			"auth0_user_id": auth0ID,
-			"error":         result.Error,
-		}).Error("Failed to update user")
-		return nil, appErrors.Internal("Failed to update user")
+			"id":    id,
+			"role":  role,
+			"error": err,
+		}).Error("Failed to set user role")
+		return nil, appErrors.Internal("Failed to set user role")
+	}
+
+	return target, nil
+}
+
+// DeleteMe deletes the current user's account
+func (s *Service) DeleteMe(ctx context.Context) error {
+	_, userID, err := GetAuthenticatedUser(ctx, s.db)
+	if err != nil {
+		return err
 	}
+	return s.Delete(ctx, userID)
+}
 
-	if result.RowsAffected == 0 {
-		return nil, appErrors.NotFound("User")
+// Delete removes a user from the database, the identity provider and their roles.
+// A deleted provider account can't be restored, so it is deleted once the database
+// transaction has committed, and the user is restored if the provider fails.
+func (s *Service) Delete(ctx context.Context, id uuid.UUID) error {
+	enforcer, err := rbac.GetEnforcerFromContext(ctx)
+	if err != nil {
+		return appErrors.Internal("Failed to delete user")
 	}
 
-	if err := s.db.WithContext(ctx).Where("id = ?", &user.ID).First(&user).Error; err != nil {
+	var deleted User
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockUser(tx, id, &deleted); err != nil {
+			return err
+		}
+		if _, err := s.hasDirectoryAccount(&deleted); err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&deleted).Error; err != nil {
+			logger.Log.WithFields(logrus.Fields{
+				"id":    id,
+				"error": err,
+			}).Error("Failed to delete user")
+			return appErrors.Internal("Failed to delete user")
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := s.deleteFromDirectory(&deleted); err != nil {
+		s.restoreDeleted(ctx, &deleted)
+		return err
+	}
+
+	if _, err := enforcer.DeleteRolesForUser(id.String()); err != nil {
 		logger.Log.WithFields(logrus.Fields{
-			"id":    &user.ID,
+			"id":    id,
 			"error": err,
-		}).Error("Failed to fetch updated user")
-		return nil, appErrors.Internal("Failed to fetch updated user")
+		}).Warn("Failed to remove roles of deleted user")
 	}
 
-	logger.Log.WithField("id", &user.ID).Info("User updated successfully")
-	return &user, nil
+	logger.Log.WithField("id", id).Info("User deleted successfully")
+	return nil
 }
 
-func (s *Service) Delete(ctx context.Context, id string) error {
-	result := s.db.WithContext(ctx).Delete(&User{}, "id = ?", id)
-	if result.Error != nil {
+// restoreDeleted undoes a user's deletion after their provider account couldn't be
+// deleted
+func (s *Service) restoreDeleted(ctx context.Context, user *User) {
+	err := s.db.WithContext(ctx).Unscoped().Model(user).Update("deleted_at", nil).Error
+	if err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"id":    user.ID,
+			"error": err,
+		}).Error("Failed to restore user after identity provider failure")
+	}
+}
+
+// lockUser loads a user for the rest of the transaction
+func lockUser(tx *gorm.DB, id uuid.UUID, user *User) error {
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(user, "id = ?", id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return appErrors.NotFound("User")
+		}
 		logger.Log.WithFields(logrus.Fields{
 			"id":    id,
-			"error": result.Error,
-		}).Error("Failed to delete user")
-		return appErrors.Internal("Failed to delete user")
+			"error": err,
+		}).Error("Failed to fetch user by ID")
+		return appErrors.Internal("Failed to fetch user")
 	}
-	if result.RowsAffected == 0 {
-		return appErrors.NotFound("User")
+	return nil
+}
+
+// hasDirectoryAccount reports whether changes to a user must be made at the identity
+// provider too. Service accounts and users who never signed in have no account there.
+func (s *Service) hasDirectoryAccount(user *User) (bool, error) {
+	if user.Auth0UserID == nil || user.ServiceAccount {
+		return false, nil
+	}
+	if s.directory == nil {
+		return false, appErrors.Internal("Identity provider not initialized")
+	}
+	return true, nil
+}
+
+func (s *Service) updateInDirectory(user *User, update identity.UserUpdate) error {
+	if ok, err := s.hasDirectoryAccount(user); !ok {
+		return err
+	}
+	if err := s.directory.UpdateUser(*user.Auth0UserID, update); err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"id":            user.ID,
+			"auth0_user_id": *user.Auth0UserID,
+			"error":         err,
+		}).Error("Failed to update user in Auth0")
+		return appErrors.Internal("Failed to update user account")
+	}
+	return nil
+}
+
+// restoreInDirectory undoes a provider change after the database transaction failed
+func (s *Service) restoreInDirectory(user *User, previous identity.UserUpdate) {
+	if err := s.directory.UpdateUser(*user.Auth0UserID, previous); err != nil {
+		logger.Log.WithFields(logrus.Fields{
+			"id":            user.ID,
+			"auth0_user_id": *user.Auth0UserID,
+			"error":         err,
+		}).Error("Failed to restore Auth0 user after transaction failure")
+	}
+}
+
+func (s *Service) deleteFromDirectory(user *User) error {
+	if ok, err := s.hasDirectoryAccount(user); !ok {
+		return err
+	}
+	err := s.directory.DeleteUser(*user.Auth0UserID)
+	if err != nil && !errors.Is(err, identity.ErrUserNotFound) {
+		logger.Log.WithFields(logrus.Fields{
+			"id":            user.ID,
+			"auth0_user_id": *user.Auth0UserID,
+			"error":         err,
+		}).Error("Failed to delete user in Auth0")
+		return appErrors.Internal("Failed to delete user account")
 	}
-	logger.Log.WithField("id", id).Info("User deleted successfully")
 	return nil
 }
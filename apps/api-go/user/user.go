@@ -48,6 +48,10 @@ func GetAuthenticatedUser(ctx context.Context, db *gorm.DB) (*User, uuid.UUID, e
 		return nil, uuid.Nil, appErrors.Internal("Failed to fetch user")
 	}
 
+	if currentUser.BlockedAt != nil {
+		return nil, uuid.Nil, appErrors.Forbidden("User account is blocked")
+	}
+
 	return &currentUser, currentUser.ID, nil
 }
 
@@ -64,14 +68,20 @@ type UpdateUserInput struct {
 }
 
 type User struct {
-	ID          uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
-	Auth0UserID *string        `json:"-" gorm:"column:auth0_user_id;uniqueIndex"` // Internal only - not exposed to client
-	FirstName   string         `json:"firstName" gorm:"column:first_name;not null"`
-	LastName    string         `json:"lastName" gorm:"column:last_name;not null"`
-	Email       string         `json:"email" gorm:"uniqueIndex;not null"`
-	CreatedAt   time.Time      `json:"createdAt" gorm:"autoCreateTime"`
-	UpdatedAt   time.Time      `json:"updatedAt" gorm:"autoUpdateTime"`
-	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"` // Soft delete support
+	ID                 uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	Auth0UserID        *string        `json:"-" gorm:"column:auth0_user_id;uniqueIndex"` // Internal only - not exposed to client
+	FirstName          string         `json:"firstName" gorm:"column:first_name;not null"`
+	LastName           string         `json:"lastName" gorm:"column:last_name;not null"`
+	Email              string         `json:"email" gorm:"uniqueIndex;not null"`
+	BlockedAt          *time.Time     `json:"-" gorm:"column:blocked_at"`                             // Set while the user is blocked in Auth0
+	ServiceAccount     bool           `json:"-" gorm:"column:service_account;not null;default:false"` // Automation identity that only uses access tokens
+	ErasureRequestedAt *time.Time     `json:"-" gorm:"column:erasure_requested_at"`                   // Set while an erasure request is pending
+	ErasureDueAt       *time.Time     `json:"-" gorm:"column:erasure_due_at"`                         // When the pending erasure runs, after its grace period
+	ErasedAt           *time.Time     `json:"-" gorm:"column:erased_at"`                              // Set once the user's personal data is erased
+	Preferences        Preferences    `json:"-" gorm:"embedded"`                                      // Only exposed to the user themselves
+	CreatedAt          time.Time      `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt          time.Time      `json:"updatedAt" gorm:"autoUpdateTime"`
+	DeletedAt          gorm.DeletedAt `json:"-" gorm:"index"` // Soft delete support
 }
 
 // TableName specifies the table name for GORM
@@ -2,6 +2,7 @@ package validation
 
 import (
 	"fmt"
+	"reflect"
 	"regexp"
 	"strings"
 
@@ -75,7 +76,30 @@ func getErrorMessage(fieldError validator.FieldError) string {
 	case "min":
 		return fmt.Sprintf("%s must be at least %s characters", field, fieldError.Param())
 	case "max":
+		if fieldError.Kind() == reflect.Slice {
+			return fmt.Sprintf("%s must have at most %s entries", field, fieldError.Param())
+		}
 		return fmt.Sprintf("%s must be at most %s characters", field, fieldError.Param())
+	case "required_with":
+		return fmt.Sprintf("%s is required when %s is set", field, fieldError.Param())
+	case "gt":
+		return fmt.Sprintf("%s must be greater than %s", field, fieldError.Param())
+	case "gte":
+		return fmt.Sprintf("%s must be at least %s", field, fieldError.Param())
+	case "uuid":
+		return fmt.Sprintf("%s must be a valid ID", field)
+	case "datetime":
+		return fmt.Sprintf("%s must be formatted as %s", field, fieldError.Param())
+	case "iso4217":
+		return fmt.Sprintf("%s must be a valid ISO 4217 currency code", field)
+	case "oneof":
+		return fmt.Sprintf("%s must be one of: %s", field, fieldError.Param())
+	case "timezone":
+		return fmt.Sprintf("%s must be a valid IANA time zone", field)
+	case "bcp47_language_tag":
+		return fmt.Sprintf("%s must be a valid BCP 47 language tag", field)
+	case "nefield":
+		return fmt.Sprintf("%s must be different from %s", field, fieldError.Param())
 	case "password_complexity":
 		return "Password must contain at least one uppercase letter, one lowercase letter, and one number"
 	default:
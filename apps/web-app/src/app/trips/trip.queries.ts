@@ -2,27 +2,29 @@ import { gql } from 'graphql-tag';
 
 export const GET_TRIPS = gql`
   query GetTrips {
-    trips {
-      id
-      title
-      destination
-      startDate
-      endDate
-      travelers
-      itinerary {
+    trips(first: 100) {
+      nodes {
         id
-        date
-        dayNumber
-        activities {
+        title
+        destination
+        startDate
+        endDate
+        travelers
+        itinerary {
           id
-          time
-          title
-          location
-          category
-          type
-          description
-          notes
-          placeId
+          date
+          dayNumber
+          activities {
+            id
+            time
+            title
+            location
+            category
+            type
+            description
+            notes
+            placeId
+          }
         }
       }
     }
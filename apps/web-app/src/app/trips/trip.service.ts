@@ -7,7 +7,7 @@ import { GET_TRIPS, GET_TRIP, GET_ACTIVITY } from './trip.queries';
 import { Trip, Activity } from '../core/models/trip.model';
 
 interface GetTripsResponse {
-  trips: Trip[];
+  trips: { nodes: Trip[] };
 }
 
 interface GetTripResponse {
@@ -46,7 +46,7 @@ export class TripService {
 
     this.graphql
       .query<GetTripsResponse, void>(GET_TRIPS)
-      .pipe(map((response) => response.trips))
+      .pipe(map((response) => response.trips.nodes))
       .subscribe({
         next: (trips) => {
           this._trips.set(trips);